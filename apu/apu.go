@@ -0,0 +1,371 @@
+package apu
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/maxfierke/gogo-gb/bits"
+	"github.com/maxfierke/gogo-gb/devices"
+	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
+	"github.com/maxfierke/gogo-gb/scheduler"
+)
+
+const (
+	REG_APU_NR10 = 0xFF10 // Channel 1 sweep
+	REG_APU_NR11 = 0xFF11 // Channel 1 length timer & duty cycle
+	REG_APU_NR12 = 0xFF12 // Channel 1 volume & envelope
+	REG_APU_NR13 = 0xFF13 // Channel 1 period low
+	REG_APU_NR14 = 0xFF14 // Channel 1 period high & control
+	REG_APU_NR21 = 0xFF16 // Channel 2 length timer & duty cycle
+	REG_APU_NR22 = 0xFF17 // Channel 2 volume & envelope
+	REG_APU_NR23 = 0xFF18 // Channel 2 period low
+	REG_APU_NR24 = 0xFF19 // Channel 2 period high & control
+	REG_APU_NR30 = 0xFF1A // Channel 3 DAC enable
+	REG_APU_NR31 = 0xFF1B // Channel 3 length timer
+	REG_APU_NR32 = 0xFF1C // Channel 3 output level
+	REG_APU_NR33 = 0xFF1D // Channel 3 period low
+	REG_APU_NR34 = 0xFF1E // Channel 3 period high & control
+	REG_APU_NR41 = 0xFF20 // Channel 4 length timer
+	REG_APU_NR42 = 0xFF21 // Channel 4 volume & envelope
+	REG_APU_NR43 = 0xFF22 // Channel 4 frequency & randomness
+	REG_APU_NR44 = 0xFF23 // Channel 4 control
+	REG_APU_NR50 = 0xFF24 // Master volume & VIN panning
+	REG_APU_NR51 = 0xFF25 // Sound panning
+	REG_APU_NR52 = 0xFF26 // Sound on/off
+
+	REG_APU_START = REG_APU_NR10
+	REG_APU_END   = 0xFF3F
+
+	WAVE_RAM_START = 0xFF30
+	WAVE_RAM_END   = 0xFF3F
+	WAVE_RAM_SIZE  = WAVE_RAM_END - WAVE_RAM_START + 1
+
+	NR52_POWER = 1 << 7
+
+	// The APU is clocked at the same 4 MiHz as the PPU, regardless of CPU speed
+	CLOCK_RATE = 4194304
+)
+
+// Bits that always read back as 1, either because they're write-only or unused
+var regReadMasks = [WAVE_RAM_START - REG_APU_START]byte{
+	0x80, 0x3F, 0x00, 0xFF, 0xBF, // NR10-NR14
+	0xFF, 0x3F, 0x00, 0xFF, 0xBF, // NR20-NR24
+	0x7F, 0xFF, 0x9F, 0xFF, 0xBF, // NR30-NR34
+	0xFF, 0xFF, 0x00, 0x00, 0xBF, // NR40-NR44
+	0x00, 0x00, 0x70, // NR50-NR52
+	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, // Unused
+}
+
+type APU struct {
+	regs [WAVE_RAM_START - REG_APU_START]byte
+
+	ch1 *pulseChannel
+	ch2 *pulseChannel
+	ch3 *waveChannel
+	ch4 *noiseChannel
+
+	frameSequencer frameSequencer
+	resampler      *resampler
+
+	enabled    bool
+	color      bool
+	divAPUSkip bool
+
+	// The APU runs at the same rate regardless of CPU speed
+	baseClock scheduler.BaseClock
+}
+
+var (
+	_ mem.MemHandler       = (*APU)(nil)
+	_ savestate.Serializer = (*APU)(nil)
+)
+
+func NewAPU() *APU {
+	return &APU{
+		ch1: newPulseChannel(true),
+		ch2: newPulseChannel(false),
+		ch3: newWaveChannel(),
+		ch4: newNoiseChannel(),
+	}
+}
+
+func (apu *APU) AttachOutput(output devices.AudioOutput) {
+	if output == nil {
+		apu.resampler = nil
+
+		return
+	}
+
+	apu.resampler = newResampler(output)
+}
+
+// ClockDivAPU is called on each falling edge of DIV bit 4, which drives the
+// frame sequencer. In double-speed mode, bit 5 is used instead, so every other
+// edge is ignored.
+func (apu *APU) ClockDivAPU() {
+	if !apu.enabled {
+		return
+	}
+
+	if apu.baseClock.DoubleSpeed {
+		apu.divAPUSkip = !apu.divAPUSkip
+		if apu.divAPUSkip {
+			return
+		}
+	}
+
+	apu.frameSequencer.step(apu)
+}
+
+func (apu *APU) EnableColor() {
+	apu.color = true
+}
+
+func (apu *APU) IsEnabled() bool {
+	return apu.enabled
+}
+
+// LoadState restores the registers & channels. The resampler's filter state
+// belongs to the attached output, and isn't included.
+func (apu *APU) LoadState(r io.Reader) error {
+	return savestate.Read(r, apu.stateFields()...)
+}
+
+// Sample returns the current mixed output of all channels, panned via NR51 and
+// scaled by the NR50 master volume, as left/right amplitudes in [-1.0, 1.0]
+func (apu *APU) Sample() (float32, float32) {
+	if !apu.enabled {
+		return 0, 0
+	}
+
+	nr50 := apu.regs[REG_APU_NR50-REG_APU_START]
+	nr51 := apu.regs[REG_APU_NR51-REG_APU_START]
+
+	outputs := [4]float32{
+		dacOutput(apu.ch1.dacEnabled(), apu.ch1.output()),
+		dacOutput(apu.ch2.dacEnabled(), apu.ch2.output()),
+		dacOutput(apu.ch3.dacEnabled, apu.ch3.output()),
+		dacOutput(apu.ch4.dacEnabled(), apu.ch4.output()),
+	}
+
+	var left, right float32
+	for i, output := range outputs {
+		if bits.Read(nr51, uint8(i)) == 1 {
+			right += output
+		}
+
+		if bits.Read(nr51, uint8(i+4)) == 1 {
+			left += output
+		}
+	}
+
+	leftVolume := float32(((nr50>>4)&0x7)+1) / 8
+	rightVolume := float32((nr50&0x7)+1) / 8
+
+	return left / 4 * leftVolume, right / 4 * rightVolume
+}
+
+func (apu *APU) SaveState(w io.Writer) error {
+	return savestate.Write(w, apu.stateFields()...)
+}
+
+func (apu *APU) SetDoubleSpeed(enabled bool) {
+	apu.baseClock.DoubleSpeed = enabled
+}
+
+func (apu *APU) Step(cycles uint8) {
+	dots := apu.baseClock.Cycles(cycles)
+
+	if apu.enabled {
+		apu.ch1.step(dots)
+		apu.ch2.step(dots)
+		apu.ch3.step(dots)
+		apu.ch4.step(dots)
+	}
+
+	if apu.resampler != nil {
+		left, right := apu.Sample()
+		apu.resampler.push(left, right, dots)
+	}
+}
+
+func (apu *APU) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
+	if addr >= WAVE_RAM_START && addr <= WAVE_RAM_END {
+		return mem.ReadReplace(apu.ch3.readWaveRAM(addr - WAVE_RAM_START))
+	}
+
+	if addr < REG_APU_START || addr > REG_APU_END {
+		return mem.ReadPassthrough()
+	}
+
+	regIdx := addr - REG_APU_START
+
+	if addr == REG_APU_NR52 {
+		value := regReadMasks[regIdx]
+
+		if apu.enabled {
+			value |= NR52_POWER
+		}
+
+		channels := [4]bool{apu.ch1.enabled, apu.ch2.enabled, apu.ch3.enabled, apu.ch4.enabled}
+		for i, enabled := range channels {
+			if enabled {
+				value |= 1 << i
+			}
+		}
+
+		return mem.ReadReplace(value)
+	}
+
+	return mem.ReadReplace(apu.regs[regIdx] | regReadMasks[regIdx])
+}
+
+func (apu *APU) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
+	if addr >= WAVE_RAM_START && addr <= WAVE_RAM_END {
+		apu.ch3.writeWaveRAM(addr-WAVE_RAM_START, value)
+
+		return mem.WriteBlock()
+	}
+
+	if addr < REG_APU_START || addr > REG_APU_END {
+		panic(fmt.Sprintf("Attempting to write 0x%02X @ 0x%04X, which is out-of-bounds for APU", value, addr))
+	}
+
+	if addr == REG_APU_NR52 {
+		apu.setPower((value & NR52_POWER) != 0)
+
+		return mem.WriteBlock()
+	}
+
+	if !apu.enabled {
+		// On DMG, the length timers are still writable while powered off
+		if !apu.color {
+			switch addr {
+			case REG_APU_NR11:
+				apu.ch1.writeLength(value)
+			case REG_APU_NR21:
+				apu.ch2.writeLength(value)
+			case REG_APU_NR31:
+				apu.ch3.writeLength(value)
+			case REG_APU_NR41:
+				apu.ch4.writeLength(value)
+			}
+		}
+
+		return mem.WriteBlock()
+	}
+
+	apu.regs[addr-REG_APU_START] = value
+
+	nextClocksLength := apu.frameSequencer.nextStepClocksLength()
+
+	switch addr {
+	case REG_APU_NR10:
+		apu.ch1.writeSweep(value)
+	case REG_APU_NR11:
+		apu.ch1.writeDutyLength(value)
+	case REG_APU_NR12:
+		apu.ch1.writeEnvelope(value)
+	case REG_APU_NR13:
+		apu.ch1.writePeriodLow(value)
+	case REG_APU_NR14:
+		apu.ch1.writeControl(value, nextClocksLength)
+	case REG_APU_NR21:
+		apu.ch2.writeDutyLength(value)
+	case REG_APU_NR22:
+		apu.ch2.writeEnvelope(value)
+	case REG_APU_NR23:
+		apu.ch2.writePeriodLow(value)
+	case REG_APU_NR24:
+		apu.ch2.writeControl(value, nextClocksLength)
+	case REG_APU_NR30:
+		apu.ch3.writeDAC(value)
+	case REG_APU_NR31:
+		apu.ch3.writeLength(value)
+	case REG_APU_NR32:
+		apu.ch3.writeOutputLevel(value)
+	case REG_APU_NR33:
+		apu.ch3.writePeriodLow(value)
+	case REG_APU_NR34:
+		apu.ch3.writeControl(value, nextClocksLength)
+	case REG_APU_NR41:
+		apu.ch4.writeLength(value)
+	case REG_APU_NR42:
+		apu.ch4.writeEnvelope(value)
+	case REG_APU_NR43:
+		apu.ch4.writeFrequency(value)
+	case REG_APU_NR44:
+		apu.ch4.writeControl(value, nextClocksLength)
+	default:
+		// NR50, NR51 & unused registers only need to be stored
+	}
+
+	return mem.WriteBlock()
+}
+
+func (apu *APU) setPower(enabled bool) {
+	if enabled == apu.enabled {
+		return
+	}
+
+	if !enabled {
+		// Powering off clears every register except wave RAM
+		apu.regs = [len(apu.regs)]byte{}
+
+		apu.ch1.reset(apu.color)
+		apu.ch2.reset(apu.color)
+		apu.ch3.reset(apu.color)
+		apu.ch4.reset(apu.color)
+	} else {
+		apu.frameSequencer.reset()
+		apu.divAPUSkip = false
+	}
+
+	apu.enabled = enabled
+}
+
+func (apu *APU) clockLength() {
+	apu.ch1.length.clock(&apu.ch1.enabled)
+	apu.ch2.length.clock(&apu.ch2.enabled)
+	apu.ch3.length.clock(&apu.ch3.enabled)
+	apu.ch4.length.clock(&apu.ch4.enabled)
+}
+
+func (apu *APU) clockSweep() {
+	apu.ch1.clockSweep()
+}
+
+func (apu *APU) clockEnvelope() {
+	apu.ch1.envelope.clock()
+	apu.ch2.envelope.clock()
+	apu.ch4.envelope.clock()
+}
+
+// The DAC converts a digital 0x0-0xF value to an analog -1.0-1.0 value. When
+// disabled, it outputs nothing.
+func (apu *APU) stateFields() []any {
+	fields := []any{
+		&apu.regs,
+		&apu.frameSequencer.nextStep,
+		&apu.enabled,
+		&apu.baseClock.DoubleSpeed,
+		&apu.divAPUSkip,
+		&apu.baseClock.Carry,
+	}
+
+	fields = append(fields, apu.ch1.stateFields()...)
+	fields = append(fields, apu.ch2.stateFields()...)
+	fields = append(fields, apu.ch3.stateFields()...)
+	fields = append(fields, apu.ch4.stateFields()...)
+
+	return fields
+}
+
+func dacOutput(enabled bool, value uint8) float32 {
+	if !enabled {
+		return 0
+	}
+
+	return 1.0 - float32(value)/7.5
+}
@@ -0,0 +1,83 @@
+package apu
+
+import (
+	"testing"
+
+	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/stretchr/testify/assert"
+)
+
+var NULL_MMU = mem.NewMMU([]byte{})
+
+func TestAPURegisterReadMasks(t *testing.T) {
+	assert := assert.New(t)
+
+	apu := NewAPU()
+	apu.OnWrite(NULL_MMU, REG_APU_NR52, NR52_POWER)
+
+	for addr := uint16(REG_APU_START); addr < WAVE_RAM_START; addr++ {
+		if addr == REG_APU_NR52 {
+			continue
+		}
+
+		apu.OnWrite(NULL_MMU, addr, 0x00)
+		read := apu.OnRead(NULL_MMU, addr)
+		assert.Equal(mem.ReadReplace(regReadMasks[addr-REG_APU_START]), read, "reading 0x%04X", addr)
+	}
+
+	assert.Equal(mem.ReadReplace(0xF0), apu.OnRead(NULL_MMU, REG_APU_NR52))
+}
+
+func TestAPUPowerOff(t *testing.T) {
+	assert := assert.New(t)
+
+	apu := NewAPU()
+	apu.OnWrite(NULL_MMU, REG_APU_NR52, NR52_POWER)
+	apu.OnWrite(NULL_MMU, REG_APU_NR50, 0x77)
+	apu.OnWrite(NULL_MMU, WAVE_RAM_START, 0xAB)
+
+	apu.OnWrite(NULL_MMU, REG_APU_NR52, 0x00)
+	assert.Equal(mem.ReadReplace(0x70), apu.OnRead(NULL_MMU, REG_APU_NR52))
+	assert.Equal(mem.ReadReplace(0x00), apu.OnRead(NULL_MMU, REG_APU_NR50))
+
+	// Writes are ignored while powered off
+	apu.OnWrite(NULL_MMU, REG_APU_NR50, 0x77)
+	assert.Equal(mem.ReadReplace(0x00), apu.OnRead(NULL_MMU, REG_APU_NR50))
+
+	// ...but wave RAM is left alone
+	assert.Equal(mem.ReadReplace(0xAB), apu.OnRead(NULL_MMU, WAVE_RAM_START))
+}
+
+func TestAPULengthCounter(t *testing.T) {
+	assert := assert.New(t)
+
+	apu := NewAPU()
+	apu.OnWrite(NULL_MMU, REG_APU_NR52, NR52_POWER)
+
+	apu.OnWrite(NULL_MMU, REG_APU_NR22, 0xF0)
+	apu.OnWrite(NULL_MMU, REG_APU_NR21, 0x3E) // 2 length clocks left
+	apu.OnWrite(NULL_MMU, REG_APU_NR24, NRX4_TRIGGER|NRX4_LENGTH_ENABLE)
+	assert.Equal(mem.ReadReplace(0xF2), apu.OnRead(NULL_MMU, REG_APU_NR52))
+
+	// Length is clocked on every other frame sequencer step
+	apu.ClockDivAPU()
+	apu.ClockDivAPU()
+	assert.Equal(mem.ReadReplace(0xF2), apu.OnRead(NULL_MMU, REG_APU_NR52))
+
+	apu.ClockDivAPU()
+	assert.Equal(mem.ReadReplace(0xF0), apu.OnRead(NULL_MMU, REG_APU_NR52))
+}
+
+func TestAPUDACDisablesChannel(t *testing.T) {
+	assert := assert.New(t)
+
+	apu := NewAPU()
+	apu.OnWrite(NULL_MMU, REG_APU_NR52, NR52_POWER)
+
+	apu.OnWrite(NULL_MMU, REG_APU_NR12, 0xF0)
+	apu.OnWrite(NULL_MMU, REG_APU_NR14, NRX4_TRIGGER)
+	assert.Equal(mem.ReadReplace(0xF1), apu.OnRead(NULL_MMU, REG_APU_NR52))
+
+	apu.OnWrite(NULL_MMU, REG_APU_NR12, 0x07)
+	assert.Equal(mem.ReadReplace(0xF0), apu.OnRead(NULL_MMU, REG_APU_NR52))
+}
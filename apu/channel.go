@@ -0,0 +1,129 @@
+package apu
+
+const (
+	NRX4_TRIGGER       = 1 << 7
+	NRX4_LENGTH_ENABLE = 1 << 6
+	NRX4_PERIOD_MASK   = 0x7
+
+	PERIOD_MAX = 2048
+)
+
+type lengthCounter struct {
+	max     uint16
+	counter uint16
+	enabled bool
+}
+
+func (lc *lengthCounter) load(value uint16) {
+	lc.counter = lc.max - value
+}
+
+func (lc *lengthCounter) clock(channelEnabled *bool) {
+	if !lc.enabled || lc.counter == 0 {
+		return
+	}
+
+	lc.counter--
+	if lc.counter == 0 {
+		*channelEnabled = false
+	}
+}
+
+// Handles the length-related side-effects of writing to NRx4, including the
+// extra length clock that occurs when enabling the length counter while the
+// frame sequencer's next step won't clock it.
+func (lc *lengthCounter) stateFields() []any {
+	return []any{&lc.counter, &lc.enabled}
+}
+
+func (lc *lengthCounter) writeControl(value byte, nextClocksLength bool, channelEnabled *bool) {
+	wasEnabled := lc.enabled
+	lc.enabled = (value & NRX4_LENGTH_ENABLE) != 0
+	trigger := (value & NRX4_TRIGGER) != 0
+
+	if !wasEnabled && lc.enabled && !nextClocksLength && lc.counter != 0 {
+		lc.counter--
+
+		if lc.counter == 0 && !trigger {
+			*channelEnabled = false
+		}
+	}
+
+	if trigger && lc.counter == 0 {
+		lc.counter = lc.max
+
+		if lc.enabled && !nextClocksLength {
+			lc.counter--
+		}
+	}
+}
+
+type volumeEnvelope struct {
+	initialVolume uint8
+	increase      bool
+	period        uint8
+
+	volume uint8
+	timer  uint8
+}
+
+func (env *volumeEnvelope) clock() {
+	if env.period == 0 {
+		return
+	}
+
+	if env.timer > 0 {
+		env.timer--
+	}
+
+	if env.timer != 0 {
+		return
+	}
+
+	env.timer = env.period
+
+	if env.increase && env.volume < 0xF {
+		env.volume++
+	} else if !env.increase && env.volume > 0 {
+		env.volume--
+	}
+}
+
+func (env *volumeEnvelope) read() byte {
+	value := (env.initialVolume << 4) | env.period
+	if env.increase {
+		value |= 0x8
+	}
+
+	return value
+}
+
+func (env *volumeEnvelope) trigger() {
+	env.volume = env.initialVolume
+	env.timer = env.period
+	if env.timer == 0 {
+		env.timer = 8
+	}
+}
+
+func (env *volumeEnvelope) write(value byte) {
+	env.initialVolume = value >> 4
+	env.increase = (value & 0x8) != 0
+	env.period = value & 0x7
+}
+
+// The DAC for a channel w/ an envelope is powered whenever the upper 5 bits of
+// NRx2 are non-zero
+func (env *volumeEnvelope) dacEnabled() bool {
+	return (env.read() & 0xF8) != 0
+}
+
+func (env *volumeEnvelope) stateFields() []any {
+	return []any{
+		&env.initialVolume,
+		&env.increase,
+		&env.period,
+		&env.volume,
+		&env.timer,
+	}
+}
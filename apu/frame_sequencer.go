@@ -0,0 +1,40 @@
+package apu
+
+// The frame sequencer is clocked at 512 Hz by DIV-APU and drives the length,
+// sweep and envelope units of each channel:
+//
+//	Step   Length Ctr  Vol Env     Sweep
+//	---------------------------------------
+//	0      Clock       -           -
+//	1      -           -           -
+//	2      Clock       -           Clock
+//	3      -           -           -
+//	4      Clock       -           -
+//	5      -           -           -
+//	6      Clock       -           Clock
+//	7      -           Clock       -
+type frameSequencer struct {
+	nextStep uint8
+}
+
+func (fs *frameSequencer) nextStepClocksLength() bool {
+	return fs.nextStep%2 == 0
+}
+
+func (fs *frameSequencer) reset() {
+	fs.nextStep = 0
+}
+
+func (fs *frameSequencer) step(apu *APU) {
+	switch fs.nextStep {
+	case 0, 4:
+		apu.clockLength()
+	case 2, 6:
+		apu.clockLength()
+		apu.clockSweep()
+	case 7:
+		apu.clockEnvelope()
+	}
+
+	fs.nextStep = (fs.nextStep + 1) % 8
+}
@@ -0,0 +1,143 @@
+package apu
+
+const (
+	NOISE_LENGTH_MAX = 64
+
+	NR43_CLOCK_SHIFT_MASK = 0xF0
+	NR43_LFSR_WIDTH       = 1 << 3
+	NR43_DIVISOR_MASK     = 0x7
+
+	NOISE_LFSR_INIT = 0x7FFF
+)
+
+type noiseChannel struct {
+	enabled  bool
+	length   lengthCounter
+	envelope volumeEnvelope
+
+	clockShift  uint8
+	narrowLFSR  bool
+	divisorCode uint8
+
+	lfsr  uint16
+	timer uint
+}
+
+func newNoiseChannel() *noiseChannel {
+	return &noiseChannel{
+		length: lengthCounter{max: NOISE_LENGTH_MAX},
+		lfsr:   NOISE_LFSR_INIT,
+	}
+}
+
+func (ch *noiseChannel) dacEnabled() bool {
+	return ch.envelope.dacEnabled()
+}
+
+func (ch *noiseChannel) output() uint8 {
+	if !ch.enabled {
+		return 0
+	}
+
+	return uint8(^ch.lfsr&0x1) * ch.envelope.volume
+}
+
+func (ch *noiseChannel) reset(color bool) {
+	length := ch.length
+
+	*ch = noiseChannel{
+		length: lengthCounter{max: NOISE_LENGTH_MAX},
+		lfsr:   NOISE_LFSR_INIT,
+	}
+
+	if !color {
+		// Length counters are unaffected by power on DMG
+		ch.length.counter = length.counter
+	}
+}
+
+func (ch *noiseChannel) period() uint {
+	divisor := uint(ch.divisorCode) * 16
+	if divisor == 0 {
+		divisor = 8
+	}
+
+	return divisor << ch.clockShift
+}
+
+func (ch *noiseChannel) stateFields() []any {
+	fields := []any{
+		&ch.enabled,
+		&ch.clockShift,
+		&ch.narrowLFSR,
+		&ch.divisorCode,
+		&ch.lfsr,
+		&ch.timer,
+	}
+
+	fields = append(fields, ch.length.stateFields()...)
+	fields = append(fields, ch.envelope.stateFields()...)
+
+	return fields
+}
+
+func (ch *noiseChannel) step(dots uint) {
+	// Clock shifts of 14 & 15 result in the LFSR receiving no clocks
+	if !ch.enabled || ch.clockShift >= 14 {
+		return
+	}
+
+	for dots > 0 {
+		if ch.timer > dots {
+			ch.timer -= dots
+
+			return
+		}
+
+		dots -= ch.timer
+		ch.timer = ch.period()
+		ch.clockLFSR()
+	}
+}
+
+func (ch *noiseChannel) clockLFSR() {
+	xor := (ch.lfsr & 0x1) ^ ((ch.lfsr >> 1) & 0x1)
+	ch.lfsr = (ch.lfsr >> 1) | (xor << 14)
+
+	if ch.narrowLFSR {
+		ch.lfsr = (ch.lfsr &^ (1 << 6)) | (xor << 6)
+	}
+}
+
+func (ch *noiseChannel) trigger() {
+	ch.enabled = ch.dacEnabled()
+	ch.timer = ch.period()
+	ch.lfsr = NOISE_LFSR_INIT
+	ch.envelope.trigger()
+}
+
+func (ch *noiseChannel) writeControl(value byte, nextClocksLength bool) {
+	ch.length.writeControl(value, nextClocksLength, &ch.enabled)
+
+	if (value & NRX4_TRIGGER) != 0 {
+		ch.trigger()
+	}
+}
+
+func (ch *noiseChannel) writeEnvelope(value byte) {
+	ch.envelope.write(value)
+
+	if !ch.dacEnabled() {
+		ch.enabled = false
+	}
+}
+
+func (ch *noiseChannel) writeFrequency(value byte) {
+	ch.clockShift = (value & NR43_CLOCK_SHIFT_MASK) >> 4
+	ch.narrowLFSR = (value & NR43_LFSR_WIDTH) != 0
+	ch.divisorCode = value & NR43_DIVISOR_MASK
+}
+
+func (ch *noiseChannel) writeLength(value byte) {
+	ch.length.load(uint16(value & NRX1_LENGTH_MASK))
+}
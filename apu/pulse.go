@@ -0,0 +1,222 @@
+package apu
+
+const (
+	PULSE_LENGTH_MAX = 64
+
+	NR10_PERIOD_MASK = 0x70
+	NR10_NEGATE      = 1 << 3
+	NR10_SHIFT_MASK  = 0x7
+
+	NRX1_DUTY_MASK   = 0xC0
+	NRX1_LENGTH_MASK = 0x3F
+)
+
+var dutyWaveforms = [4][8]uint8{
+	{0, 0, 0, 0, 0, 0, 0, 1}, // 12.5%
+	{1, 0, 0, 0, 0, 0, 0, 1}, // 25%
+	{1, 0, 0, 0, 0, 1, 1, 1}, // 50%
+	{0, 1, 1, 1, 1, 1, 1, 0}, // 75%
+}
+
+type pulseChannel struct {
+	enabled  bool
+	length   lengthCounter
+	envelope volumeEnvelope
+
+	duty     uint8
+	dutyStep uint8
+	period   uint16
+	timer    uint
+
+	hasSweep          bool
+	sweepPeriod       uint8
+	sweepNegate       bool
+	sweepShift        uint8
+	sweepTimer        uint8
+	sweepEnabled      bool
+	sweepNegateUsed   bool
+	sweepShadowPeriod uint16
+}
+
+func newPulseChannel(hasSweep bool) *pulseChannel {
+	return &pulseChannel{
+		length:   lengthCounter{max: PULSE_LENGTH_MAX},
+		hasSweep: hasSweep,
+	}
+}
+
+func (ch *pulseChannel) clockSweep() {
+	if !ch.hasSweep {
+		return
+	}
+
+	if ch.sweepTimer > 0 {
+		ch.sweepTimer--
+	}
+
+	if ch.sweepTimer != 0 {
+		return
+	}
+
+	ch.reloadSweepTimer()
+
+	if !ch.sweepEnabled || ch.sweepPeriod == 0 {
+		return
+	}
+
+	newPeriod := ch.calculateSweep()
+	if newPeriod < PERIOD_MAX && ch.sweepShift != 0 {
+		ch.sweepShadowPeriod = newPeriod
+		ch.period = newPeriod
+
+		// Overflow check is performed again w/ the new period, but not written back
+		ch.calculateSweep()
+	}
+}
+
+func (ch *pulseChannel) dacEnabled() bool {
+	return ch.envelope.dacEnabled()
+}
+
+func (ch *pulseChannel) output() uint8 {
+	if !ch.enabled {
+		return 0
+	}
+
+	return dutyWaveforms[ch.duty][ch.dutyStep] * ch.envelope.volume
+}
+
+func (ch *pulseChannel) reset(color bool) {
+	length := ch.length
+
+	*ch = pulseChannel{
+		length:   lengthCounter{max: PULSE_LENGTH_MAX},
+		hasSweep: ch.hasSweep,
+	}
+
+	if !color {
+		// Length counters are unaffected by power on DMG
+		ch.length.counter = length.counter
+	}
+}
+
+func (ch *pulseChannel) stateFields() []any {
+	fields := []any{
+		&ch.enabled,
+		&ch.duty,
+		&ch.dutyStep,
+		&ch.period,
+		&ch.timer,
+		&ch.sweepPeriod,
+		&ch.sweepNegate,
+		&ch.sweepShift,
+		&ch.sweepTimer,
+		&ch.sweepEnabled,
+		&ch.sweepNegateUsed,
+		&ch.sweepShadowPeriod,
+	}
+
+	fields = append(fields, ch.length.stateFields()...)
+	fields = append(fields, ch.envelope.stateFields()...)
+
+	return fields
+}
+
+func (ch *pulseChannel) step(dots uint) {
+	for dots > 0 {
+		if ch.timer > dots {
+			ch.timer -= dots
+
+			return
+		}
+
+		dots -= ch.timer
+		ch.timer = (PERIOD_MAX - uint(ch.period)) * 4
+		ch.dutyStep = (ch.dutyStep + 1) % 8
+	}
+}
+
+func (ch *pulseChannel) writeControl(value byte, nextClocksLength bool) {
+	ch.period = (ch.period & 0xFF) | (uint16(value&NRX4_PERIOD_MASK) << 8)
+	ch.length.writeControl(value, nextClocksLength, &ch.enabled)
+
+	if (value & NRX4_TRIGGER) != 0 {
+		ch.trigger()
+	}
+}
+
+func (ch *pulseChannel) writeDutyLength(value byte) {
+	ch.duty = (value & NRX1_DUTY_MASK) >> 6
+	ch.writeLength(value)
+}
+
+func (ch *pulseChannel) writeEnvelope(value byte) {
+	ch.envelope.write(value)
+
+	if !ch.dacEnabled() {
+		ch.enabled = false
+	}
+}
+
+func (ch *pulseChannel) writeLength(value byte) {
+	ch.length.load(uint16(value & NRX1_LENGTH_MASK))
+}
+
+func (ch *pulseChannel) writePeriodLow(value byte) {
+	ch.period = (ch.period & 0x700) | uint16(value)
+}
+
+func (ch *pulseChannel) writeSweep(value byte) {
+	ch.sweepPeriod = (value & NR10_PERIOD_MASK) >> 4
+	ch.sweepShift = value & NR10_SHIFT_MASK
+
+	negate := (value & NR10_NEGATE) != 0
+	if ch.sweepNegate && !negate && ch.sweepNegateUsed {
+		// Leaving negate mode after a negated calculation disables the channel
+		ch.enabled = false
+	}
+
+	ch.sweepNegate = negate
+}
+
+func (ch *pulseChannel) calculateSweep() uint16 {
+	delta := ch.sweepShadowPeriod >> ch.sweepShift
+
+	var newPeriod uint16
+	if ch.sweepNegate {
+		newPeriod = ch.sweepShadowPeriod - delta
+		ch.sweepNegateUsed = true
+	} else {
+		newPeriod = ch.sweepShadowPeriod + delta
+	}
+
+	if newPeriod >= PERIOD_MAX {
+		ch.enabled = false
+	}
+
+	return newPeriod
+}
+
+func (ch *pulseChannel) reloadSweepTimer() {
+	ch.sweepTimer = ch.sweepPeriod
+	if ch.sweepTimer == 0 {
+		ch.sweepTimer = 8
+	}
+}
+
+func (ch *pulseChannel) trigger() {
+	ch.enabled = ch.dacEnabled()
+	ch.timer = (PERIOD_MAX - uint(ch.period)) * 4
+	ch.envelope.trigger()
+
+	if ch.hasSweep {
+		ch.sweepShadowPeriod = ch.period
+		ch.sweepNegateUsed = false
+		ch.reloadSweepTimer()
+		ch.sweepEnabled = ch.sweepPeriod != 0 || ch.sweepShift != 0
+
+		if ch.sweepShift != 0 {
+			ch.calculateSweep()
+		}
+	}
+}
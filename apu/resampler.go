@@ -0,0 +1,75 @@
+package apu
+
+import (
+	"math"
+
+	"github.com/maxfierke/gogo-gb/devices"
+)
+
+// Per-dot charge factor of the high-pass filter capacitor on the DMG's output
+const HIGH_PASS_CHARGE_FACTOR = 0.999958
+
+// resampler box-filters the APU's output at its native rate (one sample per
+// dot) down to the output's sample rate, and then runs it through a high-pass
+// filter to remove the DC offset introduced by the DACs, much like the
+// capacitors on the real hardware.
+type resampler struct {
+	output devices.AudioOutput
+
+	dotsPerSample float64
+	chargeFactor  float64
+
+	dots     float64
+	leftSum  float64
+	rightSum float64
+	leftCap  float64
+	rightCap float64
+}
+
+func newResampler(output devices.AudioOutput) *resampler {
+	dotsPerSample := float64(CLOCK_RATE) / float64(output.SampleRate())
+
+	return &resampler{
+		output:        output,
+		dotsPerSample: dotsPerSample,
+		chargeFactor:  math.Pow(HIGH_PASS_CHARGE_FACTOR, dotsPerSample),
+	}
+}
+
+func (r *resampler) push(left, right float32, dots uint) {
+	remaining := float64(dots)
+
+	for remaining > 0 {
+		span := min(r.dotsPerSample-r.dots, remaining)
+
+		r.leftSum += float64(left) * span
+		r.rightSum += float64(right) * span
+		r.dots += span
+		remaining -= span
+
+		if r.dots >= r.dotsPerSample {
+			r.emit()
+		}
+	}
+}
+
+func (r *resampler) emit() {
+	left := r.leftSum / r.dots
+	right := r.rightSum / r.dots
+
+	r.dots = 0
+	r.leftSum = 0
+	r.rightSum = 0
+
+	leftOut := left - r.leftCap
+	r.leftCap = left - leftOut*r.chargeFactor
+
+	rightOut := right - r.rightCap
+	r.rightCap = right - rightOut*r.chargeFactor
+
+	r.output.WriteSample(toPCM(leftOut), toPCM(rightOut))
+}
+
+func toPCM(value float64) int16 {
+	return int16(max(-1.0, min(1.0, value)) * math.MaxInt16)
+}
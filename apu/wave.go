@@ -0,0 +1,148 @@
+package apu
+
+const (
+	WAVE_LENGTH_MAX = 256
+
+	NR30_DAC_ENABLE        = 1 << 7
+	NR32_OUTPUT_LEVEL_MASK = 0x60
+
+	WAVE_SAMPLES = WAVE_RAM_SIZE * 2
+)
+
+type waveChannel struct {
+	enabled    bool
+	dacEnabled bool
+	length     lengthCounter
+
+	outputLevel  uint8
+	period       uint16
+	timer        uint
+	position     uint8
+	sampleBuffer byte
+
+	waveRAM [WAVE_RAM_SIZE]byte
+}
+
+func newWaveChannel() *waveChannel {
+	return &waveChannel{
+		length: lengthCounter{max: WAVE_LENGTH_MAX},
+	}
+}
+
+func (ch *waveChannel) output() uint8 {
+	if !ch.enabled || ch.outputLevel == 0 {
+		return 0
+	}
+
+	sample := ch.sampleBuffer >> 4
+	if ch.position%2 == 1 {
+		sample = ch.sampleBuffer & 0xF
+	}
+
+	// Output levels: 1 = 100%, 2 = 50%, 3 = 25%
+	return sample >> (ch.outputLevel - 1)
+}
+
+// While the channel is playing, wave RAM accesses go to the byte currently
+// being read by the channel, rather than the requested address.
+func (ch *waveChannel) readWaveRAM(offset uint16) byte {
+	if ch.enabled {
+		return ch.waveRAM[ch.position/2]
+	}
+
+	return ch.waveRAM[offset]
+}
+
+func (ch *waveChannel) reset(color bool) {
+	length := ch.length
+	waveRAM := ch.waveRAM
+
+	*ch = waveChannel{
+		length:  lengthCounter{max: WAVE_LENGTH_MAX},
+		waveRAM: waveRAM,
+	}
+
+	if !color {
+		// Length counters are unaffected by power on DMG
+		ch.length.counter = length.counter
+	}
+}
+
+func (ch *waveChannel) stateFields() []any {
+	fields := []any{
+		&ch.enabled,
+		&ch.dacEnabled,
+		&ch.outputLevel,
+		&ch.period,
+		&ch.timer,
+		&ch.position,
+		&ch.sampleBuffer,
+		&ch.waveRAM,
+	}
+
+	return append(fields, ch.length.stateFields()...)
+}
+
+func (ch *waveChannel) step(dots uint) {
+	if !ch.enabled {
+		return
+	}
+
+	for dots > 0 {
+		if ch.timer > dots {
+			ch.timer -= dots
+
+			return
+		}
+
+		dots -= ch.timer
+		ch.timer = (PERIOD_MAX - uint(ch.period)) * 2
+		ch.position = (ch.position + 1) % WAVE_SAMPLES
+		ch.sampleBuffer = ch.waveRAM[ch.position/2]
+	}
+}
+
+func (ch *waveChannel) trigger() {
+	ch.enabled = ch.dacEnabled
+	ch.timer = (PERIOD_MAX - uint(ch.period)) * 2
+	ch.position = 0
+}
+
+func (ch *waveChannel) writeControl(value byte, nextClocksLength bool) {
+	ch.period = (ch.period & 0xFF) | (uint16(value&NRX4_PERIOD_MASK) << 8)
+	ch.length.writeControl(value, nextClocksLength, &ch.enabled)
+
+	if (value & NRX4_TRIGGER) != 0 {
+		ch.trigger()
+	}
+}
+
+func (ch *waveChannel) writeDAC(value byte) {
+	ch.dacEnabled = (value & NR30_DAC_ENABLE) != 0
+
+	if !ch.dacEnabled {
+		ch.enabled = false
+	}
+}
+
+func (ch *waveChannel) writeLength(value byte) {
+	ch.length.load(uint16(value))
+}
+
+func (ch *waveChannel) writeOutputLevel(value byte) {
+	ch.outputLevel = (value & NR32_OUTPUT_LEVEL_MASK) >> 5
+}
+
+func (ch *waveChannel) writePeriodLow(value byte) {
+	ch.period = (ch.period & 0x700) | uint16(value)
+}
+
+func (ch *waveChannel) writeWaveRAM(offset uint16, value byte) {
+	if ch.enabled {
+		ch.waveRAM[ch.position/2] = value
+
+		return
+	}
+
+	ch.waveRAM[offset] = value
+}
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/maxfierke/gogo-gb/cart/mbc"
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
+	"github.com/maxfierke/gogo-gb/scheduler"
 )
 
 var (
@@ -20,6 +23,8 @@ type Cartridge struct {
 	mbc    mbc.MBC
 }
 
+var _ savestate.Serializer = (*Cartridge)(nil)
+
 func NewCartridge() *Cartridge {
 	return &Cartridge{}
 }
@@ -98,6 +103,51 @@ func (c *Cartridge) LoadSave(r io.Reader) error {
 	return c.mbc.LoadSave(r)
 }
 
+// LoadState restores the MBC's banking, RAM & RTC registers. ROM contents
+// aren't included, so the same cartridge must already be loaded.
+func (c *Cartridge) LoadState(r io.Reader) error {
+	if c.mbc == nil {
+		return nil
+	}
+
+	return c.mbc.LoadState(r)
+}
+
+func (c *Cartridge) SaveState(w io.Writer) error {
+	if c.mbc == nil {
+		return nil
+	}
+
+	return c.mbc.SaveState(w)
+}
+
+// NextEvent returns how many cycles until the cartridge next needs stepping,
+// which is only ever for the RTC
+func (c *Cartridge) NextEvent() uint {
+	if rtc, hasRTC := c.mbc.(mbc.RTC); hasRTC {
+		return rtc.NextTick()
+	}
+
+	return scheduler.NEVER
+}
+
+// SetDoubleSpeed keeps the cartridge's RTC, if it has one, counting at the same
+// rate when the CPU switches speed
+func (c *Cartridge) SetDoubleSpeed(enabled bool) {
+	if rtc, hasRTC := c.mbc.(mbc.RTC); hasRTC {
+		rtc.SetDoubleSpeed(enabled)
+	}
+}
+
+// UseEmulatedClock makes the cartridge's RTC, if it has one, follow emulated
+// time from seed rather than the system clock. The cartridge must already be
+// loaded.
+func (c *Cartridge) UseEmulatedClock(seed time.Time) {
+	if rtc, hasRTC := c.mbc.(mbc.RTC); hasRTC {
+		rtc.UseEmulatedClock(seed)
+	}
+}
+
 func (c *Cartridge) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 	if c.mbc == nil {
 		return mem.ReadPassthrough()
@@ -2,8 +2,10 @@ package mbc
 
 import (
 	"io"
+	"time"
 
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
 )
 
 const (
@@ -13,9 +15,21 @@ const (
 
 type MBC interface {
 	mem.MemHandler
+	savestate.Serializer
 
 	Step(cycles uint8)
 	DebugPrint(w io.Writer)
 	Save(w io.Writer) error
 	LoadSave(r io.Reader) error
 }
+
+// RTC is implemented by MBCs w/ a real-time clock
+type RTC interface {
+	// NextTick returns how many cycles until the RTC next counts a second, or
+	// scheduler.NEVER if it's halted or missing
+	NextTick() uint
+	// SetDoubleSpeed is called when the CPU switches speed, as the RTC counts
+	// at the same rate either way
+	SetDoubleSpeed(enabled bool)
+	UseEmulatedClock(seed time.Time)
+}
@@ -56,3 +56,13 @@ func (m *MBC0) Save(w io.Writer) error {
 func (m *MBC0) LoadSave(r io.Reader) error {
 	return nil
 }
+
+// LoadState is a no-op, as MBC0 has no banking & its RAM (if any) lives in the
+// MMU's RAM
+func (m *MBC0) LoadState(r io.Reader) error {
+	return nil
+}
+
+func (m *MBC0) SaveState(w io.Writer) error {
+	return nil
+}
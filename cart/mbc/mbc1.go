@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
 )
 
 var (
@@ -190,3 +191,21 @@ func (m *MBC1) LoadSave(r io.Reader) error {
 
 	return nil
 }
+
+func (m *MBC1) LoadState(r io.Reader) error {
+	return savestate.Read(r, m.stateFields()...)
+}
+
+func (m *MBC1) SaveState(w io.Writer) error {
+	return savestate.Write(w, m.stateFields()...)
+}
+
+func (m *MBC1) stateFields() []any {
+	return []any{
+		&m.curRamBank,
+		&m.curRomBank,
+		m.ram,
+		&m.ramEnabled,
+		&m.ramSelected,
+	}
+}
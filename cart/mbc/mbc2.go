@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
 )
 
 var (
@@ -138,3 +139,11 @@ func (m *MBC2) LoadSave(r io.Reader) error {
 
 	return nil
 }
+
+func (m *MBC2) LoadState(r io.Reader) error {
+	return savestate.Read(r, &m.curRomBank, m.ram, &m.ramEnabled)
+}
+
+func (m *MBC2) SaveState(w io.Writer) error {
+	return savestate.Write(w, &m.curRomBank, m.ram, &m.ramEnabled)
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/maxfierke/gogo-gb/bits"
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
+	"github.com/maxfierke/gogo-gb/scheduler"
 )
 
 var (
@@ -123,6 +125,47 @@ func (regs *mbc3RTCRegs) writeReg(reg mbc3RTCReg, value byte) {
 	}
 }
 
+func (regs *mbc3RTCRegs) loadState(r io.Reader) error {
+	var timestamp int64
+
+	err := savestate.Read(r,
+		&regs.Seconds,
+		&regs.Minutes,
+		&regs.Hours,
+		&regs.Days,
+		&regs.Halt,
+		&regs.DaysOverflow,
+		&timestamp,
+	)
+	if err != nil {
+		return err
+	}
+
+	regs.Timestamp = time.Time{}
+	if timestamp != 0 {
+		regs.Timestamp = time.Unix(0, timestamp)
+	}
+
+	return nil
+}
+
+func (regs *mbc3RTCRegs) saveState(w io.Writer) error {
+	var timestamp int64
+	if !regs.Timestamp.IsZero() {
+		timestamp = regs.Timestamp.UnixNano()
+	}
+
+	return savestate.Write(w,
+		&regs.Seconds,
+		&regs.Minutes,
+		&regs.Hours,
+		&regs.Days,
+		&regs.Halt,
+		&regs.DaysOverflow,
+		timestamp,
+	)
+}
+
 func (regs *mbc3RTCRegs) advanceTime(now time.Time) {
 	rtcDiff := now.Sub(regs.Timestamp).Truncate(time.Second)
 
@@ -189,6 +232,15 @@ type MBC3 struct {
 	rtc               mbc3RTCRegs
 	latchedRTC        mbc3RTCRegs
 	rtcClock          uint
+
+	// When set, the RTC follows emulated time from this point, rather than
+	// the system clock
+	rtcSeed    time.Time
+	rtcElapsed uint64
+
+	// The RTC's oscillator is on the cartridge, so it runs at the same rate
+	// regardless of CPU speed
+	baseClock scheduler.BaseClock
 }
 
 type mbc3SaveRTC struct {
@@ -205,7 +257,10 @@ type mbc3SaveRTC struct {
 	UnixTimestamp               int64
 }
 
-var _ MBC = (*MBC3)(nil)
+var (
+	_ MBC = (*MBC3)(nil)
+	_ RTC = (*MBC3)(nil)
+)
 
 func NewMBC3(rom []byte, ram []byte, rtcAvailable bool) *MBC3 {
 	return &MBC3{
@@ -215,14 +270,21 @@ func NewMBC3(rom []byte, ram []byte, rtcAvailable bool) *MBC3 {
 	}
 }
 
-// TODO(GBC): Use GBC clock rate here
+// cyclesPerRTCSecond is in base-speed cycles, which the RTC counts in
+// regardless of CPU speed
 const cyclesPerRTCSecond = 4194304
 
 func (m *MBC3) Step(cycles uint8) {
+	dots := m.baseClock.Cycles(cycles)
+
+	if m.rtcAvailable {
+		m.rtcElapsed += uint64(dots)
+	}
+
 	if m.rtcAvailable && !m.rtc.Halt {
-		m.rtcClock += uint(cycles)
+		m.rtcClock += dots
 
-		now := time.Now()
+		now := m.now()
 		if m.rtc.Timestamp.IsZero() || m.rtc.Timestamp.After(now) {
 			m.rtc.Timestamp = now
 		}
@@ -405,6 +467,64 @@ func (m *MBC3) LoadSave(r io.Reader) error {
 	return nil
 }
 
+func (m *MBC3) LoadState(r io.Reader) error {
+	if err := savestate.Read(r, m.stateFields()...); err != nil {
+		return err
+	}
+
+	if err := m.rtc.loadState(r); err != nil {
+		return fmt.Errorf("mbc3: loading RTC registers: %w", err)
+	}
+
+	if err := m.latchedRTC.loadState(r); err != nil {
+		return fmt.Errorf("mbc3: loading latched RTC registers: %w", err)
+	}
+
+	return savestate.Read(r, &m.rtcElapsed, &m.baseClock.DoubleSpeed, &m.baseClock.Carry)
+}
+
+func (m *MBC3) SaveState(w io.Writer) error {
+	if err := savestate.Write(w, m.stateFields()...); err != nil {
+		return err
+	}
+
+	if err := m.rtc.saveState(w); err != nil {
+		return fmt.Errorf("mbc3: saving RTC registers: %w", err)
+	}
+
+	if err := m.latchedRTC.saveState(w); err != nil {
+		return fmt.Errorf("mbc3: saving latched RTC registers: %w", err)
+	}
+
+	return savestate.Write(w, &m.rtcElapsed, &m.baseClock.DoubleSpeed, &m.baseClock.Carry)
+}
+
+func (m *MBC3) NextTick() uint {
+	if !m.rtcAvailable || m.rtc.Halt {
+		return scheduler.NEVER
+	}
+
+	if m.rtcClock >= cyclesPerRTCSecond {
+		return 1
+	}
+
+	return m.baseClock.Until(cyclesPerRTCSecond - m.rtcClock)
+}
+
+// SetDoubleSpeed is called when the CPU switches speed, so the RTC keeps
+// counting at the same rate
+func (m *MBC3) SetDoubleSpeed(enabled bool) {
+	m.baseClock.DoubleSpeed = enabled
+}
+
+// UseEmulatedClock makes the RTC follow emulated time, starting from seed,
+// instead of the system clock. This makes the RTC (and anything depending on
+// it) deterministic, e.g. for movie playback.
+func (m *MBC3) UseEmulatedClock(seed time.Time) {
+	m.rtcSeed = seed
+	m.rtcElapsed = 0
+}
+
 func (m *MBC3) saveRTCRegsToSave(w io.Writer) error {
 	rtc := &mbc3SaveRTC{
 		CurrentSeconds:              uint32(m.rtc.readReg(MBC3_RTC_REG_SECONDS)),
@@ -458,13 +578,40 @@ func (m *MBC3) loadRTCRegsFromSave(r io.Reader) error {
 	latchedRTC.writeReg(MBC3_RTC_REG_DAY_HIGH, byte(savedRTC.LatchedDaysHighOverflowHalt&0xFF))
 
 	m.rtc = rtc
-	m.rtc.advanceTime(time.Now())
+	m.rtc.advanceTime(m.now())
 
 	m.latchedRTC = latchedRTC
 
 	return nil
 }
 
+func (m *MBC3) now() time.Time {
+	if m.rtcSeed.IsZero() {
+		return time.Now()
+	}
+
+	seconds := m.rtcElapsed / cyclesPerRTCSecond
+	remainder := m.rtcElapsed % cyclesPerRTCSecond
+
+	return m.rtcSeed.
+		Add(time.Duration(seconds) * time.Second).
+		Add(time.Duration(remainder) * time.Second / cyclesPerRTCSecond)
+}
+
+func (m *MBC3) stateFields() []any {
+	return []any{
+		&m.curRamBank,
+		&m.curRomBank,
+		m.ram,
+		&m.ramEnabled,
+		&m.ramSelected,
+		&m.rtcEnabled,
+		&m.rtcLatchRequested,
+		&m.rtcRegSelected,
+		&m.rtcClock,
+	}
+}
+
 var (
 	MBC30_ROM_BANKS = mem.MemRegion{Start: 0x4000, End: 0x7FFF}
 
@@ -535,3 +682,19 @@ func (m *MBC30) Save(w io.Writer) error {
 func (m *MBC30) LoadSave(r io.Reader) error {
 	return m.MBC3.LoadSave(r)
 }
+
+func (m *MBC30) LoadState(r io.Reader) error {
+	return m.MBC3.LoadState(r)
+}
+
+func (m *MBC30) SaveState(w io.Writer) error {
+	return m.MBC3.SaveState(w)
+}
+
+func (m *MBC30) SetDoubleSpeed(enabled bool) {
+	m.MBC3.SetDoubleSpeed(enabled)
+}
+
+func (m *MBC30) UseEmulatedClock(seed time.Time) {
+	m.MBC3.UseEmulatedClock(seed)
+}
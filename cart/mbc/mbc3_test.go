@@ -333,3 +333,69 @@ func TestMBC3_Save_LoadSave_rtc(t *testing.T) {
 	assert.Equal(latchedRTC.Seconds, mbc3.latchedRTC.Seconds)
 	assert.WithinDuration(currentRTC.Timestamp.Add(rtcDiff), mbc3.rtc.Timestamp, time.Second)
 }
+
+func TestMBC3_UseEmulatedClock(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	seed := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	mbc3 := NewMBC3(makeRom(8), makeRam(4), true)
+	mbc3.rtc = mbc3RTCRegs{
+		Minutes:   30,
+		Hours:     12,
+		Timestamp: seed.Add(-time.Hour),
+	}
+
+	var saveFile bytes.Buffer
+	require.NoError(mbc3.Save(&saveFile))
+
+	mbc3 = NewMBC3(makeRom(8), makeRam(4), true)
+	mbc3.UseEmulatedClock(seed)
+	require.NoError(mbc3.LoadSave(&saveFile))
+
+	// Time passes up to the seed, regardless of the system clock
+	assert.EqualValues(13, mbc3.rtc.Hours)
+	assert.EqualValues(30, mbc3.rtc.Minutes)
+	assert.EqualValues(0, mbc3.rtc.Seconds)
+	assert.Equal(seed, mbc3.rtc.Timestamp)
+
+	// ...and only as it is emulated after that
+	for range 3 * cyclesPerRTCSecond / 4 {
+		mbc3.Step(4)
+	}
+	assert.EqualValues(3, mbc3.rtc.Seconds)
+	assert.Equal(seed.Add(3*time.Second), mbc3.rtc.Timestamp)
+}
+
+func TestMBC3_DoubleSpeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seed := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	mbc3 := NewMBC3(makeRom(8), makeRam(4), true)
+	mbc3.UseEmulatedClock(seed)
+	mbc3.SetDoubleSpeed(true)
+	assert.Equal(uint(2*cyclesPerRTCSecond), mbc3.NextTick())
+
+	// The RTC counts in base-speed cycles, so a second takes twice as many
+	// CPU cycles in double speed
+	for range cyclesPerRTCSecond / 4 {
+		mbc3.Step(4)
+	}
+	assert.EqualValues(0, mbc3.rtc.Seconds)
+	assert.Equal(seed.Add(time.Second/2), mbc3.now())
+	assert.Equal(uint(cyclesPerRTCSecond), mbc3.NextTick())
+
+	for range cyclesPerRTCSecond / 4 {
+		mbc3.Step(4)
+	}
+	assert.EqualValues(1, mbc3.rtc.Seconds)
+	assert.Equal(seed.Add(time.Second), mbc3.now())
+
+	mbc3.SetDoubleSpeed(false)
+	for range cyclesPerRTCSecond / 4 {
+		mbc3.Step(4)
+	}
+	assert.EqualValues(2, mbc3.rtc.Seconds)
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
 )
 
 var (
@@ -172,3 +173,11 @@ func (m *MBC5) LoadSave(r io.Reader) error {
 
 	return nil
 }
+
+func (m *MBC5) LoadState(r io.Reader) error {
+	return savestate.Read(r, &m.curRamBank, &m.curRomBank, m.ram, &m.ramEnabled)
+}
+
+func (m *MBC5) SaveState(w io.Writer) error {
+	return savestate.Write(w, &m.curRamBank, &m.curRomBank, m.ram, &m.ramEnabled)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/maxfierke/gogo-gb/savestate"
+	"github.com/spf13/cobra"
+)
+
+var inspectStateCmd = &cobra.Command{
+	Use:   "state <file>",
+	Short: "Print save state information",
+	Long:  `Print the metadata and chunks of a .state file`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger, err := getLogger(cmd)
+		if err != nil {
+			return fmt.Errorf("getting logger: %w", err)
+		}
+
+		statePath := args[0]
+
+		stateFile, err := os.Open(statePath)
+		if statePath == "" || err != nil {
+			return fmt.Errorf("unable to open save state file: %w", err)
+		}
+		defer stateFile.Close()
+
+		meta, err := savestate.ReadMetadata(stateFile)
+		if err != nil {
+			return fmt.Errorf("unable to read save state: %w", err)
+		}
+
+		chunks, err := savestate.ReadChunks(stateFile)
+		if err != nil {
+			return fmt.Errorf("unable to read save state: %w", err)
+		}
+
+		w := logger.Writer()
+		meta.DebugPrint(w)
+
+		fmt.Fprintf(w, "\nChunks:\n")
+		for _, name := range chunks.Names() {
+			fmt.Fprintf(w, "  %s\tv%d\t%d bytes\n", name, chunks.Version(name), chunks.Size(name))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	inspectCmd.AddCommand(inspectStateCmd)
+}
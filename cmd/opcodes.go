@@ -18,12 +18,7 @@ var opcodesCmd = &cobra.Command{
 			return fmt.Errorf("getting logger: %w", err)
 		}
 
-		opcodes, err := isa.LoadOpcodes()
-		if err != nil {
-			return fmt.Errorf("unable to load opcodes: %w", err)
-		}
-
-		opcodes.DebugPrint(logger.Writer())
+		isa.LoadOpcodes().DebugPrint(logger.Writer())
 
 		return nil
 	},
@@ -37,7 +37,12 @@ func getLogger(cmd *cobra.Command) (*log.Logger, error) {
 		return nil, fmt.Errorf("getting log flag: %w", err)
 	}
 
+	return newLogger(logPath)
+}
+
+func newLogger(logPath string) (*log.Logger, error) {
 	var logFile io.Writer
+	var err error
 
 	switch logPath {
 	case "", "stdout":
@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/maxfierke/gogo-gb/cart"
 	"github.com/maxfierke/gogo-gb/cart/mbc"
@@ -14,18 +18,33 @@ import (
 	"github.com/maxfierke/gogo-gb/devices"
 	"github.com/maxfierke/gogo-gb/hardware"
 	"github.com/maxfierke/gogo-gb/host"
+	"github.com/maxfierke/gogo-gb/movie"
+	"github.com/maxfierke/gogo-gb/ppu"
+	"github.com/maxfierke/gogo-gb/ppu/rendering"
 	"github.com/spf13/cobra"
 )
 
 type RunCmdOptions struct {
-	bootRomPath  string
-	cartPath     string
-	cartSavePath string
-	debugger     string
-	headless     bool
-	model        string
-	serialPort   string
-	skipBootRom  bool
+	audioOutPath    string
+	audioSampleRate int
+	bootRomPath     string
+	cartPath        string
+	cartSavePath    string
+	debugger        string
+	headless        bool
+	frameHashes     bool
+	linkConnect     string
+	linkListen      string
+	linkLocal       string
+	model           string
+	playMoviePath   string
+	printerOutDir   string
+	recordMoviePath string
+	renderer        string
+	rewindMemory    int
+	serialDevice    string
+	serialPort      string
+	skipBootRom     bool
 }
 
 var runCmdOptions = RunCmdOptions{}
@@ -38,7 +57,7 @@ var runCmd = &cobra.Command{
 Options can be specified to attach a debugger, control peripherals, and specify paths for saves and the boot ROM.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		logger, err := getLogger(cmd)
+		logger, err := getRunLogger(cmd, &runCmdOptions)
 		if err != nil {
 			return fmt.Errorf("getting logger: %w", err)
 		}
@@ -66,9 +85,60 @@ func init() {
 
 	runCmd.Flags().StringVarP(&runCmdOptions.debugger, "debugger", "d", "", "Specify debugger to use (\"gameboy-doctor\", \"interactive\")")
 	runCmd.Flags().StringVarP(&runCmdOptions.model, "model", "m", "auto", "Specify model to use (\"auto\", \"dmg\", \"cgb\")")
+	runCmd.Flags().StringVar(&runCmdOptions.renderer, "renderer", "scanline", "Specify renderer to use (\"scanline\", \"fifo\"). \"fifo\" is slower, but handles mid-scanline effects")
 	runCmd.Flags().StringVarP(&runCmdOptions.serialPort, "serial-port", "p", "", "Path to serial port IO (could be a file, UNIX socket, etc.)")
+	runCmd.Flags().StringVar(&runCmdOptions.linkListen, "link-listen", "", "Address to wait on another gogo-gb to connect a link cable at (e.g. \":5555\")")
+	runCmd.Flags().StringVar(&runCmdOptions.linkConnect, "link-connect", "", "Address of another gogo-gb to connect a link cable to (e.g. \"localhost:5555\")")
+	runCmd.Flags().StringVar(&runCmdOptions.linkLocal, "link-local", "", "Path to a second cartridge to run headless alongside the first, connected by a link cable. If both would use the same save file, the second saves to a .link.sav file instead")
+	_ = runCmd.MarkFlagFilename("link-local", ".gb", ".gbc")
+	runCmd.Flags().StringVar(&runCmdOptions.serialDevice, "serial-device", "", "Specify device to plug into the serial port (\"printer\")")
+	runCmd.Flags().StringVar(&runCmdOptions.printerOutDir, "printer-out", ".", "Path to directory to save prints from the printer to, as PNGs")
+	_ = runCmd.MarkFlagDirname("printer-out")
+	runCmd.MarkFlagsMutuallyExclusive("serial-port", "serial-device", "link-listen", "link-connect", "link-local")
 	runCmd.Flags().BoolVar(&runCmdOptions.skipBootRom, "skip-bootrom", false, "Skip loading a boot ROM")
 	runCmd.Flags().BoolVar(&runCmdOptions.headless, "headless", false, "Launch without UI")
+	runCmd.Flags().IntVar(&runCmdOptions.rewindMemory, "rewind-memory", 64, "Memory (in MiB) to keep rewind snapshots in. Hold Backspace to rewind. 0 disables rewinding")
+	runCmd.Flags().StringVar(&runCmdOptions.audioOutPath, "audio-out", "", "Path to capture audio output to. Writes a WAV file for .wav paths, otherwise raw 16-bit stereo PCM (e.g. for pipes or \"stdout\", which moves logging to stderr by default)")
+	_ = runCmd.MarkFlagFilename("audio-out", ".wav", ".pcm", ".raw")
+	runCmd.Flags().StringVar(&runCmdOptions.recordMoviePath, "record", "", "Path to record a movie of inputs to (.ggm), for deterministic playback with --play")
+	_ = runCmd.MarkFlagFilename("record", ".ggm")
+	runCmd.Flags().BoolVar(&runCmdOptions.frameHashes, "record-frame-hashes", true, "Include a hash of every frame when recording a movie, so playback can report where it diverges")
+	runCmd.Flags().StringVar(&runCmdOptions.playMoviePath, "play", "", "Path to a movie (.ggm) to play back inputs from. The cartridge save is not loaded or written during playback")
+	_ = runCmd.MarkFlagFilename("play", ".ggm")
+	runCmd.MarkFlagsMutuallyExclusive("record", "play", "link-local")
+	runCmd.Flags().IntVar(&runCmdOptions.audioSampleRate, "audio-sample-rate", devices.DEFAULT_AUDIO_SAMPLE_RATE, "Sample rate (in Hz) to output audio at")
+}
+
+// getRunLogger logs to stderr by default when audio's being written to stdout,
+// so log lines don't end up mixed in w/ the samples
+func getRunLogger(cmd *cobra.Command, options *RunCmdOptions) (*log.Logger, error) {
+	if !isStdoutPath(options.audioOutPath) {
+		return getLogger(cmd)
+	}
+
+	logPath, err := cmd.Flags().GetString("log")
+	if err != nil {
+		return nil, fmt.Errorf("getting log flag: %w", err)
+	}
+
+	switch {
+	case logPath == "":
+		logPath = "stderr"
+	case isStdoutPath(logPath):
+		return nil, errors.New("unable to log to stdout while writing audio to it. Please specify another path with --log")
+	}
+
+	return newLogger(logPath)
+}
+
+// isStdoutPath is whether path refers to stdout, rather than a file
+func isStdoutPath(path string) bool {
+	switch path {
+	case "-", "stdout", "/dev/stdout":
+		return true
+	default:
+		return false
+	}
 }
 
 var DEFAULT_BOOT_ROM_PATHS = []string{
@@ -109,17 +179,75 @@ func getCartSaveFilePath(options *RunCmdOptions) string {
 	return cartSaveFilePath
 }
 
+// getLinkedCartSaveFilePath returns the save file for the cartridge linked w/
+// --link-local. It's kept apart from the first cartridge's, even when they're
+// the same game, so the consoles don't overwrite each other's saves.
+func getLinkedCartSaveFilePath(options *RunCmdOptions) string {
+	linkedOptions := *options
+	linkedOptions.cartPath = options.linkLocal
+	linkedOptions.cartSavePath = ""
+
+	cartSaveFilePath := getCartSaveFilePath(options)
+	linkedSaveFilePath := getCartSaveFilePath(&linkedOptions)
+
+	if isSamePath(linkedSaveFilePath, cartSaveFilePath) {
+		return strings.TrimSuffix(linkedSaveFilePath, ".sav") + ".link.sav"
+	}
+
+	return linkedSaveFilePath
+}
+
+// isSamePath returns whether a & b refer to the same file
+func isSamePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+
+	return absA == absB
+}
+
 func initHost(logger *log.Logger, options *RunCmdOptions) (host.Host, error) {
 	var hostDevice host.Host
 
 	if options.headless {
-		hostDevice = host.NewCLIHost()
+		cliHost := host.NewCLIHost()
+
+		// The interactive debugger uses SIGINT to break into the debugger
+		if options.debugger == "interactive" {
+			cliHost.StopOnSignals(syscall.SIGTERM)
+		} else {
+			cliHost.StopOnSignals(os.Interrupt, syscall.SIGTERM)
+		}
+
+		hostDevice = cliHost
 	} else {
-		hostDevice = host.NewUIHost()
+		uiHost := host.NewUIHost()
+
+		isMovie := options.recordMoviePath != "" || options.playMoviePath != ""
+
+		if options.rewindMemory < 0 {
+			return nil, fmt.Errorf("invalid rewind memory: %d", options.rewindMemory)
+		} else if options.rewindMemory > 0 && isMovie {
+			logger.Printf("rewinding is disabled while recording or playing back a movie")
+		} else if options.rewindMemory > 0 && options.linkLocal != "" {
+			logger.Printf("rewinding is disabled while linked to a second cartridge")
+		} else if options.rewindMemory > 0 {
+			uiHost.EnableRewind(options.rewindMemory * 1024 * 1024)
+		}
+
+		hostDevice = uiHost
 	}
 
 	hostDevice.SetLogger(logger)
 
+	if options.audioSampleRate <= 0 {
+		return nil, fmt.Errorf("invalid audio sample rate: %d", options.audioSampleRate)
+	}
+	hostDevice.SetAudioSampleRate(options.audioSampleRate)
+
 	if options.serialPort != "" {
 		serialCable := devices.NewHostSerialCable()
 
@@ -144,7 +272,143 @@ func initHost(logger *log.Logger, options *RunCmdOptions) (host.Host, error) {
 	return hostDevice, nil
 }
 
-func initConsole(logger *log.Logger, options *RunCmdOptions) (hardware.Console, error) {
+func initLinkCable(hostDevice host.Host, logger *log.Logger, options *RunCmdOptions) (io.Closer, error) {
+	var linkCable *devices.LinkCable
+	var err error
+
+	switch {
+	case options.linkListen != "":
+		logger.Printf("waiting for link cable connection on %s\n", options.linkListen)
+
+		linkCable, err = devices.ListenLinkCable(options.linkListen)
+	case options.linkConnect != "":
+		logger.Printf("connecting link cable to %s\n", options.linkConnect)
+
+		linkCable, err = devices.DialLinkCable(options.linkConnect)
+	default:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Printf("link cable connected to %s\n", linkCable.RemoteAddr())
+	hostDevice.AttachSerialCable(linkCable)
+
+	return linkCable, nil
+}
+
+func initSerialDevice(hostDevice host.Host, logger *log.Logger, options *RunCmdOptions) (io.Closer, error) {
+	switch options.serialDevice {
+	case "":
+		return nil, nil
+	case "printer":
+		printer := devices.NewPrinter(options.printerOutDir)
+		hostDevice.AttachSerialCable(printer)
+		logger.Printf("saving prints to %s\n", options.printerOutDir)
+
+		return printer, nil
+	default:
+		return nil, fmt.Errorf("unrecognized serial device: %s", options.serialDevice)
+	}
+}
+
+// initLinkedConsole returns the console for the second cartridge, to be run
+// linked to the first, along w/ a closer that writes its cartridge save
+func initLinkedConsole(logger *log.Logger, options *RunCmdOptions) (hardware.Console, io.Closer, error) {
+	linkedOptions := *options
+	linkedOptions.cartPath = options.linkLocal
+	linkedOptions.cartSavePath = getLinkedCartSaveFilePath(options)
+	linkedOptions.debugger = ""
+
+	console, _, err := initConsole(logger, &linkedOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = loadCart(console, logger, &linkedOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading cartridge: %w", err)
+	}
+
+	if !console.CartridgeHeader().SupportsSaving() {
+		return console, closerFunc(func() error { return nil }), nil
+	}
+
+	err = loadCartSave(console, logger, &linkedOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading cartridge save: %w", err)
+	}
+
+	return console, closerFunc(func() error {
+		return saveCart(console, logger, &linkedOptions)
+	}), nil
+}
+
+func initAudioOutput(hostDevice host.Host, logger *log.Logger, options *RunCmdOptions) (io.Closer, error) {
+	if options.audioOutPath == "" {
+		return nil, nil
+	}
+
+	var audioWriter *devices.PCMAudioWriter
+
+	switch {
+	case isStdoutPath(options.audioOutPath):
+		if options.serialPort == "stdout" || options.serialPort == "/dev/stdout" {
+			return nil, errors.New("unable to write audio & serial port output to stdout at the same time")
+		}
+
+		if options.debugger != "" {
+			return nil, errors.New("unable to write audio to stdout while using a debugger, which writes to it")
+		}
+
+		audioWriter = devices.NewRawAudioWriter(os.Stdout, options.audioSampleRate)
+	default:
+		audioFile, err := os.OpenFile(options.audioOutPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open '%s' for audio output: %w", options.audioOutPath, err)
+		}
+
+		audioFileInfo, err := audioFile.Stat()
+		if err != nil {
+			audioFile.Close()
+
+			return nil, fmt.Errorf("unable to stat '%s' for audio output: %w", options.audioOutPath, err)
+		}
+
+		isRegularFile := audioFileInfo.Mode().IsRegular()
+
+		if isRegularFile && strings.EqualFold(filepath.Ext(options.audioOutPath), ".wav") {
+			audioWriter, err = devices.NewWAVAudioWriter(audioFile, options.audioSampleRate)
+			if err != nil {
+				audioFile.Close()
+
+				return nil, fmt.Errorf("unable to write WAV to '%s': %w", options.audioOutPath, err)
+			}
+		} else {
+			audioWriter = devices.NewRawAudioWriter(audioFile, options.audioSampleRate)
+		}
+
+		hostDevice.AttachAudioOutput(audioWriter)
+		logger.Printf("capturing audio to %s\n", options.audioOutPath)
+
+		return closerFunc(func() error {
+			writerErr := audioWriter.Close()
+			fileErr := audioFile.Close()
+
+			return errors.Join(writerErr, fileErr)
+		}), nil
+	}
+
+	hostDevice.AttachAudioOutput(audioWriter)
+
+	return audioWriter, nil
+}
+
+// initConsole returns the console, along w/ the contents of the boot ROM it was
+// set up with, if any
+func initConsole(logger *log.Logger, options *RunCmdOptions) (hardware.Console, []byte, error) {
 	var model hardware.ConsoleModel
 	switch options.model {
 	case "auto":
@@ -156,7 +420,7 @@ func initConsole(logger *log.Logger, options *RunCmdOptions) (hardware.Console,
 			case ".gb":
 				model = hardware.ConsoleModelDMG
 			default:
-				return nil, errors.New("unable to auto-detect model. Please specify with --model/-m")
+				return nil, nil, errors.New("unable to auto-detect model. Please specify with --model/-m")
 			}
 		}
 	case "dmg":
@@ -164,30 +428,49 @@ func initConsole(logger *log.Logger, options *RunCmdOptions) (hardware.Console,
 	case "cgb":
 		model = hardware.ConsoleModelCGB
 	default:
-		return nil, fmt.Errorf("unrecognized model: %s", options.model)
+		return nil, nil, fmt.Errorf("unrecognized model: %s", options.model)
+	}
+
+	var renderer ppu.RendererConstructor
+	switch options.renderer {
+	case "scanline":
+		renderer = rendering.Scanline
+	case "fifo":
+		renderer = rendering.FIFO
+	default:
+		return nil, nil, fmt.Errorf("unrecognized renderer: %s", options.renderer)
 	}
 
 	debugger, err := debug.NewDebugger(options.debugger)
 	if err != nil {
-		return nil, fmt.Errorf("unable to initialize debugger: %w", err)
+		return nil, nil, fmt.Errorf("unable to initialize debugger: %w", err)
 	}
 
 	opts := []hardware.ConsoleOption{
 		hardware.WithDebugger(debugger),
+		hardware.WithRenderer(renderer),
 	}
 
+	var bootROM []byte
+
 	if options.skipBootRom {
 		opts = append(opts, hardware.WithFakeBootROM())
 	} else {
 		bootRomFile, err := loadBootROM(model, logger, options)
 		if err != nil {
-			return nil, fmt.Errorf("unable to load boot ROM: %w", err)
+			return nil, nil, fmt.Errorf("unable to load boot ROM: %w", err)
 		}
 		if bootRomFile == nil {
 			opts = append(opts, hardware.WithFakeBootROM())
 		} else {
 			defer bootRomFile.Close()
-			opts = append(opts, hardware.WithBootROM(bootRomFile))
+
+			bootROM, err = io.ReadAll(bootRomFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("unable to read boot ROM: %w", err)
+			}
+
+			opts = append(opts, hardware.WithBootROM(bytes.NewReader(bootROM)))
 		}
 	}
 
@@ -196,10 +479,10 @@ func initConsole(logger *log.Logger, options *RunCmdOptions) (hardware.Console,
 		opts...,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return console, nil
+	return console, bootROM, nil
 }
 
 func loadBootROM(model hardware.ConsoleModel, logger *log.Logger, options *RunCmdOptions) (*os.File, error) {
@@ -311,34 +594,209 @@ func runCart(logger *log.Logger, options *RunCmdOptions) error {
 		return fmt.Errorf("unable to initialize host device: %w", err)
 	}
 
-	console, err := initConsole(logger, options)
+	console, bootROM, err := initConsole(logger, options)
 	if err != nil {
 		return fmt.Errorf("initializing DMG: %w", err)
 	}
 
+	audioCloser, err := initAudioOutput(consoleHost, logger, options)
+	if err != nil {
+		return fmt.Errorf("initializing audio output: %w", err)
+	}
+
+	if audioCloser != nil {
+		defer func() {
+			err := audioCloser.Close()
+			if err != nil {
+				logger.Printf("WARN: Error occurred while finishing audio output: %s", err.Error())
+			}
+		}()
+	}
+
 	err = loadCart(console, logger, options)
 	if err != nil {
 		return fmt.Errorf("loading cartridge: %w", err)
 	}
 
-	if console.CartridgeHeader().SupportsSaving() {
-		err := loadCartSave(console, logger, options)
+	linkCloser, err := initLinkCable(consoleHost, logger, options)
+	if err != nil {
+		return fmt.Errorf("initializing link cable: %w", err)
+	}
+
+	if linkCloser != nil {
+		defer linkCloser.Close()
+	}
+
+	serialDeviceCloser, err := initSerialDevice(consoleHost, logger, options)
+	if err != nil {
+		return fmt.Errorf("initializing serial device: %w", err)
+	}
+
+	if serialDeviceCloser != nil {
+		defer func() {
+			err := serialDeviceCloser.Close()
+			if err != nil {
+				logger.Printf("WARN: Error occurred while closing serial device: %s", err.Error())
+			}
+		}()
+	}
+
+	var runOpts []hardware.RunOption
+
+	if options.linkLocal != "" {
+		linkedConsole, linkedCloser, err := initLinkedConsole(logger, options)
 		if err != nil {
-			return fmt.Errorf("loading cartridge save: %w", err)
+			return fmt.Errorf("initializing linked console: %w", err)
 		}
 
 		defer func() {
-			err := saveCart(console, logger, options)
+			err := linkedCloser.Close()
 			if err != nil {
-				logger.Printf("WARN: Error occurred while saving: %s", err.Error())
+				logger.Printf("WARN: Error occurred while saving linked cartridge: %s", err.Error())
 			}
 		}()
+
+		linkedHost := host.NewCLIHost()
+		linkedHost.SetLogger(logger)
+
+		logger.Printf("linked to %s\n", options.linkLocal)
+		runOpts = append(runOpts, hardware.WithLinkedConsole(linkedConsole, linkedHost))
 	}
 
-	err = consoleHost.Run(console)
+	if options.playMoviePath != "" {
+		player, playerCloser, err := initMoviePlayback(console, bootROM, logger, options)
+		if err != nil {
+			return fmt.Errorf("initializing movie playback: %w", err)
+		}
+		defer playerCloser.Close()
+
+		runOpts = append(runOpts, hardware.WithInputPlayer(player))
+	} else {
+		// Saves only store the RTC's timestamp to the second, so the seed
+		// is truncated to match what playback will restore from the movie
+		rtcSeed := time.Now().Truncate(time.Second)
+
+		if options.recordMoviePath != "" {
+			// Must be in place before the save is loaded, as loading it
+			// advances the RTC to the current time
+			console.UseEmulatedClock(rtcSeed)
+		}
+
+		if console.CartridgeHeader().SupportsSaving() {
+			err := loadCartSave(console, logger, options)
+			if err != nil {
+				return fmt.Errorf("loading cartridge save: %w", err)
+			}
+
+			defer func() {
+				err := saveCart(console, logger, options)
+				if err != nil {
+					logger.Printf("WARN: Error occurred while saving: %s", err.Error())
+				}
+			}()
+		}
+
+		if options.recordMoviePath != "" {
+			recorder, recorderCloser, err := initMovieRecording(console, bootROM, rtcSeed, logger, options)
+			if err != nil {
+				return fmt.Errorf("initializing movie recording: %w", err)
+			}
+
+			defer func() {
+				err := recorderCloser.Close()
+				if err != nil {
+					logger.Printf("WARN: Error occurred while finishing movie recording: %s", err.Error())
+				}
+			}()
+
+			runOpts = append(runOpts, hardware.WithInputRecorder(recorder))
+		}
+	}
+
+	err = consoleHost.Run(console, runOpts...)
 	if err != nil {
 		return fmt.Errorf("running emulation: %w", err)
 	}
 
 	return nil
 }
+
+func initMoviePlayback(console hardware.Console, bootROM []byte, logger *log.Logger, options *RunCmdOptions) (*movie.Player, io.Closer, error) {
+	movieFile, err := os.Open(options.playMoviePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to open movie '%s': %w", options.playMoviePath, err)
+	}
+
+	player, err := movie.NewPlayer(movieFile)
+	if err != nil {
+		movieFile.Close()
+
+		return nil, nil, fmt.Errorf("unable to read movie '%s': %w", options.playMoviePath, err)
+	}
+
+	header := player.Header()
+	if err := header.Verify(console, bootROM); err != nil {
+		movieFile.Close()
+
+		return nil, nil, err
+	}
+
+	console.UseEmulatedClock(header.RTCSeed)
+
+	if len(header.SRAM) > 0 {
+		if err := console.LoadSave(bytes.NewReader(header.SRAM)); err != nil {
+			movieFile.Close()
+
+			return nil, nil, fmt.Errorf("unable to load SRAM from movie: %w", err)
+		}
+	}
+
+	logger.Printf("playing back movie from %s\n", options.playMoviePath)
+
+	return player, closerFunc(func() error {
+		logger.Printf("played back %d frames from movie\n", player.Frames())
+
+		if frame, diverged := player.DivergedAt(); diverged {
+			logger.Printf("WARN: Movie playback diverged from the recording at frame %d", frame)
+		}
+
+		return movieFile.Close()
+	}), nil
+}
+
+func initMovieRecording(console hardware.Console, bootROM []byte, rtcSeed time.Time, logger *log.Logger, options *RunCmdOptions) (*movie.Recorder, io.Closer, error) {
+	header, err := movie.NewHeader(console, bootROM, rtcSeed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to describe movie: %w", err)
+	}
+	header.FrameHashes = options.frameHashes
+
+	movieFile, err := os.Create(options.recordMoviePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create movie '%s': %w", options.recordMoviePath, err)
+	}
+
+	recorder, err := movie.NewRecorder(movieFile, header)
+	if err != nil {
+		movieFile.Close()
+
+		return nil, nil, fmt.Errorf("unable to write movie '%s': %w", options.recordMoviePath, err)
+	}
+
+	logger.Printf("recording movie to %s\n", options.recordMoviePath)
+
+	return recorder, closerFunc(func() error {
+		recorderErr := recorder.Close()
+		fileErr := movieFile.Close()
+
+		logger.Printf("recorded %d frames to movie\n", recorder.Frames())
+
+		return errors.Join(recorderErr, fileErr)
+	}), nil
+}
+
+type closerFunc func() error
+
+func (fn closerFunc) Close() error {
+	return fn()
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/maxfierke/gogo-gb/devices"
+	"github.com/maxfierke/gogo-gb/host"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureStdout redirects stdout to a file for the rest of the test, returning
+// a func to read back everything written to it
+func captureStdout(t *testing.T) func() []byte {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "stdout")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = f
+	t.Cleanup(func() {
+		os.Stdout = stdout
+		f.Close()
+	})
+
+	return func() []byte {
+		captured, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		return captured
+	}
+}
+
+func newTestRunCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("log", "", "")
+	require.NoError(t, cmd.Flags().Parse(args))
+
+	return cmd
+}
+
+func TestAudioOutputToStdout(t *testing.T) {
+	assert := assert.New(t)
+
+	readStdout := captureStdout(t)
+
+	options := &RunCmdOptions{
+		audioOutPath:    "stdout",
+		audioSampleRate: devices.DEFAULT_AUDIO_SAMPLE_RATE,
+	}
+
+	logger, err := getRunLogger(newTestRunCmd(t), options)
+	require.NoError(t, err)
+
+	hostDevice := host.NewCLIHost()
+	hostDevice.SetLogger(logger)
+
+	audioCloser, err := initAudioOutput(hostDevice, logger, options)
+	require.NoError(t, err)
+
+	logger.Println("welcome to gogo-gb, the go-getting GB emulator")
+	hostDevice.AudioOutput().WriteSample(0x0102, -2)
+	hostDevice.Log("still running")
+	hostDevice.AudioOutput().WriteSample(0x7FFF, 0)
+	require.NoError(t, audioCloser.Close())
+
+	assert.Equal(
+		[]byte{0x02, 0x01, 0xFE, 0xFF, 0xFF, 0x7F, 0x00, 0x00},
+		readStdout(),
+	)
+}
+
+func TestAudioOutputToStdoutLogConflict(t *testing.T) {
+	options := &RunCmdOptions{audioOutPath: "-"}
+
+	_, err := getRunLogger(newTestRunCmd(t, "--log", "stdout"), options)
+	assert.Error(t, err)
+
+	_, err = getRunLogger(newTestRunCmd(t, "--log", "stderr"), options)
+	assert.NoError(t, err)
+}
+
+func TestLinkedCartSaveFilePath(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+
+	// Different games keep their own saves
+	assert.Equal(filepath.Join(dir, "blue.sav"), getLinkedCartSaveFilePath(&RunCmdOptions{
+		cartPath:  filepath.Join(dir, "red.gb"),
+		linkLocal: filepath.Join(dir, "blue.gb"),
+	}))
+
+	// The same game, or one that'd otherwise share a save, gets its own
+	for _, linkLocal := range []string{"red.gb", "./red.gb", "red.gbc"} {
+		savePath := getLinkedCartSaveFilePath(&RunCmdOptions{
+			cartPath:  filepath.Join(dir, "red.gb"),
+			linkLocal: filepath.Join(dir, linkLocal),
+		})
+		assert.Equal(filepath.Join(dir, "red.link.sav"), savePath, linkLocal)
+	}
+
+	// As does one sharing the save given w/ --save
+	assert.Equal(filepath.Join(dir, "blue.link.sav"), getLinkedCartSaveFilePath(&RunCmdOptions{
+		cartPath:     filepath.Join(dir, "red.gb"),
+		cartSavePath: filepath.Join(dir, "blue.sav"),
+		linkLocal:    filepath.Join(dir, "blue.gb"),
+	}))
+}
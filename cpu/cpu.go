@@ -2,6 +2,7 @@ package cpu
 
 import (
 	"fmt"
+	"io"
 	"math/bits"
 	"slices"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/maxfierke/gogo-gb/cpu/isa"
 	"github.com/maxfierke/gogo-gb/devices"
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
 )
 
 const (
@@ -16,6 +18,10 @@ const (
 
 	REG_KEY1_ARMED_BIT         = 0
 	REG_KEY1_CURRENT_SPEED_BIT = 7
+
+	// SPEED_SWITCH_CYCLES is how long the CPU is paused for while switching
+	// speed (2050 M-cycles)
+	SPEED_SWITCH_CYCLES = 8200
 )
 
 const (
@@ -40,15 +46,42 @@ type CPU struct {
 	ime    bool
 	halted bool
 
+	// EI only enables interrupts after the following instruction has executed
+	eiPending bool
+
+	// haltEntered is set by HALT until interrupts are next polled, which decides
+	// whether the CPU actually halts
+	haltEntered bool
+
+	// haltBug is set when HALT is executed w/ IME=0 & an interrupt pending. The
+	// CPU doesn't halt, but fails to increment PC after the next opcode fetch,
+	// so that byte is read twice.
+	haltBug bool
+
+	// stopped is set by STOP, until a joypad line goes low. The system clock
+	// is stopped too, so nothing else advances in the meantime.
+	stopped bool
+	stop    func()
+
 	features []Feature
 	opcodes  *isa.Opcodes
 
+	// tick advances the rest of the system, one M-cycle per memory access, so
+	// that reads & writes mid-instruction see up-to-date timer, PPU, etc. state.
+	// Whatever cycles are left over are ticked at the end of the instruction.
+	tick   func(cycles uint8)
+	ticked uint8 // Cycles already ticked during the current instruction
+
 	// CGB-only
 	speedswitchArmed bool
 	doubleSpeed      bool
+	speedSwitchWait  uint16 // Cycles left until the CPU resumes after a speed switch
 }
 
-var _ mem.MemHandler = (*CPU)(nil)
+var (
+	_ mem.MemHandler       = (*CPU)(nil)
+	_ savestate.Serializer = (*CPU)(nil)
+)
 
 func NewCPU() (*CPU, error) {
 	cpu := new(CPU)
@@ -58,16 +91,17 @@ func NewCPU() (*CPU, error) {
 	cpu.halted = false
 	cpu.ime = true
 
-	opcodes, err := isa.LoadOpcodes()
-	if err != nil {
-		return nil, fmt.Errorf("loading opcodes: %w", err)
-	}
-
-	cpu.opcodes = opcodes
+	cpu.opcodes = isa.LoadOpcodes()
 
 	return cpu, nil
 }
 
+// ConnectTicker sets the callback used to advance the rest of the system as the
+// CPU executes
+func (cpu *CPU) ConnectTicker(tick func(cycles uint8)) {
+	cpu.tick = tick
+}
+
 func (cpu *CPU) IsDoubleSpeed() bool {
 	if cpu.HasFeature(FeatureDoubleSpeed) {
 		return cpu.doubleSpeed
@@ -80,6 +114,22 @@ func (cpu *CPU) IsHalted() bool {
 	return cpu.halted
 }
 
+func (cpu *CPU) IsStopped() bool {
+	return cpu.stopped
+}
+
+// ConnectStop sets the callback used after STOP is executed, once the CPU has
+// either stopped or switched speed. STOP resets DIV in both cases, which is
+// left to the callback.
+func (cpu *CPU) ConnectStop(stop func()) {
+	cpu.stop = stop
+}
+
+// ExitStop resumes execution after STOP, once a joypad line has gone low
+func (cpu *CPU) ExitStop() {
+	cpu.stopped = false
+}
+
 func (cpu *CPU) EnableFeature(feature Feature) error {
 	if !feature.Valid() {
 		return fmt.Errorf("unrecognized feature: %s", feature)
@@ -125,23 +175,66 @@ func (cpu *CPU) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 }
 
 func (cpu *CPU) Step(mmu *mem.MMU) (uint8, error) {
+	if cpu.stopped {
+		// Nothing else is ticked while the clock is stopped
+		return 4, nil
+	}
+
+	if cpu.speedSwitchWait > 0 {
+		// The CPU's paused while switching speed, but the rest of the system isn't
+		cycles := uint8(min(cpu.speedSwitchWait, 4))
+		cpu.speedSwitchWait -= uint16(cycles)
+		cpu.finishTicks(cycles)
+
+		return cycles, nil
+	}
+
 	if cpu.halted {
 		// HALT is 4 cycles
+		cpu.finishTicks(4)
+
 		return 4, nil
 	}
 
-	inst, err := cpu.FetchAndDecode(mmu, cpu.PC.Read())
+	if cpu.eiPending {
+		cpu.eiPending = false
+		cpu.ime = true
+	}
+
+	// Opcode fetches take an M-cycle each, like any other read
+	cpu.tickCycle()
+
+	pc := cpu.PC.Read()
+	inst, err := cpu.FetchAndDecode(mmu, pc)
 	if err != nil {
 		return 0, err
 	}
 
+	if cpu.haltBug {
+		cpu.haltBug = false
+
+		// PC wasn't incremented past the opcode, so it's also read as the first
+		// byte that follows it. Executing from one byte back has the same effect
+		// on operands & the next PC. For CB, the prefix is read twice.
+		if inst.Opcode.CbPrefixed {
+			inst, _ = cpu.opcodes.InstructionFromByte(pc, 0xCB, true)
+		}
+
+		cpu.PC.Write(pc - 1)
+	}
+
+	if inst.Opcode.CbPrefixed {
+		cpu.tickCycle()
+	}
+
 	nextPc, cycles, err := cpu.Execute(mmu, inst)
 	cpu.PC.Write(nextPc)
+	cpu.finishTicks(cycles)
 
 	return cycles, err
 }
 
-func (cpu *CPU) FetchAndDecode(mmu *mem.MMU, addr uint16) (*isa.Instruction, error) {
+func (cpu *CPU) FetchAndDecode(mmu *mem.MMU, addr uint16) (isa.Instruction, error) {
 	// Fetch :)
 	opcodeByte := mmu.Read8(addr)
 	prefixed := opcodeByte == 0xCB
@@ -156,16 +249,16 @@ func (cpu *CPU) FetchAndDecode(mmu *mem.MMU, addr uint16) (*isa.Instruction, err
 
 	if !exist {
 		if prefixed {
-			return nil, fmt.Errorf("unimplemented instruction found @ 0x%04X: 0xCB%02X", addr, opcodeByte)
+			return isa.Instruction{}, fmt.Errorf("unimplemented instruction found @ 0x%04X: 0xCB%02X", addr, opcodeByte)
 		} else {
-			return nil, fmt.Errorf("unimplemented instruction found @ 0x%04X: 0x%02X", addr, opcodeByte)
+			return isa.Instruction{}, fmt.Errorf("unimplemented instruction found @ 0x%04X: 0x%02X", addr, opcodeByte)
 		}
 	}
 
 	return inst, nil
 }
 
-func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cycles uint8, err error) {
+func (cpu *CPU) Execute(mmu *mem.MMU, inst isa.Instruction) (nextPC uint16, cycles uint8, err error) {
 	opcode := inst.Opcode
 
 	if opcode.CbPrefixed {
@@ -191,7 +284,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x06:
 			// RLC (HL)
 			addr := cpu.Reg.HL.Read()
-			mmu.Write8(addr, cpu.rotl(mmu.Read8(addr), true, false))
+			cpu.write8(mmu, addr, cpu.rotl(cpu.read8(mmu, addr), true, false))
 		case 0x07:
 			// RLC A
 			cpu.Reg.A.Write(cpu.rotl(cpu.Reg.A.Read(), true, false))
@@ -216,7 +309,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x0E:
 			// RRC (HL)
 			addr := cpu.Reg.HL.Read()
-			mmu.Write8(addr, cpu.rotr(mmu.Read8(addr), true, false))
+			cpu.write8(mmu, addr, cpu.rotr(cpu.read8(mmu, addr), true, false))
 		case 0x0F:
 			// RRC A
 			cpu.Reg.A.Write(cpu.rotr(cpu.Reg.A.Read(), true, false))
@@ -241,7 +334,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x16:
 			// RL (HL)
 			addr := cpu.Reg.HL.Read()
-			mmu.Write8(addr, cpu.rotl(mmu.Read8(addr), true, true))
+			cpu.write8(mmu, addr, cpu.rotl(cpu.read8(mmu, addr), true, true))
 		case 0x17:
 			// RL A
 			cpu.Reg.A.Write(cpu.rotl(cpu.Reg.A.Read(), true, true))
@@ -266,7 +359,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x1E:
 			// RR (HL)
 			addr := cpu.Reg.HL.Read()
-			mmu.Write8(addr, cpu.rotr(mmu.Read8(addr), true, true))
+			cpu.write8(mmu, addr, cpu.rotr(cpu.read8(mmu, addr), true, true))
 		case 0x1F:
 			// RR A
 			cpu.Reg.A.Write(cpu.rotr(cpu.Reg.A.Read(), true, true))
@@ -291,7 +384,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x26:
 			// SLA (HL)
 			addr := cpu.Reg.HL.Read()
-			mmu.Write8(addr, cpu.sla(mmu.Read8(addr)))
+			cpu.write8(mmu, addr, cpu.sla(cpu.read8(mmu, addr)))
 		case 0x27:
 			// SLA A
 			cpu.Reg.A.Write(cpu.sla(cpu.Reg.A.Read()))
@@ -316,7 +409,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x2E:
 			// SRA (HL)
 			addr := cpu.Reg.HL.Read()
-			mmu.Write8(addr, cpu.sra(mmu.Read8(addr)))
+			cpu.write8(mmu, addr, cpu.sra(cpu.read8(mmu, addr)))
 		case 0x2F:
 			// SRA A
 			cpu.Reg.A.Write(cpu.sra(cpu.Reg.A.Read()))
@@ -341,9 +434,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x36:
 			// SWAP (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.swap(&cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0x37:
 			// SWAP A
 			cpu.swap(cpu.Reg.A)
@@ -368,7 +461,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x3E:
 			// SRL (HL)
 			addr := cpu.Reg.HL.Read()
-			mmu.Write8(addr, cpu.srl(mmu.Read8(addr)))
+			cpu.write8(mmu, addr, cpu.srl(cpu.read8(mmu, addr)))
 		case 0x3F:
 			// SRL A
 			cpu.Reg.A.Write(cpu.srl(cpu.Reg.A.Read()))
@@ -393,7 +486,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x46:
 			// BIT 0, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.testBit(0, &cell)
 		case 0x47:
 			// BIT 0, A
@@ -419,7 +512,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x4E:
 			// BIT 1, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.testBit(1, &cell)
 		case 0x4F:
 			// BIT 1, A
@@ -445,7 +538,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x56:
 			// BIT 2, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.testBit(2, &cell)
 		case 0x57:
 			// BIT 2, A
@@ -471,7 +564,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x5E:
 			// BIT 3, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.testBit(3, &cell)
 		case 0x5F:
 			// BIT 3, A
@@ -497,7 +590,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x66:
 			// BIT 4, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.testBit(4, &cell)
 		case 0x67:
 			// BIT 4, A
@@ -523,7 +616,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x6E:
 			// BIT 5, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.testBit(5, &cell)
 		case 0x6F:
 			// BIT 5, A
@@ -549,7 +642,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x76:
 			// BIT 6, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.testBit(6, &cell)
 		case 0x77:
 			// BIT 6, A
@@ -575,7 +668,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x7E:
 			// BIT 7, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.testBit(7, &cell)
 		case 0x7F:
 			// BIT 7, A
@@ -601,9 +694,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x86:
 			// RES 0, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.resetBit(0, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0x87:
 			// RES 0, A
 			cpu.resetBit(0, cpu.Reg.A)
@@ -628,9 +721,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x8E:
 			// RES 1, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.resetBit(1, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0x8F:
 			// RES 1, A
 			cpu.resetBit(1, cpu.Reg.A)
@@ -655,9 +748,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x96:
 			// RES 2, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.resetBit(2, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0x97:
 			// RES 2, A
 			cpu.resetBit(2, cpu.Reg.A)
@@ -682,9 +775,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x9E:
 			// RES 3, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.resetBit(3, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0x9F:
 			// RES 3, A
 			cpu.resetBit(3, cpu.Reg.A)
@@ -709,9 +802,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xA6:
 			// RES 4, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.resetBit(4, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0xA7:
 			// RES 4, A
 			cpu.resetBit(4, cpu.Reg.A)
@@ -736,9 +829,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xAE:
 			// RES 5, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.resetBit(5, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0xAF:
 			// RES 5, A
 			cpu.resetBit(5, cpu.Reg.A)
@@ -763,9 +856,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xB6:
 			// RES 6, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.resetBit(6, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0xB7:
 			// RES 6, A
 			cpu.resetBit(6, cpu.Reg.A)
@@ -790,9 +883,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xBE:
 			// RES 7, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.resetBit(7, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0xBF:
 			// RES 7, A
 			cpu.resetBit(7, cpu.Reg.A)
@@ -817,9 +910,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xC6:
 			// SET 0, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.setBit(0, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0xC7:
 			// SET 0, A
 			cpu.setBit(0, cpu.Reg.A)
@@ -844,9 +937,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xCE:
 			// SET 1, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.setBit(1, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0xCF:
 			// SET 1, A
 			cpu.setBit(1, cpu.Reg.A)
@@ -871,9 +964,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xD6:
 			// SET 2, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.setBit(2, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0xD7:
 			// SET 2, A
 			cpu.setBit(2, cpu.Reg.A)
@@ -898,9 +991,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xDE:
 			// SET 3, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.setBit(3, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0xDF:
 			// SET 3, A
 			cpu.setBit(3, cpu.Reg.A)
@@ -925,9 +1018,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xE6:
 			// SET 4, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.setBit(4, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0xE7:
 			// SET 4, A
 			cpu.setBit(4, cpu.Reg.A)
@@ -952,9 +1045,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xEE:
 			// SET 5, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.setBit(5, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0xEF:
 			// SET 5, A
 			cpu.setBit(5, cpu.Reg.A)
@@ -979,9 +1072,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xF6:
 			// SET 6, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.setBit(6, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0xF7:
 			// SET 6, A
 			cpu.setBit(6, cpu.Reg.A)
@@ -1006,9 +1099,9 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xFE:
 			// SET 7, (HL)
 			addr := cpu.Reg.HL.Read()
-			cell := ByteCell{value: mmu.Read8(addr)}
+			cell := ByteCell{value: cpu.read8(mmu, addr)}
 			cpu.setBit(7, &cell)
-			mmu.Write8(addr, cell.Read())
+			cpu.write8(mmu, addr, cell.Read())
 		case 0xFF:
 			// SET 7, A
 			cpu.setBit(7, cpu.Reg.A)
@@ -1058,7 +1151,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.add16(cpu.Reg.HL, cpu.Reg.BC.Read())
 		case 0x0A:
 			// LD A, (BC)
-			cpu.load8(cpu.Reg.A, mmu.Read8(cpu.Reg.BC.Read()))
+			cpu.load8(cpu.Reg.A, cpu.read8(mmu, cpu.Reg.BC.Read()))
 		case 0x0B:
 			// DEC BC
 			cpu.Reg.BC.Dec(1)
@@ -1085,6 +1178,13 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			if cpu.HasFeature(FeatureDoubleSpeed) && cpu.speedswitchArmed {
 				cpu.doubleSpeed = !cpu.doubleSpeed
 				cpu.speedswitchArmed = false
+				cpu.speedSwitchWait = SPEED_SWITCH_CYCLES
+			} else {
+				cpu.stopped = true
+			}
+
+			if cpu.stop != nil {
+				cpu.stop()
 			}
 		case 0x11:
 			// LD DE, n16
@@ -1115,7 +1215,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.add16(cpu.Reg.HL, cpu.Reg.DE.Read())
 		case 0x1A:
 			// LD A, (DE)
-			cpu.load8(cpu.Reg.A, mmu.Read8(cpu.Reg.DE.Read()))
+			cpu.load8(cpu.Reg.A, cpu.read8(mmu, cpu.Reg.DE.Read()))
 		case 0x1B:
 			// DEC DE
 			cpu.Reg.DE.Dec(1)
@@ -1164,7 +1264,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.add16(cpu.Reg.HL, cpu.Reg.HL.Read())
 		case 0x2A:
 			// LDI A, (HL+)
-			cpu.load8(cpu.Reg.A, mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.load8(cpu.Reg.A, cpu.read8(mmu, cpu.Reg.HL.Read()))
 			cpu.Reg.HL.Inc(1)
 		case 0x2B:
 			// DEC HL
@@ -1196,16 +1296,16 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.SP.Inc(1)
 		case 0x34:
 			// INC (HL)
-			value := mmu.Read8(cpu.Reg.HL.Read())
+			value := cpu.read8(mmu, cpu.Reg.HL.Read())
 			cell := ByteCell{value: value}
 			cpu.inc8(&cell)
-			mmu.Write8(cpu.Reg.HL.Read(), cell.Read())
+			cpu.write8(mmu, cpu.Reg.HL.Read(), cell.Read())
 		case 0x35:
 			// DEC (HL)
-			value := mmu.Read8(cpu.Reg.HL.Read())
+			value := cpu.read8(mmu, cpu.Reg.HL.Read())
 			cell := ByteCell{value: value}
 			cpu.dec8(&cell)
-			mmu.Write8(cpu.Reg.HL.Read(), cell.Read())
+			cpu.write8(mmu, cpu.Reg.HL.Read(), cell.Read())
 		case 0x36:
 			// LD (HL), n8
 			value := cpu.readNext8(mmu)
@@ -1224,7 +1324,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.add16(cpu.Reg.HL, cpu.SP.Read())
 		case 0x3A:
 			// LD A, (HL-)
-			cpu.load8(cpu.Reg.A, mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.load8(cpu.Reg.A, cpu.read8(mmu, cpu.Reg.HL.Read()))
 			cpu.Reg.HL.Dec(1)
 		case 0x3B:
 			// DEC SP
@@ -1263,7 +1363,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.load8(cpu.Reg.B, cpu.Reg.L.Read())
 		case 0x46:
 			// LD B, (HL)
-			cpu.load8(cpu.Reg.B, mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.load8(cpu.Reg.B, cpu.read8(mmu, cpu.Reg.HL.Read()))
 		case 0x47:
 			// LD B, A
 			cpu.load8(cpu.Reg.B, cpu.Reg.A.Read())
@@ -1287,7 +1387,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.load8(cpu.Reg.C, cpu.Reg.L.Read())
 		case 0x4E:
 			// LD C, (HL)
-			cpu.load8(cpu.Reg.C, mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.load8(cpu.Reg.C, cpu.read8(mmu, cpu.Reg.HL.Read()))
 		case 0x4F:
 			// LD C, A
 			cpu.load8(cpu.Reg.C, cpu.Reg.A.Read())
@@ -1311,7 +1411,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.load8(cpu.Reg.D, cpu.Reg.L.Read())
 		case 0x56:
 			// LD D, (HL)
-			cpu.load8(cpu.Reg.D, mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.load8(cpu.Reg.D, cpu.read8(mmu, cpu.Reg.HL.Read()))
 		case 0x57:
 			// LD D, A
 			cpu.load8(cpu.Reg.D, cpu.Reg.A.Read())
@@ -1335,7 +1435,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.load8(cpu.Reg.E, cpu.Reg.L.Read())
 		case 0x5E:
 			// LD E, (HL)
-			cpu.load8(cpu.Reg.E, mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.load8(cpu.Reg.E, cpu.read8(mmu, cpu.Reg.HL.Read()))
 		case 0x5F:
 			// LD E, A
 			cpu.load8(cpu.Reg.E, cpu.Reg.A.Read())
@@ -1359,7 +1459,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.load8(cpu.Reg.H, cpu.Reg.L.Read())
 		case 0x66:
 			// LD H, (HL)
-			cpu.load8(cpu.Reg.H, mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.load8(cpu.Reg.H, cpu.read8(mmu, cpu.Reg.HL.Read()))
 		case 0x67:
 			// LD H, A
 			cpu.load8(cpu.Reg.H, cpu.Reg.A.Read())
@@ -1383,7 +1483,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.load8(cpu.Reg.L, cpu.Reg.L.Read())
 		case 0x6E:
 			// LD L, (HL)
-			cpu.load8(cpu.Reg.L, mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.load8(cpu.Reg.L, cpu.read8(mmu, cpu.Reg.HL.Read()))
 		case 0x6F:
 			// LD L, A
 			cpu.load8(cpu.Reg.L, cpu.Reg.A.Read())
@@ -1408,6 +1508,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0x76:
 			// HALT
 			cpu.halted = true
+			cpu.haltEntered = true
 		case 0x77:
 			// LD (HL), A
 			cpu.load8Indirect(mmu, cpu.Reg.HL.Read(), cpu.Reg.A)
@@ -1431,7 +1532,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.load8(cpu.Reg.A, cpu.Reg.L.Read())
 		case 0x7E:
 			// LD A, (HL)
-			cpu.load8(cpu.Reg.A, mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.load8(cpu.Reg.A, cpu.read8(mmu, cpu.Reg.HL.Read()))
 		case 0x7F:
 			// LD A, A
 			cpu.load8(cpu.Reg.A, cpu.Reg.A.Read())
@@ -1455,7 +1556,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.add8(cpu.Reg.A, cpu.Reg.L.Read(), false)
 		case 0x86:
 			// ADD A, (HL)
-			cpu.add8(cpu.Reg.A, mmu.Read8(cpu.Reg.HL.Read()), false)
+			cpu.add8(cpu.Reg.A, cpu.read8(mmu, cpu.Reg.HL.Read()), false)
 		case 0x87:
 			// ADD A, A
 			cpu.add8(cpu.Reg.A, cpu.Reg.A.Read(), false)
@@ -1479,7 +1580,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.add8(cpu.Reg.A, cpu.Reg.L.Read(), true)
 		case 0x8E:
 			// ADC A, (HL)
-			cpu.add8(cpu.Reg.A, mmu.Read8(cpu.Reg.HL.Read()), true)
+			cpu.add8(cpu.Reg.A, cpu.read8(mmu, cpu.Reg.HL.Read()), true)
 		case 0x8F:
 			// ADC A, A
 			cpu.add8(cpu.Reg.A, cpu.Reg.A.Read(), true)
@@ -1503,7 +1604,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.sub8(cpu.Reg.A, cpu.Reg.L.Read(), false)
 		case 0x96:
 			// SUB A, (HL)
-			cpu.sub8(cpu.Reg.A, mmu.Read8(cpu.Reg.HL.Read()), false)
+			cpu.sub8(cpu.Reg.A, cpu.read8(mmu, cpu.Reg.HL.Read()), false)
 		case 0x97:
 			// SUB A, A
 			cpu.sub8(cpu.Reg.A, cpu.Reg.A.Read(), false)
@@ -1527,7 +1628,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.sub8(cpu.Reg.A, cpu.Reg.L.Read(), true)
 		case 0x9E:
 			// SBC A, (HL)
-			cpu.sub8(cpu.Reg.A, mmu.Read8(cpu.Reg.HL.Read()), true)
+			cpu.sub8(cpu.Reg.A, cpu.read8(mmu, cpu.Reg.HL.Read()), true)
 		case 0x9F:
 			// SBC A, A
 			cpu.sub8(cpu.Reg.A, cpu.Reg.A.Read(), true)
@@ -1551,7 +1652,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.and(cpu.Reg.L.Read())
 		case 0xA6:
 			// AND A, (HL)
-			cpu.and(mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.and(cpu.read8(mmu, cpu.Reg.HL.Read()))
 		case 0xA7:
 			// AND A, A
 			cpu.and(cpu.Reg.A.Read())
@@ -1575,7 +1676,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.xor(cpu.Reg.L.Read())
 		case 0xAE:
 			// XOR A, (HL)
-			cpu.xor(mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.xor(cpu.read8(mmu, cpu.Reg.HL.Read()))
 		case 0xAF:
 			// XOR A, A
 			cpu.xor(cpu.Reg.A.Read())
@@ -1599,7 +1700,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.or(cpu.Reg.L.Read())
 		case 0xB6:
 			// OR A, (HL)
-			cpu.or(mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.or(cpu.read8(mmu, cpu.Reg.HL.Read()))
 		case 0xB7:
 			// OR A, A
 			cpu.or(cpu.Reg.A.Read())
@@ -1623,7 +1724,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.compare(cpu.Reg.L.Read())
 		case 0xBE:
 			// CP A, (HL)
-			cpu.compare(mmu.Read8(cpu.Reg.HL.Read()))
+			cpu.compare(cpu.read8(mmu, cpu.Reg.HL.Read()))
 		case 0xBF:
 			// CP A, A
 			cpu.compare(cpu.Reg.A.Read())
@@ -1751,7 +1852,7 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xF0:
 			// LDH A, (a8)
 			addr := 0xFF00 + uint16(cpu.readNext8(mmu))
-			value := mmu.Read8(addr)
+			value := cpu.read8(mmu, addr)
 			cpu.load8(cpu.Reg.A, value)
 		case 0xF1:
 			// POP AF
@@ -1759,11 +1860,12 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 		case 0xF2:
 			// LD A, (0xFF00 + C)
 			addr := 0xFF00 + uint16(cpu.Reg.C.Read())
-			value := mmu.Read8(addr)
+			value := cpu.read8(mmu, addr)
 			cpu.load8(cpu.Reg.A, value)
 		case 0xF3:
 			// DI
 			cpu.ime = false
+			cpu.eiPending = false
 		case 0xF5:
 			// PUSH AF
 			cpu.push(mmu, cpu.Reg.AF.Read())
@@ -1783,10 +1885,10 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 			cpu.load16(cpu.SP, cpu.Reg.HL.Read())
 		case 0xFB:
 			// EI
-			cpu.ime = true
+			cpu.eiPending = !cpu.ime
 		case 0xFA:
 			// LD A, (a16)
-			cpu.load8(cpu.Reg.A, mmu.Read8(cpu.readNext16(mmu)))
+			cpu.load8(cpu.Reg.A, cpu.read8(mmu, cpu.readNext16(mmu)))
 		case 0xFE:
 			// CP A, n8
 			cpu.compare(cpu.readNext8(mmu))
@@ -1802,25 +1904,60 @@ func (cpu *CPU) Execute(mmu *mem.MMU, inst *isa.Instruction) (nextPC uint16, cyc
 }
 
 func (cpu *CPU) PollInterrupts(mmu *mem.MMU, ic *devices.InterruptController) (bool, uint8) {
+	if cpu.stopped || cpu.speedSwitchWait > 0 {
+		return false, 0
+	}
+
+	if cpu.haltEntered {
+		cpu.haltEntered = false
+
+		// HALT doesn't halt if an interrupt is already pending. W/o IME to
+		// dispatch it, this is the HALT bug.
+		if ic.NextRequest() != devices.INT_NONE {
+			cpu.halted = false
+			cpu.haltBug = !cpu.ime
+		}
+	}
+
 	if cpu.ime {
-		interrupt := ic.ConsumeRequest()
-		if interrupt == devices.INT_NONE {
+		if ic.NextRequest() == devices.INT_NONE {
 			return false, 0
 		}
 
 		// Disable interrupts while we process this one
 		cpu.ime = false
 
+		// Dispatching an interrupt is 20 cycles (Or 5 M-cycles), plus another
+		// M-cycle to wake up if we were halted
+		// ref: https://gbdev.io/pandocs/Interrupts.html#interrupt-handling
+		var cycles uint8 = 20
+		if cpu.halted {
+			cycles += 4
+			cpu.halted = false
+			cpu.tickCycle()
+		}
+
+		// Two idle M-cycles, then PC is pushed a byte at a time
+		cpu.tickCycle()
+		cpu.tickCycle()
+
+		pc := cpu.PC.Read()
+		cpu.SP.Dec(1)
+		cpu.write8(mmu, cpu.SP.Read(), uint8(pc>>8))
+
+		// Which interrupt is dispatched isn't decided until after the high byte's
+		// pushed. If that overwrote IE & cancelled it, there's nothing to consume,
+		// & we jump to 0x0000 instead.
+		interrupt := ic.ConsumeRequest()
+
+		cpu.SP.Dec(1)
+		cpu.write8(mmu, cpu.SP.Read(), uint8(pc))
+
 		// Jump to interrupt handler
-		cpu.push(mmu, cpu.PC.Read())
 		cpu.PC.Write(uint16(interrupt))
+		cpu.finishTicks(cycles)
 
-		// If we were halted, we're not now!
-		cpu.halted = false
-
-		// Consuming an IRQ is 20 cycles (Or 5 M-cycles)
-		// ref: https://gbdev.io/pandocs/Interrupts.html#interrupt-handling
-		return true, 20
+		return true, cycles
 	} else if cpu.halted {
 		if interrupt := ic.NextRequest(); interrupt != 0 {
 			// Wakey-wakey
@@ -1840,6 +1977,11 @@ func (cpu *CPU) Reset() {
 	cpu.SP.Write(0x0000)
 	cpu.ime = true
 	cpu.halted = false
+	cpu.eiPending = false
+	cpu.haltEntered = false
+	cpu.haltBug = false
+	cpu.stopped = false
+	cpu.speedSwitchWait = 0
 }
 
 // Reset CPU and registers to post-boot ROM state
@@ -1857,6 +1999,19 @@ func (cpu *CPU) ResetToBootROM() {
 	cpu.PC.Write(0x100)
 	cpu.ime = true
 	cpu.halted = false
+	cpu.eiPending = false
+	cpu.haltEntered = false
+	cpu.haltBug = false
+	cpu.stopped = false
+	cpu.speedSwitchWait = 0
+}
+
+func (cpu *CPU) LoadState(r io.Reader) error {
+	return savestate.Read(r, cpu.stateFields()...)
+}
+
+func (cpu *CPU) SaveState(w io.Writer) error {
+	return savestate.Write(w, cpu.stateFields()...)
 }
 
 func (cpu *CPU) add8(reg RWByte, value uint8, withCarry bool) uint8 {
@@ -2053,9 +2208,11 @@ func (cpu *CPU) call(mmu *mem.MMU, opcode *isa.Opcode, shouldJump bool) (nextPC
 	nextPC = cpu.PC.Read() + uint16(opcode.Bytes)
 
 	if shouldJump {
+		// The address is read before the return address is pushed
+		addr := cpu.readNext16(mmu)
 		cpu.push(mmu, nextPC)
 
-		return mmu.Read16(cpu.PC.Read() + 1), uint8(opcode.Cycles[0]), nil
+		return addr, uint8(opcode.Cycles[0]), nil
 	}
 
 	return nextPC, uint8(opcode.Cycles[1]), nil
@@ -2066,7 +2223,7 @@ func (cpu *CPU) load8(reg RWByte, value byte) {
 }
 
 func (cpu *CPU) load8Indirect(mmu *mem.MMU, addr uint16, reg RWByte) {
-	mmu.Write8(addr, reg.Read())
+	cpu.write8(mmu, addr, reg.Read())
 }
 
 func (cpu *CPU) load16(reg RWTwoByte, value uint16) {
@@ -2074,7 +2231,7 @@ func (cpu *CPU) load16(reg RWTwoByte, value uint16) {
 }
 
 func (cpu *CPU) load16Indirect(mmu *mem.MMU, addr uint16, reg RWTwoByte) {
-	mmu.Write16(addr, reg.Read())
+	cpu.write16(mmu, addr, reg.Read())
 }
 
 func (cpu *CPU) jump(mmu *mem.MMU, opcode *isa.Opcode, shouldJump bool) (nextPC uint16, cycles uint8, err error) {
@@ -2098,23 +2255,43 @@ func (cpu *CPU) jumpRel(mmu *mem.MMU, opcode *isa.Opcode, shouldJump bool) (next
 }
 
 func (cpu *CPU) pop(mmu *mem.MMU) uint16 {
-	value := mmu.Read16(cpu.SP.Read())
+	value := cpu.read16(mmu, cpu.SP.Read())
 	cpu.SP.Inc(2)
 
 	return value
 }
 
+// push takes an internal M-cycle before writing to the stack, as PUSH, CALL &
+// RST all do
 func (cpu *CPU) push(mmu *mem.MMU, value uint16) {
-	cpu.SP.Dec(2)
-	mmu.Write16(cpu.SP.Read(), value)
+	cpu.tickCycle()
+
+	// The high byte is pushed first
+	cpu.SP.Dec(1)
+	cpu.write8(mmu, cpu.SP.Read(), uint8(value>>8))
+	cpu.SP.Dec(1)
+	cpu.write8(mmu, cpu.SP.Read(), uint8(value))
+}
+
+func (cpu *CPU) read8(mmu *mem.MMU, addr uint16) byte {
+	cpu.tickCycle()
+
+	return mmu.Read8(addr)
+}
+
+func (cpu *CPU) read16(mmu *mem.MMU, addr uint16) uint16 {
+	low := cpu.read8(mmu, addr)
+	high := cpu.read8(mmu, addr+1)
+
+	return uint16(high)<<8 | uint16(low)
 }
 
 func (cpu *CPU) readNext8(mmu *mem.MMU) byte {
-	return mmu.Read8(cpu.PC.Read() + 1)
+	return cpu.read8(mmu, cpu.PC.Read()+1)
 }
 
 func (cpu *CPU) readNext16(mmu *mem.MMU) uint16 {
-	return mmu.Read16(cpu.PC.Read() + 1)
+	return cpu.read16(mmu, cpu.PC.Read()+1)
 }
 
 func (cpu *CPU) ret(mmu *mem.MMU, opcode *isa.Opcode, shouldJump bool) (nextPC uint16, cycles uint8, err error) {
@@ -2234,6 +2411,59 @@ func (cpu *CPU) resetBit(bit uint8, reg RWByte) {
 	reg.Write(value & mask)
 }
 
+// finishTicks ticks whatever's left of an instruction (or interrupt dispatch)
+// taking cycles, after the M-cycles already ticked for its memory accesses
+func (cpu *CPU) finishTicks(cycles uint8) {
+	if cycles > cpu.ticked && cpu.tick != nil {
+		cpu.tick(cycles - cpu.ticked)
+	}
+
+	cpu.ticked = 0
+}
+
+func (cpu *CPU) stateFields() []any {
+	return []any{
+		&cpu.Reg.A.value,
+		&cpu.Reg.B.value,
+		&cpu.Reg.C.value,
+		&cpu.Reg.D.value,
+		&cpu.Reg.E.value,
+		cpu.Reg.F,
+		&cpu.Reg.H.value,
+		&cpu.Reg.L.value,
+		&cpu.PC.value,
+		&cpu.SP.value,
+		&cpu.ime,
+		&cpu.halted,
+		&cpu.speedswitchArmed,
+		&cpu.doubleSpeed,
+		&cpu.eiPending,
+		&cpu.haltBug,
+		&cpu.stopped,
+		&cpu.speedSwitchWait,
+	}
+}
+
+// tickCycle ticks the M-cycle of a memory access. The access itself happens at
+// the end of the M-cycle.
+func (cpu *CPU) tickCycle() {
+	cpu.ticked += 4
+
+	if cpu.tick != nil {
+		cpu.tick(4)
+	}
+}
+
+func (cpu *CPU) write8(mmu *mem.MMU, addr uint16, value byte) {
+	cpu.tickCycle()
+	mmu.Write8(addr, value)
+}
+
+func (cpu *CPU) write16(mmu *mem.MMU, addr uint16, value uint16) {
+	cpu.write8(mmu, addr, uint8(value))
+	cpu.write8(mmu, addr+1, uint8(value>>8))
+}
+
 // Did the aVal carry over from the lower 4 bits to the upper 4 bits?
 func isHalfCarry8(aVal uint8, bVal uint8, carry uint8) bool {
 	fourBitMask := uint(0xF)
@@ -3,6 +3,7 @@ package cpu
 import (
 	"testing"
 
+	"github.com/maxfierke/gogo-gb/devices"
 	"github.com/maxfierke/gogo-gb/mem"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1715,9 +1716,18 @@ func TestExecuteEi(t *testing.T) {
 	cpu, _ := NewCPU()
 	cpu.ime = false
 
-	inst, _ := cpu.opcodes.InstructionFromByte(cpu.PC.Read(), 0xFB, false)
+	mmu := mem.NewMMU(make([]byte, testRamSize))
+	mmu.Write8(0x0000, 0xFB) // EI
+	mmu.Write8(0x0001, 0x00) // NOP
 
-	_, _, err := cpu.Execute(NULL_MMU, inst)
+	_, err := cpu.Step(mmu)
+	require.NoError(err)
+
+	if cpu.ime {
+		t.Errorf("Expected IME flag to be disabled until after the next instruction, but it was enabled")
+	}
+
+	_, err = cpu.Step(mmu)
 	require.NoError(err)
 
 	if !cpu.ime {
@@ -1741,6 +1751,230 @@ func TestExecuteHalt(t *testing.T) {
 	}
 }
 
+// newInterruptTestCPU sets up a CPU to run code from 0x100, w/ the timer
+// interrupt enabled, but not requested
+func newInterruptTestCPU(t *testing.T, code ...byte) (*CPU, *mem.MMU, *devices.InterruptController) {
+	t.Helper()
+
+	cpu, err := NewCPU()
+	require.NoError(t, err)
+
+	mmu := mem.NewMMU(make([]byte, testRamSize))
+	for i, b := range code {
+		mmu.Write8(0x100+uint16(i), b)
+	}
+
+	ic := devices.NewInterruptController()
+	mmu.AddHandler(mem.MemRegion{Start: devices.REG_IF, End: devices.REG_IF}, ic)
+	mmu.AddHandler(mem.MemRegion{Start: devices.REG_IE, End: devices.REG_IE}, ic)
+	mmu.Write8(devices.REG_IE, 0x04)
+
+	cpu.PC.Write(0x100)
+	cpu.SP.Write(0xFFFE)
+	cpu.ime = false
+
+	return cpu, mmu, ic
+}
+
+// stepWithInterrupts steps the CPU like the console does, returning whether an
+// interrupt was dispatched
+func stepWithInterrupts(t *testing.T, cpu *CPU, mmu *mem.MMU, ic *devices.InterruptController) bool {
+	t.Helper()
+
+	_, err := cpu.Step(mmu)
+	require.NoError(t, err)
+
+	dispatched, _ := cpu.PollInterrupts(mmu, ic)
+
+	return dispatched
+}
+
+func TestHaltBug(t *testing.T) {
+	assert := assert.New(t)
+
+	cpu, mmu, ic := newInterruptTestCPU(t,
+		0x76,       // HALT
+		0x3E, 0x14, // LD A, n8
+	)
+	mmu.Write8(devices.REG_IF, 0x04)
+
+	stepWithInterrupts(t, cpu, mmu, ic)
+	assert.False(cpu.IsHalted())
+	assert.Equal(uint16(0x101), cpu.PC.Read())
+
+	// The opcode is read again as the operand, then the operand as an opcode
+	stepWithInterrupts(t, cpu, mmu, ic)
+	assert.Equal(uint8(0x3E), cpu.Reg.A.Read())
+	assert.Equal(uint16(0x102), cpu.PC.Read())
+
+	stepWithInterrupts(t, cpu, mmu, ic)
+	assert.Equal(uint8(0x01), cpu.Reg.D.Read())
+	assert.Equal(uint16(0x103), cpu.PC.Read())
+}
+
+func TestHaltBugCBPrefix(t *testing.T) {
+	assert := assert.New(t)
+
+	cpu, mmu, ic := newInterruptTestCPU(t,
+		0x76,       // HALT
+		0xCB, 0x37, // SWAP A
+	)
+	mmu.Write8(devices.REG_IF, 0x04)
+
+	stepWithInterrupts(t, cpu, mmu, ic)
+
+	// Executed as SET 1, E
+	stepWithInterrupts(t, cpu, mmu, ic)
+	assert.Equal(uint8(0b10), cpu.Reg.E.Read())
+	assert.Equal(uint16(0x102), cpu.PC.Read())
+}
+
+func TestHaltIME0WakesWithoutDispatch(t *testing.T) {
+	assert := assert.New(t)
+
+	cpu, mmu, ic := newInterruptTestCPU(t,
+		0x76, // HALT
+		0x3C, // INC A
+	)
+
+	stepWithInterrupts(t, cpu, mmu, ic)
+	assert.True(cpu.IsHalted())
+
+	stepWithInterrupts(t, cpu, mmu, ic)
+	assert.True(cpu.IsHalted())
+
+	mmu.Write8(devices.REG_IF, 0x04)
+	assert.False(stepWithInterrupts(t, cpu, mmu, ic))
+	assert.False(cpu.IsHalted())
+
+	// Carries on after HALT, w/o the HALT bug
+	stepWithInterrupts(t, cpu, mmu, ic)
+	assert.Equal(uint8(0x01), cpu.Reg.A.Read())
+	assert.Equal(uint16(0x102), cpu.PC.Read())
+	assert.Equal(uint8(0x04), mmu.Read8(devices.REG_IF)&0x1F)
+}
+
+func TestHaltIME1DispatchTakesExtraCycle(t *testing.T) {
+	assert := assert.New(t)
+
+	cpu, mmu, ic := newInterruptTestCPU(t,
+		0x76, // HALT
+	)
+	cpu.ime = true
+
+	stepWithInterrupts(t, cpu, mmu, ic)
+	assert.True(cpu.IsHalted())
+
+	mmu.Write8(devices.REG_IF, 0x04)
+	_, err := cpu.Step(mmu)
+	require.NoError(t, err)
+
+	dispatched, cycles := cpu.PollInterrupts(mmu, ic)
+	assert.True(dispatched)
+	assert.Equal(uint8(24), cycles)
+	assert.Equal(uint16(devices.INT_TIMER), cpu.PC.Read())
+	assert.Equal(uint16(0x101), mmu.Read16(cpu.SP.Read()))
+}
+
+func TestEIDelaysInterrupt(t *testing.T) {
+	assert := assert.New(t)
+
+	cpu, mmu, ic := newInterruptTestCPU(t,
+		0xFB, // EI
+		0x3C, // INC A
+		0x3C, // INC A
+	)
+	mmu.Write8(devices.REG_IF, 0x04)
+
+	assert.False(stepWithInterrupts(t, cpu, mmu, ic))
+	assert.True(stepWithInterrupts(t, cpu, mmu, ic))
+	assert.Equal(uint8(0x01), cpu.Reg.A.Read())
+	assert.Equal(uint16(0x102), mmu.Read16(cpu.SP.Read()))
+}
+
+func TestEIThenDIDoesNotDispatch(t *testing.T) {
+	assert := assert.New(t)
+
+	cpu, mmu, ic := newInterruptTestCPU(t,
+		0xFB, // EI
+		0xF3, // DI
+		0x3C, // INC A
+	)
+	mmu.Write8(devices.REG_IF, 0x04)
+
+	assert.False(stepWithInterrupts(t, cpu, mmu, ic))
+	assert.False(stepWithInterrupts(t, cpu, mmu, ic))
+	assert.False(stepWithInterrupts(t, cpu, mmu, ic))
+	assert.False(cpu.ime)
+}
+
+func TestStop(t *testing.T) {
+	assert := assert.New(t)
+
+	cpu, mmu, ic := newInterruptTestCPU(t,
+		0x10, 0x00, // STOP
+		0x3C, // INC A
+	)
+
+	var stops int
+	var elapsed uint
+	cpu.ConnectStop(func() { stops++ })
+	cpu.ConnectTicker(func(cycles uint8) { elapsed += uint(cycles) })
+
+	stepWithInterrupts(t, cpu, mmu, ic)
+	assert.True(cpu.IsStopped())
+	assert.Equal(1, stops)
+	assert.Equal(uint(4), elapsed)
+
+	// Nothing's ticked while stopped, even w/ an interrupt pending
+	mmu.Write8(devices.REG_IF, 0x10)
+	mmu.Write8(devices.REG_IE, 0x10)
+	cpu.ime = true
+	assert.False(stepWithInterrupts(t, cpu, mmu, ic))
+	assert.Equal(uint(4), elapsed)
+	assert.Equal(uint16(0x102), cpu.PC.Read())
+
+	cpu.ExitStop()
+	assert.True(stepWithInterrupts(t, cpu, mmu, ic))
+	assert.Equal(uint8(0x01), cpu.Reg.A.Read())
+	assert.Equal(uint16(devices.INT_JOYPAD), cpu.PC.Read())
+}
+
+func TestStopSpeedSwitch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cpu, mmu, ic := newInterruptTestCPU(t,
+		0x10, 0x00, // STOP
+		0x3C, // INC A
+	)
+	require.NoError(cpu.EnableFeature(FeatureDoubleSpeed))
+	mmu.AddHandler(mem.MemRegion{Start: REG_KEY1, End: REG_KEY1}, cpu)
+	mmu.Write8(REG_KEY1, 0x01)
+
+	var stops int
+	var elapsed uint
+	cpu.ConnectStop(func() { stops++ })
+	cpu.ConnectTicker(func(cycles uint8) { elapsed += uint(cycles) })
+
+	stepWithInterrupts(t, cpu, mmu, ic)
+	assert.False(cpu.IsStopped())
+	assert.True(cpu.IsDoubleSpeed())
+	assert.Equal(uint8(0x80), mmu.Read8(REG_KEY1))
+	assert.Equal(1, stops)
+
+	// The rest of the system keeps running while the CPU's paused
+	elapsed = 0
+	for elapsed < SPEED_SWITCH_CYCLES {
+		stepWithInterrupts(t, cpu, mmu, ic)
+		assert.Equal(uint16(0x102), cpu.PC.Read())
+	}
+	assert.Equal(uint(SPEED_SWITCH_CYCLES), elapsed)
+
+	stepWithInterrupts(t, cpu, mmu, ic)
+	assert.Equal(uint8(0x01), cpu.Reg.A.Read())
+}
+
 func TestExecuteReset(t *testing.T) {
 	require := require.New(t)
 
@@ -2076,3 +2310,188 @@ func TestCPUReset(t *testing.T) {
 	assert.True(cpu.ime, "Expected IME flag to be enabled, but it was disabled")
 	assert.False(cpu.halted)
 }
+
+// accessTimer records how many cycles had been ticked when an address was
+// accessed
+type accessTimer struct {
+	elapsed uint
+	reads   []uint
+	writes  []uint
+}
+
+func (a *accessTimer) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
+	a.reads = append(a.reads, a.elapsed)
+
+	return mem.ReadPassthrough()
+}
+
+func (a *accessTimer) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
+	a.writes = append(a.writes, a.elapsed)
+
+	return mem.WritePassthrough()
+}
+
+func TestStepTicksOnMemoryAccess(t *testing.T) {
+	const target = 0xC000
+
+	testCases := []struct {
+		name     string
+		code     []byte
+		cycles   uint8
+		reads    []uint
+		writes   []uint
+		hlTarget bool
+		spTarget bool // The high byte of anything pushed is written to target
+	}{
+		{name: "LD A, (HL)", code: []byte{0x7E}, cycles: 8, reads: []uint{8}, hlTarget: true},
+		{name: "LD (HL), A", code: []byte{0x77}, cycles: 8, writes: []uint{8}, hlTarget: true},
+		{name: "INC (HL)", code: []byte{0x34}, cycles: 12, reads: []uint{8}, writes: []uint{12}, hlTarget: true},
+		{name: "LD A, (a16)", code: []byte{0xFA, 0x00, 0xC0}, cycles: 16, reads: []uint{16}},
+		{name: "LD (a16), A", code: []byte{0xEA, 0x00, 0xC0}, cycles: 16, writes: []uint{16}},
+		{name: "BIT 0, (HL)", code: []byte{0xCB, 0x46}, cycles: 12, reads: []uint{12}, hlTarget: true},
+		{name: "RES 0, (HL)", code: []byte{0xCB, 0x86}, cycles: 16, reads: []uint{12}, writes: []uint{16}, hlTarget: true},
+		{name: "PUSH BC", code: []byte{0xC5}, cycles: 16, writes: []uint{12}, spTarget: true},
+		{name: "CALL a16", code: []byte{0xCD, 0x00, 0x02}, cycles: 24, writes: []uint{20}, spTarget: true},
+		{name: "RST 08h", code: []byte{0xCF}, cycles: 16, writes: []uint{12}, spTarget: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cpu, err := NewCPU()
+			require.NoError(err)
+
+			mmu := mem.NewMMU(make([]byte, testRamSize))
+			for i, b := range tc.code {
+				mmu.Write8(0x100+uint16(i), b)
+			}
+
+			timer := &accessTimer{}
+			mmu.AddHandler(mem.MemRegion{Start: target, End: target}, timer)
+			cpu.ConnectTicker(func(cycles uint8) {
+				timer.elapsed += uint(cycles)
+			})
+
+			cpu.PC.Write(0x100)
+			if tc.hlTarget {
+				cpu.Reg.HL.Write(target)
+			}
+			if tc.spTarget {
+				cpu.SP.Write(target + 1)
+			}
+
+			cycles, err := cpu.Step(mmu)
+			require.NoError(err)
+
+			assert.Equal(tc.cycles, cycles)
+			assert.Equal(uint(tc.cycles), timer.elapsed)
+			assert.Equal(tc.reads, timer.reads)
+			assert.Equal(tc.writes, timer.writes)
+		})
+	}
+}
+
+func TestStepDoesNotAllocate(t *testing.T) {
+	require := require.New(t)
+
+	cpu, err := NewCPU()
+	require.NoError(err)
+
+	mmu := mem.NewMMU(make([]byte, testRamSize))
+	code := []byte{
+		0x7E,       // LD A, (HL)
+		0x3C,       // INC A
+		0x77,       // LD (HL), A
+		0xCB, 0x37, // SWAP A
+		0x18, 0xF9, // JR -7
+	}
+	for i, b := range code {
+		mmu.Write8(0x100+uint16(i), b)
+	}
+
+	cpu.PC.Write(0x100)
+	cpu.Reg.HL.Write(0xC000)
+	cpu.ConnectTicker(func(cycles uint8) {})
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		_, err = cpu.Step(mmu)
+	})
+	require.NoError(err)
+	require.Zero(allocs)
+}
+
+func TestInterruptDispatchTiming(t *testing.T) {
+	testCases := []struct {
+		name   string
+		halted bool
+		cycles uint8
+		writes []uint
+	}{
+		{name: "running", cycles: 20, writes: []uint{12, 16}},
+		{name: "halted", halted: true, cycles: 24, writes: []uint{16, 20}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			cpu, mmu, ic := newInterruptTestCPU(t)
+			cpu.ime = true
+			cpu.halted = tc.halted
+			mmu.Write8(devices.REG_IF, 0x04)
+
+			stack := &accessTimer{}
+			mmu.AddHandler(mem.MemRegion{Start: 0xFFFC, End: 0xFFFD}, stack)
+			cpu.ConnectTicker(func(cycles uint8) {
+				stack.elapsed += uint(cycles)
+			})
+
+			dispatched, cycles := cpu.PollInterrupts(mmu, ic)
+			assert.True(dispatched)
+			assert.Equal(tc.cycles, cycles)
+			assert.Equal(uint(tc.cycles), stack.elapsed)
+			assert.Equal(tc.writes, stack.writes)
+			assert.False(cpu.IsHalted())
+			assert.Equal(uint16(devices.INT_TIMER), cpu.PC.Read())
+		})
+	}
+}
+
+func TestInterruptCancelledByIEPush(t *testing.T) {
+	testCases := []struct {
+		name      string
+		pc        uint16
+		requested byte
+		nextPC    uint16
+		remaining byte
+	}{
+		// Pushing 0x01 leaves only VBlank enabled, which isn't requested
+		{name: "cancelled", pc: 0x0150, requested: 0x04, nextPC: 0x0000, remaining: 0x04},
+		// Pushing 0x02 enables STAT instead, which is dispatched instead
+		{name: "redirected", pc: 0x0250, requested: 0x06, nextPC: uint16(devices.INT_STAT), remaining: 0x04},
+		// Pushing 0x04 leaves timer enabled, so nothing changes
+		{name: "unaffected", pc: 0x0450, requested: 0x04, nextPC: uint16(devices.INT_TIMER), remaining: 0x00},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			cpu, mmu, ic := newInterruptTestCPU(t)
+			cpu.ime = true
+			cpu.PC.Write(tc.pc)
+			cpu.SP.Write(0x0000)
+			mmu.Write8(devices.REG_IF, tc.requested)
+
+			dispatched, cycles := cpu.PollInterrupts(mmu, ic)
+			assert.True(dispatched)
+			assert.Equal(uint8(20), cycles)
+			assert.Equal(tc.nextPC, cpu.PC.Read())
+			assert.Equal(tc.remaining, mmu.Read8(devices.REG_IF)&0x1F)
+			assert.False(cpu.ime)
+			assert.Equal(uint16(0xFFFE), cpu.SP.Read())
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// opcodegen generates static opcode tables for the isa package from
+// opcodes.json, so that they don't need to be parsed at runtime
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+type opcodeFlags struct {
+	Z string `json:"Z"`
+	N string `json:"N"`
+	H string `json:"H"`
+	C string `json:"C"`
+}
+
+type operand struct {
+	Name      string `json:"name"`
+	Immediate bool   `json:"immediate"`
+	Increment bool   `json:"increment,omitempty"`
+	Decrement bool   `json:"decrement,omitempty"`
+	Bytes     int    `json:"bytes,omitempty"`
+}
+
+type opcode struct {
+	Mnemonic  string      `json:"mnemonic"`
+	Bytes     int         `json:"bytes"`
+	Cycles    []int       `json:"cycles"`
+	Operands  []operand   `json:"operands"`
+	Immediate bool        `json:"immediate"`
+	Flags     opcodeFlags `json:"flags"`
+}
+
+type opcodesJSON struct {
+	Unprefixed map[string]opcode `json:"unprefixed"`
+	CbPrefixed map[string]opcode `json:"cbprefixed"`
+}
+
+func main() {
+	in := flag.String("in", "opcodes.json", "opcodes JSON to generate tables from")
+	out := flag.String("out", "opcodes_gen.go", "Go file to write tables to")
+	flag.Parse()
+
+	jsonBytes, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatalf("reading opcodes: %v", err)
+	}
+
+	var opcodes opcodesJSON
+	if err := json.Unmarshal(jsonBytes, &opcodes); err != nil {
+		log.Fatalf("parsing opcodes: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by opcodegen from %s. DO NOT EDIT.\n\n", *in)
+	fmt.Fprint(&buf, "package isa\n\n")
+	fmt.Fprint(&buf, "var opcodes = Opcodes{\n")
+
+	if err := writeTable(&buf, "Unprefixed", opcodes.Unprefixed, false); err != nil {
+		log.Fatalf("generating unprefixed opcodes: %v", err)
+	}
+
+	if err := writeTable(&buf, "CbPrefixed", opcodes.CbPrefixed, true); err != nil {
+		log.Fatalf("generating cb-prefixed opcodes: %v", err)
+	}
+
+	fmt.Fprint(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatalf("writing generated code: %v", err)
+	}
+}
+
+func writeTable(buf *bytes.Buffer, field string, table map[string]opcode, prefixed bool) error {
+	var entries [256]*opcode
+
+	for k, op := range table {
+		addr, err := parseOpcodeAddr(k)
+		if err != nil {
+			return fmt.Errorf("parsing opcode %q: %w", k, err)
+		}
+
+		entries[addr] = &op
+	}
+
+	fmt.Fprintf(buf, "%s: [256]Opcode{\n", field)
+
+	for addr, op := range entries {
+		if op == nil {
+			continue
+		}
+
+		fmt.Fprintf(buf, "0x%02X: {\n", addr)
+		fmt.Fprintf(buf, "Addr: 0x%02X,\n", addr)
+
+		if prefixed {
+			fmt.Fprint(buf, "CbPrefixed: true,\n")
+		}
+
+		fmt.Fprintf(buf, "Mnemonic: %q,\n", op.Mnemonic)
+		fmt.Fprintf(buf, "Bytes: %d,\n", op.Bytes)
+		fmt.Fprintf(buf, "Cycles: %#v,\n", op.Cycles)
+
+		if len(op.Operands) > 0 {
+			fmt.Fprint(buf, "Operands: []Operand{\n")
+
+			for _, operand := range op.Operands {
+				fmt.Fprintf(buf, "{Name: %q, Immediate: %t", operand.Name, operand.Immediate)
+
+				if operand.Increment {
+					fmt.Fprint(buf, ", Increment: true")
+				}
+
+				if operand.Decrement {
+					fmt.Fprint(buf, ", Decrement: true")
+				}
+
+				if operand.Bytes != 0 {
+					fmt.Fprintf(buf, ", Bytes: %d", operand.Bytes)
+				}
+
+				fmt.Fprint(buf, "},\n")
+			}
+
+			fmt.Fprint(buf, "},\n")
+		}
+
+		fmt.Fprintf(buf, "Immediate: %t,\n", op.Immediate)
+		fmt.Fprintf(
+			buf,
+			"Flags: OperandFlags{Z: %q, N: %q, H: %q, C: %q},\n",
+			op.Flags.Z, op.Flags.N, op.Flags.H, op.Flags.C,
+		)
+		fmt.Fprint(buf, "},\n")
+	}
+
+	fmt.Fprint(buf, "},\n")
+
+	return nil
+}
+
+func parseOpcodeAddr(key string) (uint8, error) {
+	hexStr, _ := strings.CutPrefix(key, "0x")
+
+	decoded, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return 0x00, err
+	}
+
+	if len(decoded) != 1 {
+		return 0x00, fmt.Errorf("expected a single byte, got %d", len(decoded))
+	}
+
+	return decoded[0], nil
+}
@@ -1,29 +1,26 @@
 package isa
 
+//go:generate go run ./internal/opcodegen -in opcodes.json -out opcodes_gen.go
+
 import (
-	_ "embed"
-	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"io"
-	"maps"
-	"slices"
 	"strings"
 )
 
 type OperandFlags struct {
-	Z string `json:"Z"`
-	N string `json:"N"`
-	H string `json:"H"`
-	C string `json:"C"`
+	Z string
+	N string
+	H string
+	C string
 }
 
 type Operand struct {
-	Name      string `json:"name"`
-	Immediate bool   `json:"immediate"`
-	Increment bool   `json:"increment,omitempty"`
-	Decrement bool   `json:"decrement,omitempty"`
-	Bytes     int    `json:"bytes,omitempty"`
+	Name      string
+	Immediate bool
+	Increment bool
+	Decrement bool
+	Bytes     int
 }
 
 func (operand *Operand) String() string {
@@ -45,12 +42,12 @@ func (operand *Operand) String() string {
 type Opcode struct {
 	Addr       uint8
 	CbPrefixed bool
-	Mnemonic   string       `json:"mnemonic"`
-	Bytes      int          `json:"bytes"`
-	Cycles     []int        `json:"cycles"`
-	Operands   []Operand    `json:"operands"`
-	Immediate  bool         `json:"immediate"`
-	Flags      OperandFlags `json:"flags"`
+	Mnemonic   string
+	Bytes      int
+	Cycles     []int
+	Operands   []Operand
+	Immediate  bool
+	Flags      OperandFlags
 }
 
 func (opcode *Opcode) String() string {
@@ -69,31 +66,33 @@ func (opcode *Opcode) String() string {
 	)
 }
 
-type OpcodesJSON struct {
-	Unprefixed map[string]*Opcode `json:"unprefixed"`
-	CbPrefixed map[string]*Opcode `json:"cbprefixed"`
+// Opcodes are tables of every opcode, indexed by their value. They're
+// generated from opcodes.json by `go generate`.
+type Opcodes struct {
+	Unprefixed [256]Opcode
+	CbPrefixed [256]Opcode
 }
 
-type Opcodes struct {
-	Unprefixed map[uint8]*Opcode
-	CbPrefixed map[uint8]*Opcode
+// LoadOpcodes returns the generated opcode tables. These are shared, and
+// must not be modified.
+func LoadOpcodes() *Opcodes {
+	return &opcodes
 }
 
-func (opcodes *Opcodes) InstructionFromByte(addr uint16, value byte, prefixed bool) (*Instruction, bool) {
+func (opcodes *Opcodes) InstructionFromByte(addr uint16, value byte, prefixed bool) (Instruction, bool) {
 	var opcode *Opcode
-	var present bool
 
 	if prefixed {
-		opcode, present = opcodes.CbPrefixed[value]
+		opcode = &opcodes.CbPrefixed[value]
 	} else {
-		opcode, present = opcodes.Unprefixed[value]
+		opcode = &opcodes.Unprefixed[value]
 	}
 
-	if !present {
-		return nil, present
+	if opcode.Mnemonic == "" {
+		return Instruction{}, false
 	}
 
-	return &Instruction{
+	return Instruction{
 		Addr:   addr,
 		Opcode: opcode,
 	}, true
@@ -104,74 +103,16 @@ func (opcodes *Opcodes) DebugPrint(w io.Writer) {
 
 	fmt.Fprintf(w, "=== 8-bit opcodes: \n\n")
 
-	unprefixedOpcodes := slices.Collect(maps.Keys(opcodes.Unprefixed))
-	slices.Sort(unprefixedOpcodes)
-
-	for _, k := range unprefixedOpcodes {
-		fmt.Fprintf(w, "%s\n", opcodes.Unprefixed[k].String())
-	}
-
-	cbPrefixedOpcodes := slices.Collect(maps.Keys(opcodes.CbPrefixed))
-	slices.Sort(cbPrefixedOpcodes)
-
-	fmt.Fprintf(w, "\n=== 16-bit opcodes: \n\n")
-	for _, k := range cbPrefixedOpcodes {
-		fmt.Fprintf(w, "0xCB %s\n", opcodes.CbPrefixed[k].String())
-	}
-}
-
-func LoadOpcodes() (*Opcodes, error) {
-	return parseOpcodeJson(opcodeJsonBytes)
-}
-
-//go:embed opcodes.json
-var opcodeJsonBytes []byte
-
-func parseOpcodeJson(jsonBytes []byte) (*Opcodes, error) {
-	var opcodesJSON OpcodesJSON
-	err := json.Unmarshal(jsonBytes, &opcodesJSON)
-	if err != nil {
-		return nil, err
-	}
-
-	opcodes := Opcodes{
-		Unprefixed: map[uint8]*Opcode{},
-		CbPrefixed: map[uint8]*Opcode{},
-	}
-
-	for k := range opcodesJSON.Unprefixed {
-		addr, err := parseOpcodeAddr(k)
-		if err != nil {
-			return nil, err
+	for i := range opcodes.Unprefixed {
+		if opcodes.Unprefixed[i].Mnemonic != "" {
+			fmt.Fprintf(w, "%s\n", opcodes.Unprefixed[i].String())
 		}
-
-		opcode := opcodesJSON.Unprefixed[k]
-		opcode.Addr = addr
-		opcodes.Unprefixed[addr] = opcode
 	}
 
-	for k := range opcodesJSON.CbPrefixed {
-		addr, err := parseOpcodeAddr(k)
-		if err != nil {
-			return nil, err
+	fmt.Fprintf(w, "\n=== 16-bit opcodes: \n\n")
+	for i := range opcodes.CbPrefixed {
+		if opcodes.CbPrefixed[i].Mnemonic != "" {
+			fmt.Fprintf(w, "0xCB %s\n", opcodes.CbPrefixed[i].String())
 		}
-
-		opcode := opcodesJSON.CbPrefixed[k]
-		opcode.Addr = addr
-		opcode.CbPrefixed = true
-		opcodes.CbPrefixed[addr] = opcode
 	}
-
-	return &opcodes, nil
-}
-
-func parseOpcodeAddr(key string) (uint8, error) {
-	hexStr, _ := strings.CutPrefix(key, "0x")
-
-	decoded, err := hex.DecodeString(hexStr)
-	if err != nil {
-		return 0x00, err
-	}
-
-	return decoded[0], nil
 }
@@ -0,0 +1,6194 @@
+// Code generated by opcodegen from opcodes.json. DO NOT EDIT.
+
+package isa
+
+var opcodes = Opcodes{
+	Unprefixed: [256]Opcode{
+		0x00: {
+			Addr:      0x00,
+			Mnemonic:  "NOP",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x01: {
+			Addr:     0x01,
+			Mnemonic: "LD",
+			Bytes:    3,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "BC", Immediate: true},
+				{Name: "n16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x02: {
+			Addr:     0x02,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "BC", Immediate: false},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x03: {
+			Addr:     0x03,
+			Mnemonic: "INC",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "BC", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x04: {
+			Addr:     0x04,
+			Mnemonic: "INC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "-"},
+		},
+		0x05: {
+			Addr:     0x05,
+			Mnemonic: "DEC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "-"},
+		},
+		0x06: {
+			Addr:     0x06,
+			Mnemonic: "LD",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x07: {
+			Addr:      0x07,
+			Mnemonic:  "RLCA",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "0", N: "0", H: "0", C: "C"},
+		},
+		0x08: {
+			Addr:     0x08,
+			Mnemonic: "LD",
+			Bytes:    3,
+			Cycles:   []int{20},
+			Operands: []Operand{
+				{Name: "a16", Immediate: false, Bytes: 2},
+				{Name: "SP", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x09: {
+			Addr:     0x09,
+			Mnemonic: "ADD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: true},
+				{Name: "BC", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "0", H: "H", C: "C"},
+		},
+		0x0A: {
+			Addr:     0x0A,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "BC", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x0B: {
+			Addr:     0x0B,
+			Mnemonic: "DEC",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "BC", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x0C: {
+			Addr:     0x0C,
+			Mnemonic: "INC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "-"},
+		},
+		0x0D: {
+			Addr:     0x0D,
+			Mnemonic: "DEC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "-"},
+		},
+		0x0E: {
+			Addr:     0x0E,
+			Mnemonic: "LD",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x0F: {
+			Addr:      0x0F,
+			Mnemonic:  "RRCA",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "0", N: "0", H: "0", C: "C"},
+		},
+		0x10: {
+			Addr:     0x10,
+			Mnemonic: "STOP",
+			Bytes:    2,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x11: {
+			Addr:     0x11,
+			Mnemonic: "LD",
+			Bytes:    3,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "DE", Immediate: true},
+				{Name: "n16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x12: {
+			Addr:     0x12,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "DE", Immediate: false},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x13: {
+			Addr:     0x13,
+			Mnemonic: "INC",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "DE", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x14: {
+			Addr:     0x14,
+			Mnemonic: "INC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "-"},
+		},
+		0x15: {
+			Addr:     0x15,
+			Mnemonic: "DEC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "-"},
+		},
+		0x16: {
+			Addr:     0x16,
+			Mnemonic: "LD",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x17: {
+			Addr:      0x17,
+			Mnemonic:  "RLA",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "0", N: "0", H: "0", C: "C"},
+		},
+		0x18: {
+			Addr:     0x18,
+			Mnemonic: "JR",
+			Bytes:    2,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "e8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x19: {
+			Addr:     0x19,
+			Mnemonic: "ADD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: true},
+				{Name: "DE", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "0", H: "H", C: "C"},
+		},
+		0x1A: {
+			Addr:     0x1A,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "DE", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x1B: {
+			Addr:     0x1B,
+			Mnemonic: "DEC",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "DE", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x1C: {
+			Addr:     0x1C,
+			Mnemonic: "INC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "-"},
+		},
+		0x1D: {
+			Addr:     0x1D,
+			Mnemonic: "DEC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "-"},
+		},
+		0x1E: {
+			Addr:     0x1E,
+			Mnemonic: "LD",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x1F: {
+			Addr:      0x1F,
+			Mnemonic:  "RRA",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "0", N: "0", H: "0", C: "C"},
+		},
+		0x20: {
+			Addr:     0x20,
+			Mnemonic: "JR",
+			Bytes:    2,
+			Cycles:   []int{12, 8},
+			Operands: []Operand{
+				{Name: "NZ", Immediate: true},
+				{Name: "e8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x21: {
+			Addr:     0x21,
+			Mnemonic: "LD",
+			Bytes:    3,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "HL", Immediate: true},
+				{Name: "n16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x22: {
+			Addr:     0x22,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false, Increment: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x23: {
+			Addr:     0x23,
+			Mnemonic: "INC",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x24: {
+			Addr:     0x24,
+			Mnemonic: "INC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "-"},
+		},
+		0x25: {
+			Addr:     0x25,
+			Mnemonic: "DEC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "-"},
+		},
+		0x26: {
+			Addr:     0x26,
+			Mnemonic: "LD",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x27: {
+			Addr:      0x27,
+			Mnemonic:  "DAA",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "-", H: "0", C: "C"},
+		},
+		0x28: {
+			Addr:     0x28,
+			Mnemonic: "JR",
+			Bytes:    2,
+			Cycles:   []int{12, 8},
+			Operands: []Operand{
+				{Name: "Z", Immediate: true},
+				{Name: "e8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x29: {
+			Addr:     0x29,
+			Mnemonic: "ADD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: true},
+				{Name: "HL", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "0", H: "H", C: "C"},
+		},
+		0x2A: {
+			Addr:     0x2A,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "HL", Immediate: false, Increment: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x2B: {
+			Addr:     0x2B,
+			Mnemonic: "DEC",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x2C: {
+			Addr:     0x2C,
+			Mnemonic: "INC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "-"},
+		},
+		0x2D: {
+			Addr:     0x2D,
+			Mnemonic: "DEC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "-"},
+		},
+		0x2E: {
+			Addr:     0x2E,
+			Mnemonic: "LD",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x2F: {
+			Addr:      0x2F,
+			Mnemonic:  "CPL",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "1", H: "1", C: "-"},
+		},
+		0x30: {
+			Addr:     0x30,
+			Mnemonic: "JR",
+			Bytes:    2,
+			Cycles:   []int{12, 8},
+			Operands: []Operand{
+				{Name: "NC", Immediate: true},
+				{Name: "e8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x31: {
+			Addr:     0x31,
+			Mnemonic: "LD",
+			Bytes:    3,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "SP", Immediate: true},
+				{Name: "n16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x32: {
+			Addr:     0x32,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false, Decrement: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x33: {
+			Addr:     0x33,
+			Mnemonic: "INC",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "SP", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x34: {
+			Addr:     0x34,
+			Mnemonic: "INC",
+			Bytes:    1,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "-"},
+		},
+		0x35: {
+			Addr:     0x35,
+			Mnemonic: "DEC",
+			Bytes:    1,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "-"},
+		},
+		0x36: {
+			Addr:     0x36,
+			Mnemonic: "LD",
+			Bytes:    2,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x37: {
+			Addr:      0x37,
+			Mnemonic:  "SCF",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "0", H: "0", C: "1"},
+		},
+		0x38: {
+			Addr:     0x38,
+			Mnemonic: "JR",
+			Bytes:    2,
+			Cycles:   []int{12, 8},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+				{Name: "e8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x39: {
+			Addr:     0x39,
+			Mnemonic: "ADD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: true},
+				{Name: "SP", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "0", H: "H", C: "C"},
+		},
+		0x3A: {
+			Addr:     0x3A,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "HL", Immediate: false, Decrement: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x3B: {
+			Addr:     0x3B,
+			Mnemonic: "DEC",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "SP", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x3C: {
+			Addr:     0x3C,
+			Mnemonic: "INC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "-"},
+		},
+		0x3D: {
+			Addr:     0x3D,
+			Mnemonic: "DEC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "-"},
+		},
+		0x3E: {
+			Addr:     0x3E,
+			Mnemonic: "LD",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x3F: {
+			Addr:      0x3F,
+			Mnemonic:  "CCF",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "0", H: "0", C: "C"},
+		},
+		0x40: {
+			Addr:     0x40,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x41: {
+			Addr:     0x41,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x42: {
+			Addr:     0x42,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x43: {
+			Addr:     0x43,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x44: {
+			Addr:     0x44,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x45: {
+			Addr:     0x45,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x46: {
+			Addr:     0x46,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x47: {
+			Addr:     0x47,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x48: {
+			Addr:     0x48,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x49: {
+			Addr:     0x49,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x4A: {
+			Addr:     0x4A,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x4B: {
+			Addr:     0x4B,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x4C: {
+			Addr:     0x4C,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x4D: {
+			Addr:     0x4D,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x4E: {
+			Addr:     0x4E,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x4F: {
+			Addr:     0x4F,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x50: {
+			Addr:     0x50,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x51: {
+			Addr:     0x51,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x52: {
+			Addr:     0x52,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x53: {
+			Addr:     0x53,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x54: {
+			Addr:     0x54,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x55: {
+			Addr:     0x55,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x56: {
+			Addr:     0x56,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x57: {
+			Addr:     0x57,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x58: {
+			Addr:     0x58,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x59: {
+			Addr:     0x59,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x5A: {
+			Addr:     0x5A,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x5B: {
+			Addr:     0x5B,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x5C: {
+			Addr:     0x5C,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x5D: {
+			Addr:     0x5D,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x5E: {
+			Addr:     0x5E,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x5F: {
+			Addr:     0x5F,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x60: {
+			Addr:     0x60,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x61: {
+			Addr:     0x61,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x62: {
+			Addr:     0x62,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x63: {
+			Addr:     0x63,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x64: {
+			Addr:     0x64,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x65: {
+			Addr:     0x65,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x66: {
+			Addr:     0x66,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x67: {
+			Addr:     0x67,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x68: {
+			Addr:     0x68,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x69: {
+			Addr:     0x69,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x6A: {
+			Addr:     0x6A,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x6B: {
+			Addr:     0x6B,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x6C: {
+			Addr:     0x6C,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x6D: {
+			Addr:     0x6D,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x6E: {
+			Addr:     0x6E,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x6F: {
+			Addr:     0x6F,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x70: {
+			Addr:     0x70,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x71: {
+			Addr:     0x71,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x72: {
+			Addr:     0x72,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x73: {
+			Addr:     0x73,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x74: {
+			Addr:     0x74,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x75: {
+			Addr:     0x75,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x76: {
+			Addr:      0x76,
+			Mnemonic:  "HALT",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x77: {
+			Addr:     0x77,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x78: {
+			Addr:     0x78,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x79: {
+			Addr:     0x79,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x7A: {
+			Addr:     0x7A,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x7B: {
+			Addr:     0x7B,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x7C: {
+			Addr:     0x7C,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x7D: {
+			Addr:     0x7D,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x7E: {
+			Addr:     0x7E,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x7F: {
+			Addr:     0x7F,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x80: {
+			Addr:     0x80,
+			Mnemonic: "ADD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x81: {
+			Addr:     0x81,
+			Mnemonic: "ADD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x82: {
+			Addr:     0x82,
+			Mnemonic: "ADD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x83: {
+			Addr:     0x83,
+			Mnemonic: "ADD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x84: {
+			Addr:     0x84,
+			Mnemonic: "ADD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x85: {
+			Addr:     0x85,
+			Mnemonic: "ADD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x86: {
+			Addr:     0x86,
+			Mnemonic: "ADD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x87: {
+			Addr:     0x87,
+			Mnemonic: "ADD",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x88: {
+			Addr:     0x88,
+			Mnemonic: "ADC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x89: {
+			Addr:     0x89,
+			Mnemonic: "ADC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x8A: {
+			Addr:     0x8A,
+			Mnemonic: "ADC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x8B: {
+			Addr:     0x8B,
+			Mnemonic: "ADC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x8C: {
+			Addr:     0x8C,
+			Mnemonic: "ADC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x8D: {
+			Addr:     0x8D,
+			Mnemonic: "ADC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x8E: {
+			Addr:     0x8E,
+			Mnemonic: "ADC",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x8F: {
+			Addr:     0x8F,
+			Mnemonic: "ADC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0x90: {
+			Addr:     0x90,
+			Mnemonic: "SUB",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x91: {
+			Addr:     0x91,
+			Mnemonic: "SUB",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x92: {
+			Addr:     0x92,
+			Mnemonic: "SUB",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x93: {
+			Addr:     0x93,
+			Mnemonic: "SUB",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x94: {
+			Addr:     0x94,
+			Mnemonic: "SUB",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x95: {
+			Addr:     0x95,
+			Mnemonic: "SUB",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x96: {
+			Addr:     0x96,
+			Mnemonic: "SUB",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x97: {
+			Addr:     0x97,
+			Mnemonic: "SUB",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "1", N: "1", H: "0", C: "0"},
+		},
+		0x98: {
+			Addr:     0x98,
+			Mnemonic: "SBC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x99: {
+			Addr:     0x99,
+			Mnemonic: "SBC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x9A: {
+			Addr:     0x9A,
+			Mnemonic: "SBC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x9B: {
+			Addr:     0x9B,
+			Mnemonic: "SBC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x9C: {
+			Addr:     0x9C,
+			Mnemonic: "SBC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x9D: {
+			Addr:     0x9D,
+			Mnemonic: "SBC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x9E: {
+			Addr:     0x9E,
+			Mnemonic: "SBC",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0x9F: {
+			Addr:     0x9F,
+			Mnemonic: "SBC",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "-"},
+		},
+		0xA0: {
+			Addr:     0xA0,
+			Mnemonic: "AND",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "0"},
+		},
+		0xA1: {
+			Addr:     0xA1,
+			Mnemonic: "AND",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "0"},
+		},
+		0xA2: {
+			Addr:     0xA2,
+			Mnemonic: "AND",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "0"},
+		},
+		0xA3: {
+			Addr:     0xA3,
+			Mnemonic: "AND",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "0"},
+		},
+		0xA4: {
+			Addr:     0xA4,
+			Mnemonic: "AND",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "0"},
+		},
+		0xA5: {
+			Addr:     0xA5,
+			Mnemonic: "AND",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "0"},
+		},
+		0xA6: {
+			Addr:     0xA6,
+			Mnemonic: "AND",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "0"},
+		},
+		0xA7: {
+			Addr:     0xA7,
+			Mnemonic: "AND",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "0"},
+		},
+		0xA8: {
+			Addr:     0xA8,
+			Mnemonic: "XOR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xA9: {
+			Addr:     0xA9,
+			Mnemonic: "XOR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xAA: {
+			Addr:     0xAA,
+			Mnemonic: "XOR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xAB: {
+			Addr:     0xAB,
+			Mnemonic: "XOR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xAC: {
+			Addr:     0xAC,
+			Mnemonic: "XOR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xAD: {
+			Addr:     0xAD,
+			Mnemonic: "XOR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xAE: {
+			Addr:     0xAE,
+			Mnemonic: "XOR",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xAF: {
+			Addr:     0xAF,
+			Mnemonic: "XOR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "1", N: "0", H: "0", C: "0"},
+		},
+		0xB0: {
+			Addr:     0xB0,
+			Mnemonic: "OR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xB1: {
+			Addr:     0xB1,
+			Mnemonic: "OR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xB2: {
+			Addr:     0xB2,
+			Mnemonic: "OR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xB3: {
+			Addr:     0xB3,
+			Mnemonic: "OR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xB4: {
+			Addr:     0xB4,
+			Mnemonic: "OR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xB5: {
+			Addr:     0xB5,
+			Mnemonic: "OR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xB6: {
+			Addr:     0xB6,
+			Mnemonic: "OR",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xB7: {
+			Addr:     0xB7,
+			Mnemonic: "OR",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xB8: {
+			Addr:     0xB8,
+			Mnemonic: "CP",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0xB9: {
+			Addr:     0xB9,
+			Mnemonic: "CP",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0xBA: {
+			Addr:     0xBA,
+			Mnemonic: "CP",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0xBB: {
+			Addr:     0xBB,
+			Mnemonic: "CP",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0xBC: {
+			Addr:     0xBC,
+			Mnemonic: "CP",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0xBD: {
+			Addr:     0xBD,
+			Mnemonic: "CP",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0xBE: {
+			Addr:     0xBE,
+			Mnemonic: "CP",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0xBF: {
+			Addr:     0xBF,
+			Mnemonic: "CP",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "1", N: "1", H: "0", C: "0"},
+		},
+		0xC0: {
+			Addr:     0xC0,
+			Mnemonic: "RET",
+			Bytes:    1,
+			Cycles:   []int{20, 8},
+			Operands: []Operand{
+				{Name: "NZ", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC1: {
+			Addr:     0xC1,
+			Mnemonic: "POP",
+			Bytes:    1,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "BC", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC2: {
+			Addr:     0xC2,
+			Mnemonic: "JP",
+			Bytes:    3,
+			Cycles:   []int{16, 12},
+			Operands: []Operand{
+				{Name: "NZ", Immediate: true},
+				{Name: "a16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC3: {
+			Addr:     0xC3,
+			Mnemonic: "JP",
+			Bytes:    3,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "a16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC4: {
+			Addr:     0xC4,
+			Mnemonic: "CALL",
+			Bytes:    3,
+			Cycles:   []int{24, 12},
+			Operands: []Operand{
+				{Name: "NZ", Immediate: true},
+				{Name: "a16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC5: {
+			Addr:     0xC5,
+			Mnemonic: "PUSH",
+			Bytes:    1,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "BC", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC6: {
+			Addr:     0xC6,
+			Mnemonic: "ADD",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0xC7: {
+			Addr:     0xC7,
+			Mnemonic: "RST",
+			Bytes:    1,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "$00", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC8: {
+			Addr:     0xC8,
+			Mnemonic: "RET",
+			Bytes:    1,
+			Cycles:   []int{20, 8},
+			Operands: []Operand{
+				{Name: "Z", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC9: {
+			Addr:      0xC9,
+			Mnemonic:  "RET",
+			Bytes:     1,
+			Cycles:    []int{16},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xCA: {
+			Addr:     0xCA,
+			Mnemonic: "JP",
+			Bytes:    3,
+			Cycles:   []int{16, 12},
+			Operands: []Operand{
+				{Name: "Z", Immediate: true},
+				{Name: "a16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xCB: {
+			Addr:      0xCB,
+			Mnemonic:  "PREFIX",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xCC: {
+			Addr:     0xCC,
+			Mnemonic: "CALL",
+			Bytes:    3,
+			Cycles:   []int{24, 12},
+			Operands: []Operand{
+				{Name: "Z", Immediate: true},
+				{Name: "a16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xCD: {
+			Addr:     0xCD,
+			Mnemonic: "CALL",
+			Bytes:    3,
+			Cycles:   []int{24},
+			Operands: []Operand{
+				{Name: "a16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xCE: {
+			Addr:     0xCE,
+			Mnemonic: "ADC",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "H", C: "C"},
+		},
+		0xCF: {
+			Addr:     0xCF,
+			Mnemonic: "RST",
+			Bytes:    1,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "$08", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD0: {
+			Addr:     0xD0,
+			Mnemonic: "RET",
+			Bytes:    1,
+			Cycles:   []int{20, 8},
+			Operands: []Operand{
+				{Name: "NC", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD1: {
+			Addr:     0xD1,
+			Mnemonic: "POP",
+			Bytes:    1,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "DE", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD2: {
+			Addr:     0xD2,
+			Mnemonic: "JP",
+			Bytes:    3,
+			Cycles:   []int{16, 12},
+			Operands: []Operand{
+				{Name: "NC", Immediate: true},
+				{Name: "a16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD3: {
+			Addr:      0xD3,
+			Mnemonic:  "ILLEGAL_D3",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD4: {
+			Addr:     0xD4,
+			Mnemonic: "CALL",
+			Bytes:    3,
+			Cycles:   []int{24, 12},
+			Operands: []Operand{
+				{Name: "NC", Immediate: true},
+				{Name: "a16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD5: {
+			Addr:     0xD5,
+			Mnemonic: "PUSH",
+			Bytes:    1,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "DE", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD6: {
+			Addr:     0xD6,
+			Mnemonic: "SUB",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0xD7: {
+			Addr:     0xD7,
+			Mnemonic: "RST",
+			Bytes:    1,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "$10", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD8: {
+			Addr:     0xD8,
+			Mnemonic: "RET",
+			Bytes:    1,
+			Cycles:   []int{20, 8},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD9: {
+			Addr:      0xD9,
+			Mnemonic:  "RETI",
+			Bytes:     1,
+			Cycles:    []int{16},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xDA: {
+			Addr:     0xDA,
+			Mnemonic: "JP",
+			Bytes:    3,
+			Cycles:   []int{16, 12},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+				{Name: "a16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xDB: {
+			Addr:      0xDB,
+			Mnemonic:  "ILLEGAL_DB",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xDC: {
+			Addr:     0xDC,
+			Mnemonic: "CALL",
+			Bytes:    3,
+			Cycles:   []int{24, 12},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+				{Name: "a16", Immediate: true, Bytes: 2},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xDD: {
+			Addr:      0xDD,
+			Mnemonic:  "ILLEGAL_DD",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xDE: {
+			Addr:     0xDE,
+			Mnemonic: "SBC",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0xDF: {
+			Addr:     0xDF,
+			Mnemonic: "RST",
+			Bytes:    1,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "$18", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE0: {
+			Addr:     0xE0,
+			Mnemonic: "LDH",
+			Bytes:    2,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "a8", Immediate: false, Bytes: 1},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE1: {
+			Addr:     0xE1,
+			Mnemonic: "POP",
+			Bytes:    1,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "HL", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE2: {
+			Addr:     0xE2,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "C", Immediate: false},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE3: {
+			Addr:      0xE3,
+			Mnemonic:  "ILLEGAL_E3",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE4: {
+			Addr:      0xE4,
+			Mnemonic:  "ILLEGAL_E4",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE5: {
+			Addr:     0xE5,
+			Mnemonic: "PUSH",
+			Bytes:    1,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "HL", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE6: {
+			Addr:     0xE6,
+			Mnemonic: "AND",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "0"},
+		},
+		0xE7: {
+			Addr:     0xE7,
+			Mnemonic: "RST",
+			Bytes:    1,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "$20", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE8: {
+			Addr:     0xE8,
+			Mnemonic: "ADD",
+			Bytes:    2,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "SP", Immediate: true},
+				{Name: "e8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "0", N: "0", H: "H", C: "C"},
+		},
+		0xE9: {
+			Addr:     0xE9,
+			Mnemonic: "JP",
+			Bytes:    1,
+			Cycles:   []int{4},
+			Operands: []Operand{
+				{Name: "HL", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xEA: {
+			Addr:     0xEA,
+			Mnemonic: "LD",
+			Bytes:    3,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "a16", Immediate: false, Bytes: 2},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xEB: {
+			Addr:      0xEB,
+			Mnemonic:  "ILLEGAL_EB",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xEC: {
+			Addr:      0xEC,
+			Mnemonic:  "ILLEGAL_EC",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xED: {
+			Addr:      0xED,
+			Mnemonic:  "ILLEGAL_ED",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xEE: {
+			Addr:     0xEE,
+			Mnemonic: "XOR",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xEF: {
+			Addr:     0xEF,
+			Mnemonic: "RST",
+			Bytes:    1,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "$28", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF0: {
+			Addr:     0xF0,
+			Mnemonic: "LDH",
+			Bytes:    2,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "a8", Immediate: false, Bytes: 1},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF1: {
+			Addr:     0xF1,
+			Mnemonic: "POP",
+			Bytes:    1,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "AF", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "N", H: "H", C: "C"},
+		},
+		0xF2: {
+			Addr:     0xF2,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "C", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF3: {
+			Addr:      0xF3,
+			Mnemonic:  "DI",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF4: {
+			Addr:      0xF4,
+			Mnemonic:  "ILLEGAL_F4",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF5: {
+			Addr:     0xF5,
+			Mnemonic: "PUSH",
+			Bytes:    1,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "AF", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF6: {
+			Addr:     0xF6,
+			Mnemonic: "OR",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0xF7: {
+			Addr:     0xF7,
+			Mnemonic: "RST",
+			Bytes:    1,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "$30", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF8: {
+			Addr:     0xF8,
+			Mnemonic: "LD",
+			Bytes:    2,
+			Cycles:   []int{12},
+			Operands: []Operand{
+				{Name: "HL", Immediate: true},
+				{Name: "SP", Immediate: true, Increment: true},
+				{Name: "e8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "0", N: "0", H: "H", C: "C"},
+		},
+		0xF9: {
+			Addr:     0xF9,
+			Mnemonic: "LD",
+			Bytes:    1,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "SP", Immediate: true},
+				{Name: "HL", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xFA: {
+			Addr:     0xFA,
+			Mnemonic: "LD",
+			Bytes:    3,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "a16", Immediate: false, Bytes: 2},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xFB: {
+			Addr:      0xFB,
+			Mnemonic:  "EI",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xFC: {
+			Addr:      0xFC,
+			Mnemonic:  "ILLEGAL_FC",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xFD: {
+			Addr:      0xFD,
+			Mnemonic:  "ILLEGAL_FD",
+			Bytes:     1,
+			Cycles:    []int{4},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xFE: {
+			Addr:     0xFE,
+			Mnemonic: "CP",
+			Bytes:    2,
+			Cycles:   []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+				{Name: "n8", Immediate: true, Bytes: 1},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "1", H: "H", C: "C"},
+		},
+		0xFF: {
+			Addr:     0xFF,
+			Mnemonic: "RST",
+			Bytes:    1,
+			Cycles:   []int{16},
+			Operands: []Operand{
+				{Name: "$38", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+	},
+	CbPrefixed: [256]Opcode{
+		0x00: {
+			Addr:       0x00,
+			CbPrefixed: true,
+			Mnemonic:   "RLC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x01: {
+			Addr:       0x01,
+			CbPrefixed: true,
+			Mnemonic:   "RLC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x02: {
+			Addr:       0x02,
+			CbPrefixed: true,
+			Mnemonic:   "RLC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x03: {
+			Addr:       0x03,
+			CbPrefixed: true,
+			Mnemonic:   "RLC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x04: {
+			Addr:       0x04,
+			CbPrefixed: true,
+			Mnemonic:   "RLC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x05: {
+			Addr:       0x05,
+			CbPrefixed: true,
+			Mnemonic:   "RLC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x06: {
+			Addr:       0x06,
+			CbPrefixed: true,
+			Mnemonic:   "RLC",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x07: {
+			Addr:       0x07,
+			CbPrefixed: true,
+			Mnemonic:   "RLC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x08: {
+			Addr:       0x08,
+			CbPrefixed: true,
+			Mnemonic:   "RRC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x09: {
+			Addr:       0x09,
+			CbPrefixed: true,
+			Mnemonic:   "RRC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x0A: {
+			Addr:       0x0A,
+			CbPrefixed: true,
+			Mnemonic:   "RRC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x0B: {
+			Addr:       0x0B,
+			CbPrefixed: true,
+			Mnemonic:   "RRC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x0C: {
+			Addr:       0x0C,
+			CbPrefixed: true,
+			Mnemonic:   "RRC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x0D: {
+			Addr:       0x0D,
+			CbPrefixed: true,
+			Mnemonic:   "RRC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x0E: {
+			Addr:       0x0E,
+			CbPrefixed: true,
+			Mnemonic:   "RRC",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x0F: {
+			Addr:       0x0F,
+			CbPrefixed: true,
+			Mnemonic:   "RRC",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x10: {
+			Addr:       0x10,
+			CbPrefixed: true,
+			Mnemonic:   "RL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x11: {
+			Addr:       0x11,
+			CbPrefixed: true,
+			Mnemonic:   "RL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x12: {
+			Addr:       0x12,
+			CbPrefixed: true,
+			Mnemonic:   "RL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x13: {
+			Addr:       0x13,
+			CbPrefixed: true,
+			Mnemonic:   "RL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x14: {
+			Addr:       0x14,
+			CbPrefixed: true,
+			Mnemonic:   "RL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x15: {
+			Addr:       0x15,
+			CbPrefixed: true,
+			Mnemonic:   "RL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x16: {
+			Addr:       0x16,
+			CbPrefixed: true,
+			Mnemonic:   "RL",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x17: {
+			Addr:       0x17,
+			CbPrefixed: true,
+			Mnemonic:   "RL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x18: {
+			Addr:       0x18,
+			CbPrefixed: true,
+			Mnemonic:   "RR",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x19: {
+			Addr:       0x19,
+			CbPrefixed: true,
+			Mnemonic:   "RR",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x1A: {
+			Addr:       0x1A,
+			CbPrefixed: true,
+			Mnemonic:   "RR",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x1B: {
+			Addr:       0x1B,
+			CbPrefixed: true,
+			Mnemonic:   "RR",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x1C: {
+			Addr:       0x1C,
+			CbPrefixed: true,
+			Mnemonic:   "RR",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x1D: {
+			Addr:       0x1D,
+			CbPrefixed: true,
+			Mnemonic:   "RR",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x1E: {
+			Addr:       0x1E,
+			CbPrefixed: true,
+			Mnemonic:   "RR",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x1F: {
+			Addr:       0x1F,
+			CbPrefixed: true,
+			Mnemonic:   "RR",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x20: {
+			Addr:       0x20,
+			CbPrefixed: true,
+			Mnemonic:   "SLA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x21: {
+			Addr:       0x21,
+			CbPrefixed: true,
+			Mnemonic:   "SLA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x22: {
+			Addr:       0x22,
+			CbPrefixed: true,
+			Mnemonic:   "SLA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x23: {
+			Addr:       0x23,
+			CbPrefixed: true,
+			Mnemonic:   "SLA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x24: {
+			Addr:       0x24,
+			CbPrefixed: true,
+			Mnemonic:   "SLA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x25: {
+			Addr:       0x25,
+			CbPrefixed: true,
+			Mnemonic:   "SLA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x26: {
+			Addr:       0x26,
+			CbPrefixed: true,
+			Mnemonic:   "SLA",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x27: {
+			Addr:       0x27,
+			CbPrefixed: true,
+			Mnemonic:   "SLA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x28: {
+			Addr:       0x28,
+			CbPrefixed: true,
+			Mnemonic:   "SRA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x29: {
+			Addr:       0x29,
+			CbPrefixed: true,
+			Mnemonic:   "SRA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x2A: {
+			Addr:       0x2A,
+			CbPrefixed: true,
+			Mnemonic:   "SRA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x2B: {
+			Addr:       0x2B,
+			CbPrefixed: true,
+			Mnemonic:   "SRA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x2C: {
+			Addr:       0x2C,
+			CbPrefixed: true,
+			Mnemonic:   "SRA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x2D: {
+			Addr:       0x2D,
+			CbPrefixed: true,
+			Mnemonic:   "SRA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x2E: {
+			Addr:       0x2E,
+			CbPrefixed: true,
+			Mnemonic:   "SRA",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x2F: {
+			Addr:       0x2F,
+			CbPrefixed: true,
+			Mnemonic:   "SRA",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x30: {
+			Addr:       0x30,
+			CbPrefixed: true,
+			Mnemonic:   "SWAP",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0x31: {
+			Addr:       0x31,
+			CbPrefixed: true,
+			Mnemonic:   "SWAP",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0x32: {
+			Addr:       0x32,
+			CbPrefixed: true,
+			Mnemonic:   "SWAP",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0x33: {
+			Addr:       0x33,
+			CbPrefixed: true,
+			Mnemonic:   "SWAP",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0x34: {
+			Addr:       0x34,
+			CbPrefixed: true,
+			Mnemonic:   "SWAP",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0x35: {
+			Addr:       0x35,
+			CbPrefixed: true,
+			Mnemonic:   "SWAP",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0x36: {
+			Addr:       0x36,
+			CbPrefixed: true,
+			Mnemonic:   "SWAP",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0x37: {
+			Addr:       0x37,
+			CbPrefixed: true,
+			Mnemonic:   "SWAP",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "0"},
+		},
+		0x38: {
+			Addr:       0x38,
+			CbPrefixed: true,
+			Mnemonic:   "SRL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x39: {
+			Addr:       0x39,
+			CbPrefixed: true,
+			Mnemonic:   "SRL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x3A: {
+			Addr:       0x3A,
+			CbPrefixed: true,
+			Mnemonic:   "SRL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x3B: {
+			Addr:       0x3B,
+			CbPrefixed: true,
+			Mnemonic:   "SRL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x3C: {
+			Addr:       0x3C,
+			CbPrefixed: true,
+			Mnemonic:   "SRL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x3D: {
+			Addr:       0x3D,
+			CbPrefixed: true,
+			Mnemonic:   "SRL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x3E: {
+			Addr:       0x3E,
+			CbPrefixed: true,
+			Mnemonic:   "SRL",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x3F: {
+			Addr:       0x3F,
+			CbPrefixed: true,
+			Mnemonic:   "SRL",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "0", C: "C"},
+		},
+		0x40: {
+			Addr:       0x40,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x41: {
+			Addr:       0x41,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x42: {
+			Addr:       0x42,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x43: {
+			Addr:       0x43,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x44: {
+			Addr:       0x44,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x45: {
+			Addr:       0x45,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x46: {
+			Addr:       0x46,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{12},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x47: {
+			Addr:       0x47,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x48: {
+			Addr:       0x48,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x49: {
+			Addr:       0x49,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x4A: {
+			Addr:       0x4A,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x4B: {
+			Addr:       0x4B,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x4C: {
+			Addr:       0x4C,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x4D: {
+			Addr:       0x4D,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x4E: {
+			Addr:       0x4E,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{12},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x4F: {
+			Addr:       0x4F,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x50: {
+			Addr:       0x50,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x51: {
+			Addr:       0x51,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x52: {
+			Addr:       0x52,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x53: {
+			Addr:       0x53,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x54: {
+			Addr:       0x54,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x55: {
+			Addr:       0x55,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x56: {
+			Addr:       0x56,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{12},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x57: {
+			Addr:       0x57,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x58: {
+			Addr:       0x58,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x59: {
+			Addr:       0x59,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x5A: {
+			Addr:       0x5A,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x5B: {
+			Addr:       0x5B,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x5C: {
+			Addr:       0x5C,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x5D: {
+			Addr:       0x5D,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x5E: {
+			Addr:       0x5E,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{12},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x5F: {
+			Addr:       0x5F,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x60: {
+			Addr:       0x60,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x61: {
+			Addr:       0x61,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x62: {
+			Addr:       0x62,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x63: {
+			Addr:       0x63,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x64: {
+			Addr:       0x64,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x65: {
+			Addr:       0x65,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x66: {
+			Addr:       0x66,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{12},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x67: {
+			Addr:       0x67,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x68: {
+			Addr:       0x68,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x69: {
+			Addr:       0x69,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x6A: {
+			Addr:       0x6A,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x6B: {
+			Addr:       0x6B,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x6C: {
+			Addr:       0x6C,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x6D: {
+			Addr:       0x6D,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x6E: {
+			Addr:       0x6E,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{12},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x6F: {
+			Addr:       0x6F,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x70: {
+			Addr:       0x70,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x71: {
+			Addr:       0x71,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x72: {
+			Addr:       0x72,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x73: {
+			Addr:       0x73,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x74: {
+			Addr:       0x74,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x75: {
+			Addr:       0x75,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x76: {
+			Addr:       0x76,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{12},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x77: {
+			Addr:       0x77,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x78: {
+			Addr:       0x78,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x79: {
+			Addr:       0x79,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x7A: {
+			Addr:       0x7A,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x7B: {
+			Addr:       0x7B,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x7C: {
+			Addr:       0x7C,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x7D: {
+			Addr:       0x7D,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x7E: {
+			Addr:       0x7E,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{12},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x7F: {
+			Addr:       0x7F,
+			CbPrefixed: true,
+			Mnemonic:   "BIT",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "Z", N: "0", H: "1", C: "-"},
+		},
+		0x80: {
+			Addr:       0x80,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x81: {
+			Addr:       0x81,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x82: {
+			Addr:       0x82,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x83: {
+			Addr:       0x83,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x84: {
+			Addr:       0x84,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x85: {
+			Addr:       0x85,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x86: {
+			Addr:       0x86,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x87: {
+			Addr:       0x87,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x88: {
+			Addr:       0x88,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x89: {
+			Addr:       0x89,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x8A: {
+			Addr:       0x8A,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x8B: {
+			Addr:       0x8B,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x8C: {
+			Addr:       0x8C,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x8D: {
+			Addr:       0x8D,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x8E: {
+			Addr:       0x8E,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x8F: {
+			Addr:       0x8F,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x90: {
+			Addr:       0x90,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x91: {
+			Addr:       0x91,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x92: {
+			Addr:       0x92,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x93: {
+			Addr:       0x93,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x94: {
+			Addr:       0x94,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x95: {
+			Addr:       0x95,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x96: {
+			Addr:       0x96,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x97: {
+			Addr:       0x97,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x98: {
+			Addr:       0x98,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x99: {
+			Addr:       0x99,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x9A: {
+			Addr:       0x9A,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x9B: {
+			Addr:       0x9B,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x9C: {
+			Addr:       0x9C,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x9D: {
+			Addr:       0x9D,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x9E: {
+			Addr:       0x9E,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0x9F: {
+			Addr:       0x9F,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xA0: {
+			Addr:       0xA0,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xA1: {
+			Addr:       0xA1,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xA2: {
+			Addr:       0xA2,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xA3: {
+			Addr:       0xA3,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xA4: {
+			Addr:       0xA4,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xA5: {
+			Addr:       0xA5,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xA6: {
+			Addr:       0xA6,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xA7: {
+			Addr:       0xA7,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xA8: {
+			Addr:       0xA8,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xA9: {
+			Addr:       0xA9,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xAA: {
+			Addr:       0xAA,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xAB: {
+			Addr:       0xAB,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xAC: {
+			Addr:       0xAC,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xAD: {
+			Addr:       0xAD,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xAE: {
+			Addr:       0xAE,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xAF: {
+			Addr:       0xAF,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xB0: {
+			Addr:       0xB0,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xB1: {
+			Addr:       0xB1,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xB2: {
+			Addr:       0xB2,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xB3: {
+			Addr:       0xB3,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xB4: {
+			Addr:       0xB4,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xB5: {
+			Addr:       0xB5,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xB6: {
+			Addr:       0xB6,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xB7: {
+			Addr:       0xB7,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xB8: {
+			Addr:       0xB8,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xB9: {
+			Addr:       0xB9,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xBA: {
+			Addr:       0xBA,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xBB: {
+			Addr:       0xBB,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xBC: {
+			Addr:       0xBC,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xBD: {
+			Addr:       0xBD,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xBE: {
+			Addr:       0xBE,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xBF: {
+			Addr:       0xBF,
+			CbPrefixed: true,
+			Mnemonic:   "RES",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC0: {
+			Addr:       0xC0,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC1: {
+			Addr:       0xC1,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC2: {
+			Addr:       0xC2,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC3: {
+			Addr:       0xC3,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC4: {
+			Addr:       0xC4,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC5: {
+			Addr:       0xC5,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC6: {
+			Addr:       0xC6,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC7: {
+			Addr:       0xC7,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "0", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC8: {
+			Addr:       0xC8,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xC9: {
+			Addr:       0xC9,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xCA: {
+			Addr:       0xCA,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xCB: {
+			Addr:       0xCB,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xCC: {
+			Addr:       0xCC,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xCD: {
+			Addr:       0xCD,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xCE: {
+			Addr:       0xCE,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xCF: {
+			Addr:       0xCF,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "1", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD0: {
+			Addr:       0xD0,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD1: {
+			Addr:       0xD1,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD2: {
+			Addr:       0xD2,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD3: {
+			Addr:       0xD3,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD4: {
+			Addr:       0xD4,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD5: {
+			Addr:       0xD5,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD6: {
+			Addr:       0xD6,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD7: {
+			Addr:       0xD7,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "2", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD8: {
+			Addr:       0xD8,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xD9: {
+			Addr:       0xD9,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xDA: {
+			Addr:       0xDA,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xDB: {
+			Addr:       0xDB,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xDC: {
+			Addr:       0xDC,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xDD: {
+			Addr:       0xDD,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xDE: {
+			Addr:       0xDE,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xDF: {
+			Addr:       0xDF,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "3", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE0: {
+			Addr:       0xE0,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE1: {
+			Addr:       0xE1,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE2: {
+			Addr:       0xE2,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE3: {
+			Addr:       0xE3,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE4: {
+			Addr:       0xE4,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE5: {
+			Addr:       0xE5,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE6: {
+			Addr:       0xE6,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE7: {
+			Addr:       0xE7,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "4", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE8: {
+			Addr:       0xE8,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xE9: {
+			Addr:       0xE9,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xEA: {
+			Addr:       0xEA,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xEB: {
+			Addr:       0xEB,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xEC: {
+			Addr:       0xEC,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xED: {
+			Addr:       0xED,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xEE: {
+			Addr:       0xEE,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xEF: {
+			Addr:       0xEF,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "5", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF0: {
+			Addr:       0xF0,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF1: {
+			Addr:       0xF1,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF2: {
+			Addr:       0xF2,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF3: {
+			Addr:       0xF3,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF4: {
+			Addr:       0xF4,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF5: {
+			Addr:       0xF5,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF6: {
+			Addr:       0xF6,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF7: {
+			Addr:       0xF7,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "6", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF8: {
+			Addr:       0xF8,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "B", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xF9: {
+			Addr:       0xF9,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "C", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xFA: {
+			Addr:       0xFA,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "D", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xFB: {
+			Addr:       0xFB,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "E", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xFC: {
+			Addr:       0xFC,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "H", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xFD: {
+			Addr:       0xFD,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "L", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xFE: {
+			Addr:       0xFE,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{16},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "HL", Immediate: false},
+			},
+			Immediate: false,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+		0xFF: {
+			Addr:       0xFF,
+			CbPrefixed: true,
+			Mnemonic:   "SET",
+			Bytes:      2,
+			Cycles:     []int{8},
+			Operands: []Operand{
+				{Name: "7", Immediate: true},
+				{Name: "A", Immediate: true},
+			},
+			Immediate: true,
+			Flags:     OperandFlags{Z: "-", N: "-", H: "-", C: "-"},
+		},
+	},
+}
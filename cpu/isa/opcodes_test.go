@@ -0,0 +1,91 @@
+package isa
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpcodesMatchJSON catches generated tables which have gone stale. If this
+// fails, run `go generate ./cpu/isa`.
+func TestOpcodesMatchJSON(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	jsonBytes, err := os.ReadFile("opcodes.json")
+	require.NoError(err)
+
+	var opcodesJSON struct {
+		Unprefixed map[string]struct {
+			Mnemonic string
+			Bytes    int
+			Cycles   []int
+			Operands []struct {
+				Name      string
+				Immediate bool
+				Increment bool
+				Decrement bool
+				Bytes     int
+			}
+			Immediate bool
+			Flags     OperandFlags
+		}
+		CbPrefixed map[string]struct {
+			Mnemonic string
+			Cycles   []int
+		} `json:"cbprefixed"`
+	}
+	require.NoError(json.Unmarshal(jsonBytes, &opcodesJSON))
+
+	opcodes := LoadOpcodes()
+	require.Len(opcodesJSON.Unprefixed, len(opcodes.Unprefixed))
+	require.Len(opcodesJSON.CbPrefixed, len(opcodes.CbPrefixed))
+
+	for k, expected := range opcodesJSON.Unprefixed {
+		addr, err := strconv.ParseUint(strings.TrimPrefix(k, "0x"), 16, 8)
+		require.NoError(err)
+
+		opcode := opcodes.Unprefixed[addr]
+		assert.Equal(uint8(addr), opcode.Addr)
+		assert.False(opcode.CbPrefixed)
+		assert.Equal(expected.Mnemonic, opcode.Mnemonic)
+		assert.Equal(expected.Bytes, opcode.Bytes)
+		assert.Equal(expected.Cycles, opcode.Cycles)
+		assert.Equal(expected.Immediate, opcode.Immediate)
+		assert.Equal(expected.Flags, opcode.Flags)
+		require.Len(opcode.Operands, len(expected.Operands))
+
+		for i, operand := range expected.Operands {
+			assert.Equal(Operand(operand), opcode.Operands[i])
+		}
+	}
+
+	for k, expected := range opcodesJSON.CbPrefixed {
+		addr, err := strconv.ParseUint(strings.TrimPrefix(k, "0x"), 16, 8)
+		require.NoError(err)
+
+		opcode := opcodes.CbPrefixed[addr]
+		assert.Equal(uint8(addr), opcode.Addr)
+		assert.True(opcode.CbPrefixed)
+		assert.Equal(expected.Mnemonic, opcode.Mnemonic)
+		assert.Equal(expected.Cycles, opcode.Cycles)
+	}
+}
+
+func TestInstructionFromByte(t *testing.T) {
+	assert := assert.New(t)
+
+	inst, ok := LoadOpcodes().InstructionFromByte(0x0150, 0x37, true)
+	assert.True(ok)
+	assert.Equal("0x0150    0x37 SWAP A", inst.String())
+
+	allocs := testing.AllocsPerRun(100, func() {
+		inst, _ = LoadOpcodes().InstructionFromByte(0x0150, 0x01, false)
+	})
+	assert.Zero(allocs)
+}
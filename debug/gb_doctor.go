@@ -52,9 +52,9 @@ func (gbd *GBDoctorDebugger) printState(cpu *cpu.CPU, mmu *mem.MMU) {
 		cpu.Reg.L.Read(),
 		cpu.SP.Read(),
 		cpu.PC.Read(),
-		mmu.Read8(cpu.PC.Read()),
-		mmu.Read8(cpu.PC.Read()+1),
-		mmu.Read8(cpu.PC.Read()+2),
-		mmu.Read8(cpu.PC.Read()+3),
+		mmu.Peek8(cpu.PC.Read()),
+		mmu.Peek8(cpu.PC.Read()+1),
+		mmu.Peek8(cpu.PC.Read()+2),
+		mmu.Peek8(cpu.PC.Read()+3),
 	)
 }
@@ -159,9 +159,9 @@ func NewInteractiveDebugger() (*InteractiveDebugger, error) {
 				}
 
 				if len(c.Args) > 1 && c.Args[1] == "16" {
-					c.Printf("0x%04X: %04X\n", addr, mmu.Read16(addr))
+					c.Printf("0x%04X: %04X\n", addr, mmu.Peek16(addr))
 				} else {
-					c.Printf("0x%04X: %02X\n", addr, mmu.Read8(addr))
+					c.Printf("0x%04X: %02X\n", addr, mmu.Peek8(addr))
 				}
 			}
 		},
@@ -225,13 +225,13 @@ func NewInteractiveDebugger() (*InteractiveDebugger, error) {
 				for _, operand := range inst.Opcode.Operands {
 					switch {
 					case operand.Bytes == 1 && operand.Immediate:
-						operands = append(operands, fmt.Sprintf("$%02X", mmu.Read8(addr+1)))
+						operands = append(operands, fmt.Sprintf("$%02X", mmu.Peek8(addr+1)))
 					case operand.Bytes == 1 && !operand.Immediate:
-						operands = append(operands, fmt.Sprintf("($%02X)", mmu.Read8(addr+1)))
+						operands = append(operands, fmt.Sprintf("($%02X)", mmu.Peek8(addr+1)))
 					case operand.Bytes == 2 && operand.Immediate:
-						operands = append(operands, fmt.Sprintf("$%04X", mmu.Read16(addr+1)))
+						operands = append(operands, fmt.Sprintf("$%04X", mmu.Peek16(addr+1)))
 					case operand.Bytes == 2 && !operand.Immediate:
-						operands = append(operands, fmt.Sprintf("($%04X)", mmu.Read16(addr+1)))
+						operands = append(operands, fmt.Sprintf("($%04X)", mmu.Peek16(addr+1)))
 					default:
 						operands = append(operands, operand.String())
 					}
@@ -496,10 +496,10 @@ func (i *InteractiveDebugger) printState(cpu *cpu.CPU, mmu *mem.MMU) {
 		"SP: %04X PC: %04X PCMEM: %02X,%02X,%02X,%02X\n",
 		cpu.SP.Read(),
 		cpu.PC.Read(),
-		mmu.Read8(cpu.PC.Read()),
-		mmu.Read8(cpu.PC.Read()+1),
-		mmu.Read8(cpu.PC.Read()+2),
-		mmu.Read8(cpu.PC.Read()+3),
+		mmu.Peek8(cpu.PC.Read()),
+		mmu.Peek8(cpu.PC.Read()+1),
+		mmu.Peek8(cpu.PC.Read()+2),
+		mmu.Peek8(cpu.PC.Read()+3),
 	)
 }
 
@@ -0,0 +1,104 @@
+package devices
+
+import (
+	"encoding/binary"
+	"sync"
+)
+
+const (
+	DEFAULT_AUDIO_SAMPLE_RATE = 48000
+
+	// Bytes per stereo frame of signed 16-bit PCM
+	AUDIO_FRAME_SIZE = 4
+)
+
+// AudioOutput receives stereo 16-bit PCM samples from the console at the
+// sample rate it reports. Implementations must never block.
+type AudioOutput interface {
+	SampleRate() int
+	WriteSample(left, right int16)
+}
+
+type NullAudioOutput struct {
+	sampleRate int
+}
+
+func NewNullAudioOutput(sampleRate int) *NullAudioOutput {
+	return &NullAudioOutput{sampleRate: sampleRate}
+}
+
+func (out *NullAudioOutput) SampleRate() int {
+	return out.sampleRate
+}
+
+func (out *NullAudioOutput) WriteSample(left, right int16) {}
+
+// AudioBuffer is a fixed-size ring buffer of stereo samples, which can be
+// consumed as a little-endian signed 16-bit PCM stream via Read. If the
+// console gets ahead of the reader, the oldest samples are dropped. If the
+// reader gets ahead of the console, silence is returned.
+type AudioBuffer struct {
+	mu         sync.Mutex
+	sampleRate int
+	samples    [][2]int16
+	readPos    int
+	len        int
+}
+
+func NewAudioBuffer(sampleRate int, capacity int) *AudioBuffer {
+	return &AudioBuffer{
+		sampleRate: sampleRate,
+		samples:    make([][2]int16, capacity),
+	}
+}
+
+func (buf *AudioBuffer) Len() int {
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+
+	return buf.len
+}
+
+func (buf *AudioBuffer) Read(p []byte) (int, error) {
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+
+	frames := len(p) / AUDIO_FRAME_SIZE
+
+	for i := range frames {
+		var sample [2]int16
+		if buf.len > 0 {
+			sample = buf.samples[buf.readPos]
+			buf.readPos = (buf.readPos + 1) % len(buf.samples)
+			buf.len--
+		}
+
+		binary.LittleEndian.PutUint16(p[i*AUDIO_FRAME_SIZE:], uint16(sample[0]))
+		binary.LittleEndian.PutUint16(p[i*AUDIO_FRAME_SIZE+2:], uint16(sample[1]))
+	}
+
+	return frames * AUDIO_FRAME_SIZE, nil
+}
+
+func (buf *AudioBuffer) SampleRate() int {
+	return buf.sampleRate
+}
+
+func (buf *AudioBuffer) WriteSample(left, right int16) {
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+
+	if len(buf.samples) == 0 {
+		return
+	}
+
+	if buf.len == len(buf.samples) {
+		// Drop the oldest sample
+		buf.readPos = (buf.readPos + 1) % len(buf.samples)
+		buf.len--
+	}
+
+	writePos := (buf.readPos + buf.len) % len(buf.samples)
+	buf.samples[writePos] = [2]int16{left, right}
+	buf.len++
+}
@@ -0,0 +1,70 @@
+package devices
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudioBufferRead(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := NewAudioBuffer(DEFAULT_AUDIO_SAMPLE_RATE, 4)
+	buf.WriteSample(0x0102, -2)
+
+	// Underruns are padded with silence
+	p := make([]byte, 2*AUDIO_FRAME_SIZE+1)
+	n, err := buf.Read(p)
+	assert.NoError(err)
+	assert.Equal(2*AUDIO_FRAME_SIZE, n)
+	assert.Equal([]byte{0x02, 0x01, 0xFE, 0xFF, 0x00, 0x00, 0x00, 0x00}, p[:n])
+	assert.Equal(0, buf.Len())
+}
+
+func TestAudioBufferOverflow(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := NewAudioBuffer(DEFAULT_AUDIO_SAMPLE_RATE, 2)
+	buf.WriteSample(1, 1)
+	buf.WriteSample(2, 2)
+	buf.WriteSample(3, 3)
+	assert.Equal(2, buf.Len())
+
+	// Oldest samples are dropped first
+	p := make([]byte, 2*AUDIO_FRAME_SIZE)
+	_, err := buf.Read(p)
+	assert.NoError(err)
+	assert.Equal([]byte{0x02, 0x00, 0x02, 0x00, 0x03, 0x00, 0x03, 0x00}, p)
+}
+
+func TestWAVAudioWriter(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := os.CreateTemp(t.TempDir(), "*.wav")
+	require.NoError(t, err)
+	defer f.Close()
+
+	aw, err := NewWAVAudioWriter(f, 44100)
+	require.NoError(t, err)
+
+	aw.WriteSample(1, -1)
+	aw.WriteSample(2, -2)
+	require.NoError(t, aw.Close())
+
+	// Writes after close are dropped
+	aw.WriteSample(3, -3)
+
+	wav, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Len(wav, WAV_HEADER_SIZE+2*AUDIO_FRAME_SIZE)
+	assert.Equal("RIFF", string(wav[0:4]))
+	assert.Equal(uint32(WAV_HEADER_SIZE-8+2*AUDIO_FRAME_SIZE), binary.LittleEndian.Uint32(wav[4:8]))
+	assert.Equal("WAVE", string(wav[8:12]))
+	assert.Equal(uint32(44100), binary.LittleEndian.Uint32(wav[24:28]))
+	assert.Equal("data", string(wav[36:40]))
+	assert.Equal(uint32(2*AUDIO_FRAME_SIZE), binary.LittleEndian.Uint32(wav[40:44]))
+	assert.Equal([]byte{0x01, 0x00, 0xFF, 0xFF, 0x02, 0x00, 0xFE, 0xFF}, wav[WAV_HEADER_SIZE:])
+}
@@ -0,0 +1,193 @@
+package devices
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+const (
+	WAV_HEADER_SIZE = 44
+
+	wavRIFFSizeOffset = 4
+	wavDataSizeOffset = 40
+
+	// Used in the header until it's fixed up on Close, so that a file from an
+	// interrupted run still reads as a (streaming) WAV file
+	wavPlaceholderSize = 0xFFFFFFFF
+)
+
+// PCMAudioWriter writes stereo signed 16-bit little-endian PCM, either raw
+// (e.g. to a pipe) or wrapped in a WAV container. Samples written after Close
+// are discarded.
+type PCMAudioWriter struct {
+	mu         sync.Mutex
+	closed     bool
+	w          io.Writer
+	buf        *bufio.Writer
+	sampleRate int
+	wav        bool
+	dataSize   uint32
+	err        error
+}
+
+var _ AudioOutput = (*PCMAudioWriter)(nil)
+
+func NewRawAudioWriter(w io.Writer, sampleRate int) *PCMAudioWriter {
+	return &PCMAudioWriter{
+		w:          w,
+		buf:        bufio.NewWriter(w),
+		sampleRate: sampleRate,
+	}
+}
+
+// NewWAVAudioWriter writes a WAV header w/ placeholder sizes. The header is
+// fixed up w/ the real sizes on Close, which is why the writer must be seekable.
+func NewWAVAudioWriter(w io.WriteSeeker, sampleRate int) (*PCMAudioWriter, error) {
+	aw := &PCMAudioWriter{
+		w:          w,
+		buf:        bufio.NewWriter(w),
+		sampleRate: sampleRate,
+		wav:        true,
+	}
+
+	if err := aw.writeWAVHeader(); err != nil {
+		return nil, fmt.Errorf("writing WAV header: %w", err)
+	}
+
+	return aw, nil
+}
+
+func (aw *PCMAudioWriter) Close() error {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	if aw.closed {
+		return nil
+	}
+	aw.closed = true
+
+	if err := aw.buf.Flush(); err != nil {
+		return fmt.Errorf("flushing audio: %w", err)
+	}
+
+	if aw.err != nil {
+		return fmt.Errorf("writing audio: %w", aw.err)
+	}
+
+	if !aw.wav {
+		return nil
+	}
+
+	seeker, ok := aw.w.(io.Seeker)
+	if !ok {
+		return errors.New("unable to fix up WAV header: writer is not seekable")
+	}
+
+	if _, err := seeker.Seek(wavRIFFSizeOffset, io.SeekStart); err != nil {
+		return fmt.Errorf("seeking to WAV RIFF size: %w", err)
+	}
+
+	if err := binary.Write(aw.w, binary.LittleEndian, aw.dataSize+WAV_HEADER_SIZE-8); err != nil {
+		return fmt.Errorf("fixing up WAV RIFF size: %w", err)
+	}
+
+	if _, err := seeker.Seek(wavDataSizeOffset, io.SeekStart); err != nil {
+		return fmt.Errorf("seeking to WAV data size: %w", err)
+	}
+
+	if err := binary.Write(aw.w, binary.LittleEndian, aw.dataSize); err != nil {
+		return fmt.Errorf("fixing up WAV data size: %w", err)
+	}
+
+	if _, err := seeker.Seek(0, io.SeekEnd); err != nil {
+		return fmt.Errorf("seeking to end of WAV: %w", err)
+	}
+
+	return nil
+}
+
+func (aw *PCMAudioWriter) SampleRate() int {
+	return aw.sampleRate
+}
+
+func (aw *PCMAudioWriter) WriteSample(left, right int16) {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	if aw.closed || aw.err != nil {
+		return
+	}
+
+	var frame [AUDIO_FRAME_SIZE]byte
+	binary.LittleEndian.PutUint16(frame[0:], uint16(left))
+	binary.LittleEndian.PutUint16(frame[2:], uint16(right))
+
+	if _, err := aw.buf.Write(frame[:]); err != nil {
+		aw.err = err
+
+		return
+	}
+
+	aw.dataSize += AUDIO_FRAME_SIZE
+}
+
+func (aw *PCMAudioWriter) writeWAVHeader() error {
+	const (
+		channels      = 2
+		bitsPerSample = 16
+		fmtChunkSize  = 16
+		formatPCM     = 1
+	)
+
+	header := []any{
+		[4]byte{'R', 'I', 'F', 'F'},
+		uint32(wavPlaceholderSize),
+		[4]byte{'W', 'A', 'V', 'E'},
+		[4]byte{'f', 'm', 't', ' '},
+		uint32(fmtChunkSize),
+		uint16(formatPCM),
+		uint16(channels),
+		uint32(aw.sampleRate),
+		uint32(aw.sampleRate * AUDIO_FRAME_SIZE), // Byte rate
+		uint16(AUDIO_FRAME_SIZE),                 // Block align
+		uint16(bitsPerSample),
+		[4]byte{'d', 'a', 't', 'a'},
+		uint32(wavPlaceholderSize),
+	}
+
+	for _, field := range header {
+		if err := binary.Write(aw.buf, binary.LittleEndian, field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MultiAudioOutput duplicates samples to each of its outputs, much like
+// io.MultiWriter. All outputs are expected to share the same sample rate.
+type MultiAudioOutput struct {
+	outputs []AudioOutput
+}
+
+func NewMultiAudioOutput(outputs ...AudioOutput) *MultiAudioOutput {
+	return &MultiAudioOutput{outputs: outputs}
+}
+
+func (mo *MultiAudioOutput) SampleRate() int {
+	if len(mo.outputs) == 0 {
+		return DEFAULT_AUDIO_SAMPLE_RATE
+	}
+
+	return mo.outputs[0].SampleRate()
+}
+
+func (mo *MultiAudioOutput) WriteSample(left, right int16) {
+	for _, output := range mo.outputs {
+		output.WriteSample(left, right)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/maxfierke/gogo-gb/bits"
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
 )
 
 const (
@@ -20,6 +21,7 @@ const (
 
 type BootROM interface {
 	mem.MemHandler
+	savestate.Serializer
 	AttachMemHandlers(mmu *mem.MMU)
 	LoadROM(r io.Reader) error
 }
@@ -40,6 +42,12 @@ func (br *DMGBootROM) AttachMemHandlers(mmu *mem.MMU) {
 	mmu.AddHandler(mem.MemRegion{Start: 0xFF50, End: 0xFF50}, br) // BootROM enable register
 }
 
+// LoadState restores whether the boot ROM is still mapped. The ROM itself is
+// considered part of the console's configuration, and isn't included.
+func (br *DMGBootROM) LoadState(r io.Reader) error {
+	return savestate.Read(r, &br.enabled)
+}
+
 func (br *DMGBootROM) LoadROM(r io.Reader) error {
 	if _, err := r.Read(br.rom[:]); err != nil {
 		return fmt.Errorf("unable to load boot ROM: %w", err)
@@ -58,6 +66,10 @@ func (br *DMGBootROM) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 	}
 }
 
+func (br *DMGBootROM) SaveState(w io.Writer) error {
+	return savestate.Write(w, &br.enabled)
+}
+
 func (br *DMGBootROM) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 	if addr == REG_BOOTROM_EN && br.enabled {
 		br.enabled = value == 0x00
@@ -87,6 +99,10 @@ func (br *CGBBootROM) AttachMemHandlers(mmu *mem.MMU) {
 	mmu.AddHandler(mem.MemRegion{Start: 0x0200, End: 0x08FF}, br) // BootROM (Part 2)
 }
 
+func (br *CGBBootROM) LoadState(r io.Reader) error {
+	return savestate.Read(r, &br.enabled, &br.dmgModeEnabled)
+}
+
 func (br *CGBBootROM) LoadROM(r io.Reader) error {
 	if _, err := r.Read(br.rom[:]); err != nil {
 		return fmt.Errorf("unable to load boot ROM: %w", err)
@@ -113,6 +129,10 @@ func (br *CGBBootROM) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 	}
 }
 
+func (br *CGBBootROM) SaveState(w io.Writer) error {
+	return savestate.Write(w, &br.enabled, &br.dmgModeEnabled)
+}
+
 func (br *CGBBootROM) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 	if addr == REG_BOOTROM_EN && br.enabled {
 		br.enabled = value == 0x00
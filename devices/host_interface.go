@@ -3,6 +3,7 @@ package devices
 import "image"
 
 type HostInterface interface {
+	AudioOutput() AudioOutput
 	Framebuffer() chan<- image.Image
 	JoypadInput() <-chan JoypadInputs
 	RequestFrame() <-chan struct{}
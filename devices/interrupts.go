@@ -2,9 +2,11 @@ package devices
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/maxfierke/gogo-gb/bits"
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
 )
 
 const (
@@ -118,6 +120,19 @@ func (ic *InterruptController) ConsumeRequest() IRQ {
 	return nextReq
 }
 
+func (ic *InterruptController) LoadState(r io.Reader) error {
+	var enabled, requested uint8
+
+	if err := savestate.Read(r, &enabled, &requested); err != nil {
+		return err
+	}
+
+	ic.enabled.Write(enabled)
+	ic.requested.Write(requested)
+
+	return nil
+}
+
 func (ic *InterruptController) NextRequest() IRQ {
 	if ic.enabled.vblank && ic.requested.vblank {
 		return INT_VBLANK
@@ -147,6 +162,10 @@ func (ic *InterruptController) Reset() {
 	ic.requested.Write(0x00)
 }
 
+func (ic *InterruptController) SaveState(w io.Writer) error {
+	return savestate.Write(w, ic.enabled.Read(), ic.requested.Read())
+}
+
 func (ic *InterruptController) RequestLCD() {
 	ic.requested.lcd = true
 }
@@ -172,7 +191,8 @@ func (ic *InterruptController) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 	case REG_IE:
 		return mem.ReadReplace(ic.enabled.Read())
 	case REG_IF:
-		return mem.ReadReplace(ic.requested.Read())
+		// The upper 3 bits are unused, & always read as 1
+		return mem.ReadReplace(ic.requested.Read() | 0xE0)
 	default:
 		return mem.ReadPassthrough()
 	}
@@ -0,0 +1,28 @@
+package devices
+
+import (
+	"testing"
+
+	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterruptFlagsUnusedBits(t *testing.T) {
+	assert := assert.New(t)
+
+	ic := NewInterruptController()
+	mmu := mem.NewMMU(make([]byte, 0x10000))
+	mmu.AddHandler(mem.MemRegion{Start: REG_IF, End: REG_IF}, ic)
+	mmu.AddHandler(mem.MemRegion{Start: REG_IE, End: REG_IE}, ic)
+
+	assert.Equal(byte(0xE0), mmu.Read8(REG_IF))
+
+	mmu.Write8(REG_IF, 0xFF)
+	assert.Equal(byte(0xFF), mmu.Read8(REG_IF))
+
+	mmu.Write8(REG_IF, 0x04)
+	assert.Equal(byte(0xE4), mmu.Read8(REG_IF))
+
+	mmu.Write8(REG_IE, 0x1F)
+	assert.Equal(byte(0x1F), mmu.Read8(REG_IE))
+}
@@ -1,10 +1,12 @@
 package devices
 
 import (
+	"io"
 	"sync"
 
 	"github.com/maxfierke/gogo-gb/bits"
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
 )
 
 const (
@@ -50,6 +52,32 @@ func NewJoypad(ic *InterruptController) *Joypad {
 	}
 }
 
+// IsAnyLineLow returns whether any of the selected input lines are low, i.e.
+// a selected button is pressed. This is what wakes the system from STOP.
+func (j *Joypad) IsAnyLineLow() bool {
+	j.inputStateMu.Lock()
+	defer j.inputStateMu.Unlock()
+
+	buttonsLow := j.readButtons && (j.inputState.A || j.inputState.B || j.inputState.Select || j.inputState.Start)
+	dpadLow := j.readDPad && (j.inputState.Up || j.inputState.Down || j.inputState.Left || j.inputState.Right)
+
+	return buttonsLow || dpadLow
+}
+
+func (j *Joypad) LoadState(r io.Reader) error {
+	j.inputStateMu.Lock()
+	defer j.inputStateMu.Unlock()
+
+	return savestate.Read(r, &j.readButtons, &j.readDPad, &j.inputState)
+}
+
+func (j *Joypad) SaveState(w io.Writer) error {
+	j.inputStateMu.Lock()
+	defer j.inputStateMu.Unlock()
+
+	return savestate.Write(w, &j.readButtons, &j.readDPad, &j.inputState)
+}
+
 func (j *Joypad) ReceiveInputs(inputs JoypadInputs) {
 	j.inputStateMu.Lock()
 	defer j.inputStateMu.Unlock()
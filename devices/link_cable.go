@@ -0,0 +1,350 @@
+package devices
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// LINK_CABLE_TIMEOUT is how long the master waits on the other end to reply
+	// to a transfer, before completing it as if nothing were connected
+	LINK_CABLE_TIMEOUT = time.Second
+
+	// LINK_CABLE_HANDSHAKE_TIMEOUT is how long to wait on the other end to
+	// identify itself, once connected
+	LINK_CABLE_HANDSHAKE_TIMEOUT = 10 * time.Second
+
+	LINK_CABLE_VERSION = 1
+
+	linkMsgTransfer byte = 'T'
+	linkMsgReply    byte = 'R'
+)
+
+var linkCableMagic = []byte("GOGO-GB-LINK")
+
+var (
+	ErrLinkCableHandshake = errors.New("link cable peer is not a compatible gogo-gb")
+	ErrLinkCableProtocol  = errors.New("link cable peer sent an unrecognized message")
+	ErrLinkCableTimeout   = errors.New("timed out waiting on link cable peer")
+)
+
+// LinkCable connects the serial port to another instance of gogo-gb over the
+// network. Each byte's exchanged as it's clocked, & the master's transfer isn't
+// completed until the other end's reply arrives, so both ends stay in lockstep
+// on every transfer. The serial port keeps running while it's on its way.
+//
+// Whichever end is using its internal clock is the master for a transfer, & the
+// other end only takes part if it's waiting on the external clock. Otherwise,
+// or if both ends try to be the master at once, the master receives 0xFF, just
+// as it would w/ nothing connected.
+type LinkCable struct {
+	conn    net.Conn
+	replies chan byte
+	done    chan struct{}
+	err     error // Why the connection closed, once done is
+
+	// When to give up on a reply to the transfer started by WriteByte
+	deadline time.Time
+
+	writeMu sync.Mutex
+
+	mu  sync.Mutex
+	end linkCableEnd
+}
+
+var (
+	_ AsyncSerialCable  = (*LinkCable)(nil)
+	_ SerialClockSource = (*LinkCable)(nil)
+)
+
+// NewLinkCable identifies itself to the other end of conn & starts listening
+// for transfers from it. conn is closed if the other end isn't gogo-gb.
+func NewLinkCable(conn net.Conn) (*LinkCable, error) {
+	if err := linkCableHandshake(conn); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	lc := &LinkCable{
+		conn:    conn,
+		replies: make(chan byte, 1),
+		done:    make(chan struct{}),
+	}
+
+	go lc.receive()
+
+	return lc, nil
+}
+
+// AcceptLinkCable waits for the other end to connect to listener
+func AcceptLinkCable(listener net.Listener) (*LinkCable, error) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return nil, fmt.Errorf("accepting link cable connection: %w", err)
+	}
+
+	return NewLinkCable(conn)
+}
+
+// DialLinkCable connects to the other end, which is listening at addr
+func DialLinkCable(addr string) (*LinkCable, error) {
+	conn, err := net.DialTimeout("tcp", addr, LINK_CABLE_HANDSHAKE_TIMEOUT)
+	if err != nil {
+		return nil, fmt.Errorf("connecting link cable to %s: %w", addr, err)
+	}
+
+	return NewLinkCable(conn)
+}
+
+// ListenLinkCable waits for the other end to connect at addr
+func ListenLinkCable(addr string) (*LinkCable, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listening for link cable on %s: %w", addr, err)
+	}
+	defer listener.Close()
+
+	return AcceptLinkCable(listener)
+}
+
+func (lc *LinkCable) Close() error {
+	err := lc.conn.Close()
+	<-lc.done
+
+	return err
+}
+
+func (lc *LinkCable) RemoteAddr() net.Addr {
+	return lc.conn.RemoteAddr()
+}
+
+// PollByte checks for the other end's reply to the transfer started by
+// WriteByte, w/o waiting on it
+func (lc *LinkCable) PollByte() (byte, bool, error) {
+	select {
+	case value := <-lc.replies:
+		return value, true, nil
+	case <-lc.done:
+		return 0xFF, true, fmt.Errorf("link cable disconnected: %w", lc.err)
+	default:
+	}
+
+	if time.Now().After(lc.deadline) {
+		return 0xFF, true, ErrLinkCableTimeout
+	}
+
+	return 0x00, false, nil
+}
+
+// ReadByte waits on the other end to reply to the transfer started by
+// WriteByte. The serial port uses PollByte instead, so it isn't held up.
+func (lc *LinkCable) ReadByte() (byte, error) {
+	timeout := time.NewTimer(time.Until(lc.deadline))
+	defer timeout.Stop()
+
+	select {
+	case value := <-lc.replies:
+		return value, nil
+	case <-lc.done:
+		return 0xFF, fmt.Errorf("link cable disconnected: %w", lc.err)
+	case <-timeout.C:
+		return 0xFF, ErrLinkCableTimeout
+	}
+}
+
+func (lc *LinkCable) ReceiveClocked(send byte) (byte, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	return lc.end.receiveClocked(send)
+}
+
+func (lc *LinkCable) StopListening() {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.end.stopListening()
+}
+
+// WriteByte starts a transfer as the master
+func (lc *LinkCable) WriteByte(value byte) error {
+	lc.mu.Lock()
+	lc.end.listening = false
+	lc.mu.Unlock()
+
+	// Drop any reply to an earlier transfer that timed out
+	select {
+	case <-lc.replies:
+	default:
+	}
+
+	lc.deadline = time.Now().Add(LINK_CABLE_TIMEOUT)
+
+	return lc.send(linkMsgTransfer, value)
+}
+
+// clockedBy handles a transfer from the other end, returning the reply
+func (lc *LinkCable) clockedBy(value byte) byte {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	return lc.end.clockedBy(value)
+}
+
+func (lc *LinkCable) receive() {
+	defer close(lc.done)
+
+	msg := make([]byte, 2)
+
+	for {
+		if _, err := io.ReadFull(lc.conn, msg); err != nil {
+			lc.err = err
+
+			return
+		}
+
+		switch msg[0] {
+		case linkMsgTransfer:
+			if err := lc.send(linkMsgReply, lc.clockedBy(msg[1])); err != nil {
+				lc.err = err
+
+				return
+			}
+		case linkMsgReply:
+			select {
+			case lc.replies <- msg[1]:
+			default:
+			}
+		default:
+			lc.err = ErrLinkCableProtocol
+			lc.conn.Close()
+
+			return
+		}
+	}
+}
+
+func (lc *LinkCable) send(kind byte, value byte) error {
+	lc.writeMu.Lock()
+	defer lc.writeMu.Unlock()
+
+	_, err := lc.conn.Write([]byte{kind, value})
+
+	return err
+}
+
+func linkCableHandshake(conn net.Conn) error {
+	hello := append(bytes.Clone(linkCableMagic), LINK_CABLE_VERSION)
+
+	if err := conn.SetDeadline(time.Now().Add(LINK_CABLE_HANDSHAKE_TIMEOUT)); err != nil {
+		return err
+	}
+
+	if _, err := conn.Write(hello); err != nil {
+		return fmt.Errorf("identifying to link cable peer: %w", err)
+	}
+
+	peerHello := make([]byte, len(hello))
+	if _, err := io.ReadFull(conn, peerHello); err != nil {
+		return fmt.Errorf("waiting on link cable peer to identify itself: %w", err)
+	}
+
+	if !bytes.Equal(hello, peerHello) {
+		return ErrLinkCableHandshake
+	}
+
+	return conn.SetDeadline(time.Time{})
+}
+
+// LocalLinkCable is one end of a link cable between two consoles in the same
+// process. Transfers are exchanged immediately, w/ the same rules as LinkCable,
+// so both consoles must be stepped from the same goroutine, e.g. by
+// hardware.RunLinked.
+type LocalLinkCable struct {
+	peer  *LocalLinkCable
+	end   linkCableEnd
+	reply byte
+}
+
+var (
+	_ SerialCable       = (*LocalLinkCable)(nil)
+	_ SerialClockSource = (*LocalLinkCable)(nil)
+)
+
+// NewLocalLinkCables returns both ends of a link cable
+func NewLocalLinkCables() (*LocalLinkCable, *LocalLinkCable) {
+	a := &LocalLinkCable{}
+	b := &LocalLinkCable{peer: a}
+	a.peer = b
+
+	return a, b
+}
+
+func (lc *LocalLinkCable) ReadByte() (byte, error) {
+	return lc.reply, nil
+}
+
+func (lc *LocalLinkCable) ReceiveClocked(send byte) (byte, bool) {
+	return lc.end.receiveClocked(send)
+}
+
+func (lc *LocalLinkCable) StopListening() {
+	lc.end.stopListening()
+}
+
+func (lc *LocalLinkCable) WriteByte(value byte) error {
+	lc.end.listening = false
+	lc.reply = lc.peer.end.clockedBy(value)
+
+	return nil
+}
+
+// linkCableEnd tracks whether the serial port's waiting on the external clock,
+// so the other end can clock a transfer
+type linkCableEnd struct {
+	listening   bool
+	offer       byte
+	received    byte
+	hasReceived bool
+}
+
+// clockedBy handles a transfer from the other end, returning the reply, or
+// 0xFF if the serial port wasn't waiting on one
+func (end *linkCableEnd) clockedBy(value byte) byte {
+	if !end.listening {
+		return 0xFF
+	}
+
+	end.listening = false
+	end.received = value
+	end.hasReceived = true
+
+	return end.offer
+}
+
+func (end *linkCableEnd) receiveClocked(send byte) (byte, bool) {
+	if end.hasReceived {
+		end.hasReceived = false
+
+		return end.received, true
+	}
+
+	end.listening = true
+	end.offer = send
+
+	return 0x00, false
+}
+
+// stopListening drops the offer made to the other end, & anything it clocked
+// in that hasn't been picked up yet
+func (end *linkCableEnd) stopListening() {
+	end.listening = false
+	end.offer = 0xFF
+	end.hasReceived = false
+}
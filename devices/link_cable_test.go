@@ -0,0 +1,300 @@
+package devices
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLinkCablePair connects two link cables together over loopback
+func newLinkCablePair(t *testing.T) (*LinkCable, *LinkCable) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	accepted := make(chan *LinkCable, 1)
+	go func() {
+		cable, err := AcceptLinkCable(listener)
+		assert.NoError(t, err)
+		accepted <- cable
+	}()
+
+	dialed, err := DialLinkCable(listener.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { dialed.Close() })
+
+	listened := <-accepted
+	require.NotNil(t, listened)
+	t.Cleanup(func() { listened.Close() })
+
+	return listened, dialed
+}
+
+// stepSerialUntilInterrupt steps the serial port until its transfer completes,
+// giving the other end's reply time to arrive
+func stepSerialUntilInterrupt(t *testing.T, sp *SerialPort, ic *InterruptController) {
+	t.Helper()
+
+	deadline := time.Now().Add(LINK_CABLE_TIMEOUT)
+
+	for ic.NextRequest() == INT_NONE {
+		require.True(t, time.Now().Before(deadline), "transfer never completed")
+		stepSerial(sp, ic, SERIAL_CLOCK_CYCLES)
+	}
+}
+
+func TestLinkCableTransfer(t *testing.T) {
+	assert := assert.New(t)
+
+	master, slave := newLinkCablePair(t)
+
+	_, ok := slave.ReceiveClocked(0x42)
+	assert.False(ok)
+
+	require.NoError(t, master.WriteByte(0x11))
+	value, err := master.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(byte(0x42), value)
+
+	value, ok = slave.ReceiveClocked(0x99)
+	assert.True(ok)
+	assert.Equal(byte(0x11), value)
+
+	// Roles can swap between transfers
+	_, ok = master.ReceiveClocked(0x24)
+	assert.False(ok)
+
+	require.NoError(t, slave.WriteByte(0x99))
+	value, err = slave.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(byte(0x24), value)
+
+	value, ok = master.ReceiveClocked(0x24)
+	assert.True(ok)
+	assert.Equal(byte(0x99), value)
+}
+
+func TestLinkCableNotListening(t *testing.T) {
+	assert := assert.New(t)
+
+	master, slave := newLinkCablePair(t)
+
+	require.NoError(t, master.WriteByte(0x11))
+	value, err := master.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(byte(0xFF), value)
+
+	// The transfer was missed entirely
+	_, ok := slave.ReceiveClocked(0x42)
+	assert.False(ok)
+}
+
+func TestLinkCableBothMasters(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b := newLinkCablePair(t)
+
+	require.NoError(t, a.WriteByte(0x11))
+	require.NoError(t, b.WriteByte(0x22))
+
+	value, err := a.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(byte(0xFF), value)
+
+	value, err = b.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(byte(0xFF), value)
+}
+
+func TestLinkCableStopListening(t *testing.T) {
+	assert := assert.New(t)
+
+	master, slave := newLinkCablePair(t)
+
+	_, ok := slave.ReceiveClocked(0x42)
+	assert.False(ok)
+	slave.StopListening()
+
+	// The offer was withdrawn
+	require.NoError(t, master.WriteByte(0x11))
+	value, err := master.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(byte(0xFF), value)
+
+	_, ok = slave.ReceiveClocked(0x42)
+	assert.False(ok)
+
+	// Anything clocked in before it stopped listening is dropped too
+	require.NoError(t, master.WriteByte(0x22))
+	value, err = master.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(byte(0x42), value)
+
+	slave.StopListening()
+	_, ok = slave.ReceiveClocked(0x55)
+	assert.False(ok)
+
+	require.NoError(t, master.WriteByte(0x33))
+	value, err = master.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(byte(0x55), value)
+
+	value, ok = slave.ReceiveClocked(0x55)
+	assert.True(ok)
+	assert.Equal(byte(0x33), value)
+}
+
+func TestLinkCablePollByte(t *testing.T) {
+	assert := assert.New(t)
+
+	master, slave := newLinkCablePair(t)
+
+	_, ok := slave.ReceiveClocked(0x42)
+	assert.False(ok)
+
+	var (
+		value byte
+		err   error
+	)
+
+	require.NoError(t, master.WriteByte(0x11))
+	require.Eventually(t, func() bool {
+		value, ok, err = master.PollByte()
+
+		return ok
+	}, LINK_CABLE_TIMEOUT, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(byte(0x42), value)
+
+	// Nothing's been sent since
+	_, ok, err = master.PollByte()
+	assert.False(ok)
+	assert.NoError(err)
+
+	require.NoError(t, slave.Close())
+	require.Eventually(t, func() bool {
+		value, ok, err = master.PollByte()
+
+		return ok
+	}, LINK_CABLE_TIMEOUT, time.Millisecond)
+	assert.Error(err)
+	assert.Equal(byte(0xFF), value)
+}
+
+func TestLinkCableDisconnected(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b := newLinkCablePair(t)
+	require.NoError(t, b.Close())
+
+	_ = a.WriteByte(0x11)
+	value, err := a.ReadByte()
+	assert.Error(err)
+	assert.Equal(byte(0xFF), value)
+}
+
+func TestLinkCableHandshake(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = conn.Write([]byte("GET / HTTP/1.1\r\n\r\n"))
+	}()
+
+	_, err = AcceptLinkCable(listener)
+	assert.ErrorIs(t, err, ErrLinkCableHandshake)
+}
+
+func TestLinkCableSerialPorts(t *testing.T) {
+	assert := assert.New(t)
+
+	masterCable, slaveCable := newLinkCablePair(t)
+	master, masterIC := newSerialTestPort(masterCable)
+	slave, slaveIC := newSerialTestPort(slaveCable)
+
+	slave.OnWrite(NULL_MMU, REG_SERIAL_SB, 0x42)
+	slave.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x80)
+
+	master.OnWrite(NULL_MMU, REG_SERIAL_SB, 0x11)
+	master.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x81)
+
+	stepSerialUntilInterrupt(t, master, masterIC)
+	assert.Equal(mem.ReadReplace(0x42), master.OnRead(NULL_MMU, REG_SERIAL_SB))
+	assert.Equal(INT_SERIAL, masterIC.NextRequest())
+
+	stepSerial(slave, slaveIC, SERIAL_POLL_CYCLES+8*SERIAL_CLOCK_CYCLES)
+	assert.Equal(mem.ReadReplace(0x11), slave.OnRead(NULL_MMU, REG_SERIAL_SB))
+	assert.Equal(INT_SERIAL, slaveIC.NextRequest())
+}
+
+func TestLocalLinkCable(t *testing.T) {
+	assert := assert.New(t)
+
+	a, b := NewLocalLinkCables()
+
+	// Nothing's listening yet
+	require.NoError(t, a.WriteByte(0x11))
+	value, err := a.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(byte(0xFF), value)
+
+	_, ok := b.ReceiveClocked(0x42)
+	assert.False(ok)
+
+	require.NoError(t, a.WriteByte(0x11))
+	value, err = a.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(byte(0x42), value)
+
+	value, ok = b.ReceiveClocked(0x42)
+	assert.True(ok)
+	assert.Equal(byte(0x11), value)
+}
+
+func TestLocalLinkCableSerialPortsCancel(t *testing.T) {
+	assert := assert.New(t)
+
+	masterCable, slaveCable := NewLocalLinkCables()
+	master, masterIC := newSerialTestPort(masterCable)
+	slave, slaveIC := newSerialTestPort(slaveCable)
+
+	// The slave starts waiting, then gives up before the master clocks it
+	slave.OnWrite(NULL_MMU, REG_SERIAL_SB, 0x42)
+	slave.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x80)
+	slave.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x00)
+
+	master.OnWrite(NULL_MMU, REG_SERIAL_SB, 0x11)
+	master.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x81)
+	stepSerial(master, masterIC, 8*SERIAL_CLOCK_CYCLES)
+	assert.Equal(mem.ReadReplace(0xFF), master.OnRead(NULL_MMU, REG_SERIAL_SB))
+	assert.Equal(INT_SERIAL, masterIC.ConsumeRequest())
+
+	// Restarting doesn't pick up the transfer it missed
+	slave.OnWrite(NULL_MMU, REG_SERIAL_SB, 0x55)
+	slave.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x80)
+	stepSerial(slave, slaveIC, SERIAL_POLL_CYCLES+8*SERIAL_CLOCK_CYCLES)
+	assert.Equal(mem.ReadReplace(0x55), slave.OnRead(NULL_MMU, REG_SERIAL_SB))
+	assert.Equal(INT_NONE, slaveIC.NextRequest())
+
+	master.OnWrite(NULL_MMU, REG_SERIAL_SB, 0x22)
+	master.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x81)
+	stepSerial(master, masterIC, 8*SERIAL_CLOCK_CYCLES)
+	assert.Equal(mem.ReadReplace(0x55), master.OnRead(NULL_MMU, REG_SERIAL_SB))
+
+	stepSerial(slave, slaveIC, SERIAL_POLL_CYCLES+8*SERIAL_CLOCK_CYCLES)
+	assert.Equal(mem.ReadReplace(0x22), slave.OnRead(NULL_MMU, REG_SERIAL_SB))
+	assert.Equal(INT_SERIAL, slaveIC.NextRequest())
+}
@@ -0,0 +1,335 @@
+package devices
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	PRINTER_MAGIC_1 = 0x88
+	PRINTER_MAGIC_2 = 0x33
+
+	PRINTER_CMD_INIT  = 0x01
+	PRINTER_CMD_PRINT = 0x02
+	PRINTER_CMD_DATA  = 0x04
+	PRINTER_CMD_BREAK = 0x08
+	PRINTER_CMD_NUL   = 0x0F
+
+	PRINTER_ALIVE = 0x81
+
+	PRINTER_STATUS_CHECKSUM_ERR = 1 << 0
+	PRINTER_STATUS_PRINTING     = 1 << 1
+	PRINTER_STATUS_FULL         = 1 << 2
+	PRINTER_STATUS_UNPROCESSED  = 1 << 3
+	PRINTER_STATUS_PACKET_ERR   = 1 << 4
+
+	// PRINTER_DATA_SIZE is how much image data is in each DATA packet, i.e. 2
+	// rows of 20 tiles, & the printer has room for 9 of them
+	PRINTER_DATA_SIZE   = 0x280
+	PRINTER_BUFFER_SIZE = 9 * PRINTER_DATA_SIZE
+
+	// PRINTER_PRINT_POLLS is how many status checks printing takes to finish
+	PRINTER_PRINT_POLLS = 8
+
+	PRINTER_WIDTH = 160
+)
+
+type printerState uint8
+
+const (
+	printerStateMagic1 printerState = iota
+	printerStateMagic2
+	printerStateCommand
+	printerStateCompression
+	printerStateLengthLow
+	printerStateLengthHigh
+	printerStateData
+	printerStateChecksumLow
+	printerStateChecksumHigh
+	printerStateAlive
+	printerStateStatus
+)
+
+// printerShades are the shades of gray for each color the printer can print,
+// from white to black
+var printerShades = [4]uint8{0xFF, 0xAA, 0x55, 0x00}
+
+// Printer is a Game Boy Printer, plugged into the serial port. It saves each
+// strip printed as a PNG in a directory, once it's been fed past, i.e. once
+// something's printed w/ a margin after it, or the printer's closed.
+type Printer struct {
+	outDir string
+
+	state      printerState
+	command    byte
+	compressed bool
+	length     uint16
+	data       []byte
+	checksum   uint16
+	sum        uint16
+	response   byte
+
+	buf      []byte // Image data waiting to be printed
+	status   byte
+	printing int
+
+	strip  []byte // Shade of each pixel printed since the last margin
+	prints int
+
+	// The first error saving a print, reported by Close, as the serial port
+	// can't do anything about it
+	err error
+}
+
+var _ SerialCable = (*Printer)(nil)
+
+// NewPrinter returns a printer that saves strips to outDir, creating it if
+// need be
+func NewPrinter(outDir string) *Printer {
+	return &Printer{
+		outDir: outDir,
+	}
+}
+
+// Close saves anything that's been printed, but not yet fed past, returning
+// the first error saving a print, if any
+func (p *Printer) Close() error {
+	return errors.Join(p.err, p.savePrint())
+}
+
+// ReadByte returns the printer's response to the byte just sent
+func (p *Printer) ReadByte() (byte, error) {
+	return p.response, nil
+}
+
+func (p *Printer) WriteByte(value byte) error {
+	p.response = 0x00
+
+	switch p.state {
+	case printerStateMagic1:
+		if value == PRINTER_MAGIC_1 {
+			p.state = printerStateMagic2
+		}
+	case printerStateMagic2:
+		if value == PRINTER_MAGIC_2 {
+			p.state = printerStateCommand
+		} else if value != PRINTER_MAGIC_1 {
+			p.state = printerStateMagic1
+		}
+	case printerStateCommand:
+		p.command = value
+		p.sum = uint16(value)
+		p.state = printerStateCompression
+	case printerStateCompression:
+		p.compressed = value&0x1 != 0
+		p.sum += uint16(value)
+		p.state = printerStateLengthLow
+	case printerStateLengthLow:
+		p.length = uint16(value)
+		p.sum += uint16(value)
+		p.state = printerStateLengthHigh
+	case printerStateLengthHigh:
+		p.length |= uint16(value) << 8
+		p.sum += uint16(value)
+		p.data = p.data[:0]
+
+		if p.length == 0 {
+			p.state = printerStateChecksumLow
+		} else {
+			p.state = printerStateData
+		}
+	case printerStateData:
+		p.data = append(p.data, value)
+		p.sum += uint16(value)
+
+		if len(p.data) == int(p.length) {
+			p.state = printerStateChecksumLow
+		}
+	case printerStateChecksumLow:
+		p.checksum = uint16(value)
+		p.state = printerStateChecksumHigh
+	case printerStateChecksumHigh:
+		p.checksum |= uint16(value) << 8
+		p.state = printerStateAlive
+	case printerStateAlive:
+		p.response = PRINTER_ALIVE
+		p.state = printerStateStatus
+	case printerStateStatus:
+		p.state = printerStateMagic1
+
+		err := p.handlePacket()
+		p.response = p.status
+
+		if err != nil && p.err == nil {
+			p.err = err
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (p *Printer) handlePacket() error {
+	if p.checksum != p.sum {
+		p.status |= PRINTER_STATUS_CHECKSUM_ERR
+
+		return nil
+	}
+
+	p.status &^= PRINTER_STATUS_CHECKSUM_ERR | PRINTER_STATUS_PACKET_ERR
+
+	switch p.command {
+	case PRINTER_CMD_INIT:
+		p.buf = p.buf[:0]
+		p.printing = 0
+	case PRINTER_CMD_DATA:
+		data := p.data
+		if p.compressed {
+			data = decompressPrinterData(data)
+		}
+
+		p.buf = append(p.buf, data[:min(len(data), PRINTER_BUFFER_SIZE-len(p.buf))]...)
+	case PRINTER_CMD_PRINT:
+		if len(p.data) != 4 {
+			p.status |= PRINTER_STATUS_PACKET_ERR
+
+			break
+		}
+
+		p.printing = PRINTER_PRINT_POLLS
+
+		if err := p.print(p.data[1], p.data[2]); err != nil {
+			p.updateStatus()
+
+			return err
+		}
+	case PRINTER_CMD_BREAK:
+		p.buf = p.buf[:0]
+		p.printing = 0
+	case PRINTER_CMD_NUL:
+		if p.printing > 0 {
+			p.printing--
+		}
+	default:
+		p.status |= PRINTER_STATUS_PACKET_ERR
+	}
+
+	p.updateStatus()
+
+	return nil
+}
+
+// print prints the image data in the buffer w/ palette, feeding the paper past
+// it if there's a margin after it
+func (p *Printer) print(margins byte, palette byte) error {
+	if palette == 0x00 {
+		palette = 0xE4
+	}
+
+	tileRows := len(p.buf) / (16 * PRINTER_WIDTH / 8)
+
+	for tileRow := range tileRows {
+		for y := range 8 {
+			for x := range PRINTER_WIDTH {
+				tile := tileRow*PRINTER_WIDTH/8 + x/8
+				lo := p.buf[tile*16+y*2]
+				hi := p.buf[tile*16+y*2+1]
+				bit := 7 - x%8
+
+				colorIdx := (hi>>bit)&0x1<<1 | (lo>>bit)&0x1
+				p.strip = append(p.strip, (palette>>(colorIdx*2))&0x3)
+			}
+		}
+	}
+
+	p.buf = p.buf[:0]
+
+	if margins&0x0F == 0 {
+		return nil
+	}
+
+	return p.savePrint()
+}
+
+// savePrint saves the strip printed since the last margin as a PNG
+func (p *Printer) savePrint() error {
+	if len(p.strip) == 0 {
+		return nil
+	}
+
+	img := image.NewGray(image.Rect(0, 0, PRINTER_WIDTH, len(p.strip)/PRINTER_WIDTH))
+	for i, shade := range p.strip {
+		img.Pix[i] = printerShades[shade]
+	}
+
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		return fmt.Errorf("encoding print: %w", err)
+	}
+
+	if err := os.MkdirAll(p.outDir, 0o755); err != nil {
+		return fmt.Errorf("creating directory for prints: %w", err)
+	}
+
+	p.prints++
+	name := fmt.Sprintf("print-%s-%d.png", time.Now().Format("20060102-150405"), p.prints)
+
+	if err := os.WriteFile(filepath.Join(p.outDir, name), encoded.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("saving print: %w", err)
+	}
+
+	// Only dropped once it's saved, so it can be tried again on Close
+	p.strip = p.strip[:0]
+
+	return nil
+}
+
+func (p *Printer) updateStatus() {
+	p.status &^= PRINTER_STATUS_PRINTING | PRINTER_STATUS_FULL | PRINTER_STATUS_UNPROCESSED
+
+	if p.printing > 0 {
+		p.status |= PRINTER_STATUS_PRINTING
+	}
+
+	if len(p.buf) >= PRINTER_BUFFER_SIZE {
+		p.status |= PRINTER_STATUS_FULL
+	}
+
+	if len(p.buf) > 0 {
+		p.status |= PRINTER_STATUS_UNPROCESSED
+	}
+}
+
+// decompressPrinterData expands run-length encoded image data. Each run
+// starts w/ a control byte: if the top bit is set, the byte after it repeats
+// (control & 0x7F) + 2 times, otherwise (control + 1) bytes follow as-is.
+func decompressPrinterData(data []byte) []byte {
+	var out []byte
+
+	for i := 0; i < len(data); {
+		control := data[i]
+		i++
+
+		if control&0x80 != 0 {
+			if i >= len(data) {
+				break
+			}
+
+			out = append(out, bytes.Repeat([]byte{data[i]}, int(control&0x7F)+2)...)
+			i++
+		} else {
+			end := min(i+int(control)+1, len(data))
+			out = append(out, data[i:end]...)
+			i = end
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,237 @@
+package devices
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sendPrinterPacket sends a packet to the printer, returning its responses to
+// the alive & status bytes
+func sendPrinterPacket(t *testing.T, p *Printer, command byte, compressed bool, data []byte) (byte, byte) {
+	t.Helper()
+
+	packet := printerPacket(command, compressed, data)
+
+	responses := make([]byte, 0, len(packet))
+	for _, b := range packet {
+		require.NoError(t, p.WriteByte(b))
+
+		response, err := p.ReadByte()
+		require.NoError(t, err)
+		responses = append(responses, response)
+	}
+
+	return responses[len(responses)-2], responses[len(responses)-1]
+}
+
+func printerPacket(command byte, compressed bool, data []byte) []byte {
+	compression := byte(0x00)
+	if compressed {
+		compression = 0x01
+	}
+
+	body := append([]byte{command, compression, byte(len(data)), byte(len(data) >> 8)}, data...)
+
+	var checksum uint16
+	for _, b := range body {
+		checksum += uint16(b)
+	}
+
+	packet := append([]byte{PRINTER_MAGIC_1, PRINTER_MAGIC_2}, body...)
+
+	return append(packet, byte(checksum), byte(checksum>>8), 0x00, 0x00)
+}
+
+// printerStripe is a DATA packet's worth of tiles, w/ each row of pixels in a
+// tile using the next color
+func printerStripe() []byte {
+	data := make([]byte, 0, PRINTER_DATA_SIZE)
+
+	for range PRINTER_DATA_SIZE / 16 {
+		for y := range 8 {
+			colorIdx := byte(y % 4)
+			data = append(data, -(colorIdx & 0x1), -(colorIdx >> 1))
+		}
+	}
+
+	return data
+}
+
+func readPrints(t *testing.T, dir string) []image.Image {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join(dir, "print-*.png"))
+	require.NoError(t, err)
+
+	prints := make([]image.Image, 0, len(paths))
+	for _, path := range paths {
+		f, err := os.Open(path)
+		require.NoError(t, err)
+
+		img, err := png.Decode(f)
+		f.Close()
+		require.NoError(t, err)
+
+		prints = append(prints, img)
+	}
+
+	return prints
+}
+
+func TestPrinterStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	p := NewPrinter(t.TempDir())
+
+	alive, status := sendPrinterPacket(t, p, PRINTER_CMD_INIT, false, nil)
+	assert.Equal(byte(PRINTER_ALIVE), alive)
+	assert.Equal(byte(0x00), status)
+
+	_, status = sendPrinterPacket(t, p, PRINTER_CMD_DATA, false, printerStripe())
+	assert.Equal(byte(PRINTER_STATUS_UNPROCESSED), status)
+
+	for range 8 {
+		_, status = sendPrinterPacket(t, p, PRINTER_CMD_DATA, false, printerStripe())
+	}
+	assert.Equal(byte(PRINTER_STATUS_UNPROCESSED|PRINTER_STATUS_FULL), status)
+
+	_, status = sendPrinterPacket(t, p, PRINTER_CMD_PRINT, false, []byte{0x01, 0x13, 0xE4, 0x40})
+	assert.Equal(byte(PRINTER_STATUS_PRINTING), status)
+
+	for range PRINTER_PRINT_POLLS - 1 {
+		_, status = sendPrinterPacket(t, p, PRINTER_CMD_NUL, false, nil)
+		assert.Equal(byte(PRINTER_STATUS_PRINTING), status)
+	}
+
+	_, status = sendPrinterPacket(t, p, PRINTER_CMD_NUL, false, nil)
+	assert.Equal(byte(0x00), status)
+
+	_, status = sendPrinterPacket(t, p, 0x42, false, nil)
+	assert.Equal(byte(PRINTER_STATUS_PACKET_ERR), status)
+}
+
+func TestPrinterChecksumError(t *testing.T) {
+	assert := assert.New(t)
+
+	p := NewPrinter(t.TempDir())
+
+	packet := []byte{PRINTER_MAGIC_1, PRINTER_MAGIC_2, PRINTER_CMD_DATA, 0x00, 0x01, 0x00, 0xFF, 0x00, 0x00, 0x00, 0x00}
+	for _, b := range packet {
+		require.NoError(t, p.WriteByte(b))
+	}
+
+	status, err := p.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(byte(PRINTER_STATUS_CHECKSUM_ERR), status)
+
+	// The data was discarded
+	_, status = sendPrinterPacket(t, p, PRINTER_CMD_NUL, false, nil)
+	assert.Equal(byte(0x00), status)
+}
+
+func TestPrinterPrint(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	p := NewPrinter(dir)
+
+	sendPrinterPacket(t, p, PRINTER_CMD_INIT, false, nil)
+	sendPrinterPacket(t, p, PRINTER_CMD_DATA, false, printerStripe())
+	sendPrinterPacket(t, p, PRINTER_CMD_DATA, false, printerStripe())
+	sendPrinterPacket(t, p, PRINTER_CMD_DATA, false, nil)
+
+	// No margin after, so it's not fed past yet
+	sendPrinterPacket(t, p, PRINTER_CMD_PRINT, false, []byte{0x01, 0x10, 0xE4, 0x40})
+	assert.Empty(readPrints(t, dir))
+
+	// Inverted palette, w/ compressed data
+	compressed := []byte{}
+	for range PRINTER_DATA_SIZE / 16 {
+		compressed = append(compressed, 0x07, 0x00, 0x00, 0xFF, 0x00, 0x00, 0xFF, 0xFF, 0xFF)
+		compressed = append(compressed, 0x86, 0x00)
+	}
+	sendPrinterPacket(t, p, PRINTER_CMD_DATA, true, compressed)
+	sendPrinterPacket(t, p, PRINTER_CMD_PRINT, false, []byte{0x01, 0x03, 0x1B, 0x40})
+
+	prints := readPrints(t, dir)
+	require.Len(t, prints, 1)
+
+	img := prints[0]
+	assert.Equal(image.Rect(0, 0, PRINTER_WIDTH, 48), img.Bounds())
+
+	shades := []uint32{0xFFFF, 0xAAAA, 0x5555, 0x0000}
+	for y := range 32 {
+		r, _, _, _ := img.At(y%PRINTER_WIDTH, y).RGBA()
+		assert.Equal(shades[y%4], r, "row %d", y)
+	}
+
+	// 0x1B maps colors 0-3 to black, dark, light & white, & each tile in the
+	// compressed data is 4 rows of colors 0-3, then 4 rows of color 0
+	for y := 32; y < 48; y++ {
+		expected := shades[3-(y%4)]
+		if y%8 >= 4 {
+			expected = shades[3]
+		}
+
+		r, _, _, _ := img.At(8, y).RGBA()
+		assert.Equal(expected, r, "row %d", y)
+	}
+
+	// Closing saves nothing further
+	require.NoError(t, p.Close())
+	assert.Len(readPrints(t, dir), 1)
+}
+
+func TestPrinterCloseSavesPrint(t *testing.T) {
+	dir := t.TempDir()
+	p := NewPrinter(filepath.Join(dir, "prints"))
+
+	sendPrinterPacket(t, p, PRINTER_CMD_DATA, false, printerStripe())
+	sendPrinterPacket(t, p, PRINTER_CMD_PRINT, false, []byte{0x01, 0x00, 0xE4, 0x40})
+	require.NoError(t, p.Close())
+
+	assert.Len(t, readPrints(t, filepath.Join(dir, "prints")), 1)
+}
+
+func TestPrinterSaveError(t *testing.T) {
+	assert := assert.New(t)
+
+	// The directory for prints can't be created, as there's a file in the way
+	dir := filepath.Join(t.TempDir(), "prints")
+	require.NoError(t, os.WriteFile(dir, nil, 0o644))
+
+	p := NewPrinter(dir)
+	sendPrinterPacket(t, p, PRINTER_CMD_DATA, false, printerStripe())
+
+	var err error
+	for _, b := range printerPacket(PRINTER_CMD_PRINT, false, []byte{0x01, 0x01, 0xE4, 0x40}) {
+		err = p.WriteByte(b)
+	}
+	assert.Error(err)
+
+	// The print's kept until it can be saved, & the error's reported on Close
+	require.NoError(t, os.Remove(dir))
+
+	err = p.Close()
+	assert.Error(err)
+	assert.Len(readPrints(t, dir), 1)
+}
+
+func TestDecompressPrinterData(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(
+		[]byte{0x01, 0x02, 0x03, 0xAA, 0xAA, 0xAA, 0xAA, 0x04},
+		decompressPrinterData([]byte{0x02, 0x01, 0x02, 0x03, 0x82, 0xAA, 0x00, 0x04}),
+	)
+
+	// Truncated runs are cut short
+	assert.Equal([]byte{0x01}, decompressPrinterData([]byte{0x00, 0x01, 0x82}))
+	assert.Equal([]byte{0x01, 0x02}, decompressPrinterData([]byte{0x03, 0x01, 0x02}))
+}
@@ -5,11 +5,41 @@ import (
 	"io"
 )
 
+// SerialCable is whatever's plugged into the serial port. When the port's
+// using its internal clock, it's the master, & exchanges each byte w/ the other
+// end of the cable by writing it, then reading what was received in return.
 type SerialCable interface {
 	ReadByte() (byte, error)
 	WriteByte(value byte) error
 }
 
+// SerialClockSource is implemented by cables where the other end can be the
+// master, clocking transfers for a serial port using the external clock
+type SerialClockSource interface {
+	// ReceiveClocked is polled while the serial port is waiting on the external
+	// clock, w/ the byte it'll send in exchange. Once the other end has clocked
+	// a transfer, it returns the byte received & true.
+	ReceiveClocked(send byte) (byte, bool)
+
+	// StopListening is called when the serial port stops waiting on the
+	// external clock before the other end's clocked a transfer, e.g. because
+	// it was disabled. Anything offered or received since is dropped.
+	StopListening()
+}
+
+// AsyncSerialCable is implemented by cables where the other end's reply to a
+// transfer can take a while to arrive, e.g. over a network. Rather than waiting
+// on it w/ ReadByte, the serial port polls for it as the transfer's shifted, &
+// doesn't complete the transfer until it's arrived.
+type AsyncSerialCable interface {
+	SerialCable
+
+	// PollByte returns the reply to the transfer started by WriteByte, & true,
+	// once it's arrived, or has been given up on, in which case it's 0xFF w/
+	// an error
+	PollByte() (byte, bool, error)
+}
+
 type NullSerialCable struct{}
 
 func (sc *NullSerialCable) ReadByte() (byte, error) {
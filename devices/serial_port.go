@@ -2,8 +2,11 @@ package devices
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
+	"github.com/maxfierke/gogo-gb/scheduler"
 )
 
 const (
@@ -16,6 +19,17 @@ const (
 
 	SC_CLK_EXT = 0x0
 	SC_CLK_INT = 0x1
+
+	// SERIAL_CLOCK_CYCLES is how many cycles each bit takes w/ the internal
+	// clock (8192 Hz), or SERIAL_FAST_CLOCK_CYCLES w/ the CGB's fast clock
+	// (262144 Hz). The clock's derived from the same counter as DIV, so these
+	// are the same in double speed mode, but twice as fast in real time.
+	SERIAL_CLOCK_CYCLES      = 512
+	SERIAL_FAST_CLOCK_CYCLES = 16
+
+	// SERIAL_POLL_CYCLES is how often the cable is checked for a transfer from
+	// the other end, while waiting on the external clock
+	SERIAL_POLL_CYCLES = SERIAL_CLOCK_CYCLES
 )
 
 type SerialCtrl struct {
@@ -25,18 +39,19 @@ type SerialCtrl struct {
 }
 
 func (sc *SerialCtrl) Read() byte {
-	value := byte(0x0)
+	// Unused bits always read as 1
+	value := byte(0x7C)
 
 	if sc.transferEnabled {
-		value &= SC_TRANSFER_EN
+		value |= SC_TRANSFER_EN
 	}
 
 	if sc.clockSpeedDbl {
-		value &= SC_CLK_SPD
+		value |= SC_CLK_SPD
 	}
 
 	if sc.clockInternal {
-		value &= SC_CLK_INT
+		value |= SC_CLK_INT
 	}
 
 	return value
@@ -73,11 +88,17 @@ func (sc *SerialCtrl) SetClockInternal(enabled bool) {
 }
 
 type SerialPort struct {
-	clk   uint
-	ctrl  SerialCtrl
-	recv  byte
-	buf   byte
-	cable SerialCable
+	clk      uint // Cycles until the next bit is shifted
+	ctrl     SerialCtrl
+	recv     byte // Remaining bits being received from the other end
+	buf      byte // SB, which is shifted out as bits are shifted in
+	bitsLeft uint8
+	cable    SerialCable
+	color    bool
+
+	// Whether we're still waiting on the other end to reply to a transfer we're
+	// clocking. It's not saved, as the reply won't survive a reload.
+	awaitingReply bool
 }
 
 func NewSerialPort() *SerialPort {
@@ -90,22 +111,82 @@ func (sp *SerialPort) AttachCable(cable SerialCable) {
 	sp.cable = cable
 }
 
+func (sp *SerialPort) EnableColor() {
+	sp.color = true
+}
+
+func (sp *SerialPort) LoadState(r io.Reader) error {
+	sp.awaitingReply = false
+
+	return savestate.Read(r, sp.stateFields()...)
+}
+
+// NextEvent returns how many cycles until the transfer in progress completes,
+// or the cable's next polled for one driven by the external clock
+func (sp *SerialPort) NextEvent() uint {
+	if !sp.ctrl.IsTransferEnabled() {
+		return scheduler.NEVER
+	}
+
+	if sp.isHoldingLastBit() {
+		return SERIAL_POLL_CYCLES
+	}
+
+	if sp.bitsLeft > 0 {
+		return sp.clk + uint(sp.bitsLeft-1)*sp.cyclesPerBit()
+	}
+
+	if _, ok := sp.cable.(SerialClockSource); ok && !sp.ctrl.IsClockInternal() {
+		return SERIAL_POLL_CYCLES
+	}
+
+	return scheduler.NEVER
+}
+
+func (sp *SerialPort) SaveState(w io.Writer) error {
+	return savestate.Write(w, sp.stateFields()...)
+}
+
 func (sp *SerialPort) Step(cycles uint8, ic *InterruptController) {
 	if !sp.ctrl.IsTransferEnabled() {
 		return
 	}
 
-	if sp.ctrl.IsClockInternal() {
-		if sp.clk < uint(cycles) {
-			sp.buf = sp.recv
-			sp.ctrl.SetTransferEnabled(false)
-			ic.RequestSerial()
-		} else {
-			sp.clk -= uint(cycles)
+	if sp.awaitingReply {
+		sp.pollReply()
+	}
+
+	if sp.bitsLeft == 0 && !sp.ctrl.IsClockInternal() {
+		sp.pollExternalClock()
+	}
+
+	if sp.bitsLeft == 0 {
+		return
+	}
+
+	remaining := uint(cycles)
+	for sp.bitsLeft > 0 {
+		if sp.clk > remaining {
+			sp.clk -= remaining
+
+			return
 		}
+
+		if sp.bitsLeft == 1 && sp.awaitingReply {
+			// Hold off on completing the transfer until the other end's replied
+			sp.clk = 0
+
+			return
+		}
+
+		remaining -= sp.clk
+		sp.shiftBit()
+		sp.clk = sp.cyclesPerBit()
 	}
 
-	// TODO: Implement external clock
+	sp.clk = 0
+	sp.ctrl.SetTransferEnabled(false)
+	ic.RequestSerial()
 }
 
 func (sp *SerialPort) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
@@ -113,7 +194,14 @@ func (sp *SerialPort) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 	case REG_SERIAL_SB:
 		return mem.ReadReplace(sp.buf)
 	case REG_SERIAL_SC:
-		return mem.ReadReplace(sp.ctrl.Read())
+		value := sp.ctrl.Read()
+
+		// The clock speed bit is CGB-only
+		if !sp.color {
+			value |= SC_CLK_SPD
+		}
+
+		return mem.ReadReplace(value)
 	default:
 		return mem.ReadPassthrough()
 	}
@@ -126,20 +214,44 @@ func (sp *SerialPort) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrit
 
 		return mem.WriteBlock()
 	case REG_SERIAL_SC:
+		wasListening := sp.isListening()
+
 		sp.ctrl.Write(value)
 
-		if sp.ctrl.IsTransferEnabled() && sp.ctrl.IsClockInternal() {
-			// TODO(GBC): derive this somehow and factor in GBC speeds when relevant
-			sp.clk = 8192
+		if !sp.color {
+			sp.ctrl.SetClockSpeedDbl(false)
+		}
 
+		sp.bitsLeft = 0
+		sp.clk = 0
+		sp.awaitingReply = false
+
+		if source, ok := sp.cable.(SerialClockSource); ok && wasListening && !sp.isListening() {
+			// Don't leave the other end thinking we're still waiting on it
+			source.StopListening()
+		}
+
+		if sp.ctrl.IsTransferEnabled() && sp.ctrl.IsClockInternal() {
+			// We're the master, so exchange the whole byte w/ the other end up
+			// front, then shift it in a bit at a time
 			_ = sp.cable.WriteByte(sp.buf)
 
-			recvVal, err := sp.cable.ReadByte()
-			if err != nil {
-				sp.recv = 0xFF
+			if _, ok := sp.cable.(AsyncSerialCable); ok {
+				// Start shifting right away, & patch in the reply once it's
+				// arrived
+				sp.startTransfer(0xFF)
+				sp.awaitingReply = true
 			} else {
-				sp.recv = recvVal
+				recvVal, err := sp.cable.ReadByte()
+				if err != nil {
+					recvVal = 0xFF
+				}
+
+				sp.startTransfer(recvVal)
 			}
+		} else if sp.ctrl.IsTransferEnabled() {
+			// Let the other end know what we're sending, in case it's waiting
+			sp.pollExternalClock()
 		}
 
 		return mem.WriteBlock()
@@ -147,3 +259,88 @@ func (sp *SerialPort) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrit
 
 	panic(fmt.Sprintf("Attempting to write 0x%02X @ 0x%04X, which is out-of-bounds for serial port", value, addr))
 }
+
+// cyclesPerBit returns how many cycles each bit takes to shift. When using the
+// external clock, the other end's assumed to be using the normal speed.
+func (sp *SerialPort) cyclesPerBit() uint {
+	if sp.ctrl.IsClockInternal() && sp.ctrl.IsClockSpeedDbl() {
+		return SERIAL_FAST_CLOCK_CYCLES
+	}
+
+	return SERIAL_CLOCK_CYCLES
+}
+
+// isHoldingLastBit returns whether the transfer's only waiting on the other
+// end's reply to complete
+func (sp *SerialPort) isHoldingLastBit() bool {
+	return sp.awaitingReply && sp.bitsLeft == 1 && sp.clk == 0
+}
+
+// isListening returns whether the serial port's waiting on the other end to
+// clock a transfer
+func (sp *SerialPort) isListening() bool {
+	return sp.ctrl.IsTransferEnabled() && !sp.ctrl.IsClockInternal() && sp.bitsLeft == 0
+}
+
+// pollExternalClock checks whether the other end's clocked a transfer, while
+// waiting on the external clock
+func (sp *SerialPort) pollExternalClock() {
+	source, ok := sp.cable.(SerialClockSource)
+	if !ok {
+		return
+	}
+
+	if recvVal, ok := source.ReceiveClocked(sp.buf); ok {
+		sp.startTransfer(recvVal)
+	}
+}
+
+// pollReply checks whether the other end's replied to the transfer we're
+// clocking, & if so, swaps the bits shifted in so far for the reply's
+func (sp *SerialPort) pollReply() {
+	cable, ok := sp.cable.(AsyncSerialCable)
+	if !ok {
+		sp.awaitingReply = false
+
+		return
+	}
+
+	recvVal, ok, err := cable.PollByte()
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		recvVal = 0xFF
+	}
+
+	shifted := 8 - sp.bitsLeft
+	sp.buf = sp.buf&^(byte(1<<shifted)-1) | recvVal>>sp.bitsLeft
+	sp.recv = recvVal << shifted
+	sp.awaitingReply = false
+}
+
+// shiftBit shifts SB left by one, w/ the next bit received shifted into it
+func (sp *SerialPort) shiftBit() {
+	sp.buf = sp.buf<<1 | sp.recv>>7
+	sp.recv <<= 1
+	sp.bitsLeft--
+}
+
+func (sp *SerialPort) startTransfer(recvVal byte) {
+	sp.recv = recvVal
+	sp.bitsLeft = 8
+	sp.clk = sp.cyclesPerBit()
+}
+
+func (sp *SerialPort) stateFields() []any {
+	return []any{
+		&sp.clk,
+		&sp.ctrl.transferEnabled,
+		&sp.ctrl.clockSpeedDbl,
+		&sp.ctrl.clockInternal,
+		&sp.recv,
+		&sp.buf,
+		&sp.bitsLeft,
+	}
+}
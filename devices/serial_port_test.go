@@ -0,0 +1,293 @@
+package devices
+
+import (
+	"testing"
+
+	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+// clockedCable is driven by a fake master, which clocks in master once the
+// serial port's waiting on the external clock
+type clockedCable struct {
+	NullSerialCable
+
+	master   byte
+	clocked  bool
+	received []byte
+	stopped  int
+}
+
+func (cc *clockedCable) ReceiveClocked(send byte) (byte, bool) {
+	if !cc.clocked {
+		return 0, false
+	}
+
+	cc.clocked = false
+	cc.received = append(cc.received, send)
+
+	return cc.master, true
+}
+
+func (cc *clockedCable) StopListening() {
+	cc.stopped++
+}
+
+// pendingCable's reply to a transfer only arrives once it's been sent
+type pendingCable struct {
+	written []byte
+	reply   byte
+	sent    bool
+}
+
+func (pc *pendingCable) PollByte() (byte, bool, error) {
+	if !pc.sent {
+		return 0x00, false, nil
+	}
+
+	pc.sent = false
+
+	return pc.reply, true, nil
+}
+
+func (pc *pendingCable) ReadByte() (byte, error) {
+	return pc.reply, nil
+}
+
+func (pc *pendingCable) WriteByte(value byte) error {
+	pc.written = append(pc.written, value)
+
+	return nil
+}
+
+func (pc *pendingCable) send(reply byte) {
+	pc.reply = reply
+	pc.sent = true
+}
+
+// echoCable sends back whatever was last written to it
+type echoCable struct {
+	value byte
+}
+
+func (ec *echoCable) ReadByte() (byte, error) {
+	return ec.value, nil
+}
+
+func (ec *echoCable) WriteByte(value byte) error {
+	ec.value = value
+
+	return nil
+}
+
+func newSerialTestPort(cable SerialCable) (*SerialPort, *InterruptController) {
+	sp := NewSerialPort()
+	sp.AttachCable(cable)
+
+	ic := NewInterruptController()
+	ic.OnWrite(NULL_MMU, REG_IE, 0xFF)
+
+	return sp, ic
+}
+
+func stepSerial(sp *SerialPort, ic *InterruptController, cycles uint) {
+	for ; cycles > 0; cycles -= 4 {
+		sp.Step(4, ic)
+	}
+}
+
+func TestSerialCtrlUnusedBits(t *testing.T) {
+	assert := assert.New(t)
+
+	sp := NewSerialPort()
+	assert.Equal(mem.ReadReplace(0x7E), sp.OnRead(NULL_MMU, REG_SERIAL_SC))
+
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x01)
+	assert.Equal(mem.ReadReplace(0x7F), sp.OnRead(NULL_MMU, REG_SERIAL_SC))
+
+	// Selecting the fast clock does nothing on DMG
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x02)
+	assert.Equal(mem.ReadReplace(0x7E), sp.OnRead(NULL_MMU, REG_SERIAL_SC))
+	assert.False(sp.ctrl.IsClockSpeedDbl())
+
+	sp.EnableColor()
+	assert.Equal(mem.ReadReplace(0x7C), sp.OnRead(NULL_MMU, REG_SERIAL_SC))
+
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x02)
+	assert.Equal(mem.ReadReplace(0x7E), sp.OnRead(NULL_MMU, REG_SERIAL_SC))
+}
+
+func TestSerialInternalClock(t *testing.T) {
+	testCases := []struct {
+		name         string
+		color        bool
+		ctrl         byte
+		cyclesPerBit uint
+	}{
+		{name: "normal", ctrl: 0x81, cyclesPerBit: SERIAL_CLOCK_CYCLES},
+		{name: "fast ignored on DMG", ctrl: 0x83, cyclesPerBit: SERIAL_CLOCK_CYCLES},
+		{name: "fast", color: true, ctrl: 0x83, cyclesPerBit: SERIAL_FAST_CLOCK_CYCLES},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			sp, ic := newSerialTestPort(&NullSerialCable{})
+			if tc.color {
+				sp.EnableColor()
+			}
+
+			sp.OnWrite(NULL_MMU, REG_SERIAL_SB, 0x00)
+			sp.OnWrite(NULL_MMU, REG_SERIAL_SC, tc.ctrl)
+			assert.Equal(8*tc.cyclesPerBit, sp.NextEvent())
+
+			// Bits are shifted in a bit at a time, from the top
+			for bit := range 7 {
+				stepSerial(sp, ic, tc.cyclesPerBit)
+				assert.Equal(mem.ReadReplace(byte(0xFF)>>(7-bit)), sp.OnRead(NULL_MMU, REG_SERIAL_SB))
+				assert.Equal(INT_NONE, ic.NextRequest())
+			}
+
+			stepSerial(sp, ic, tc.cyclesPerBit-4)
+			assert.Equal(INT_NONE, ic.NextRequest())
+			assert.Equal(uint(4), sp.NextEvent())
+
+			stepSerial(sp, ic, 4)
+			assert.Equal(mem.ReadReplace(0xFF), sp.OnRead(NULL_MMU, REG_SERIAL_SB))
+			assert.Equal(INT_SERIAL, ic.NextRequest())
+			assert.False(sp.ctrl.IsTransferEnabled())
+			assert.Equal(scheduler.NEVER, sp.NextEvent())
+		})
+	}
+}
+
+func TestSerialInternalClockExchange(t *testing.T) {
+	assert := assert.New(t)
+
+	cable := &echoCable{}
+	sp, ic := newSerialTestPort(cable)
+
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SB, 0xA5)
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x81)
+	assert.Equal(byte(0xA5), cable.value)
+
+	stepSerial(sp, ic, 4*SERIAL_CLOCK_CYCLES)
+	assert.Equal(mem.ReadReplace(0x5A), sp.OnRead(NULL_MMU, REG_SERIAL_SB))
+
+	stepSerial(sp, ic, 4*SERIAL_CLOCK_CYCLES)
+	assert.Equal(mem.ReadReplace(0xA5), sp.OnRead(NULL_MMU, REG_SERIAL_SB))
+	assert.Equal(INT_SERIAL, ic.NextRequest())
+}
+
+func TestSerialInternalClockAsync(t *testing.T) {
+	assert := assert.New(t)
+
+	cable := &pendingCable{}
+	sp, ic := newSerialTestPort(cable)
+
+	// The reply arrives part way through, replacing the bits shifted in so far
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SB, 0xA5)
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x81)
+	assert.Equal([]byte{0xA5}, cable.written)
+
+	stepSerial(sp, ic, 4*SERIAL_CLOCK_CYCLES)
+	assert.Equal(mem.ReadReplace(0x5F), sp.OnRead(NULL_MMU, REG_SERIAL_SB))
+
+	cable.send(0x3C)
+	stepSerial(sp, ic, 4)
+	assert.Equal(mem.ReadReplace(0x53), sp.OnRead(NULL_MMU, REG_SERIAL_SB))
+
+	stepSerial(sp, ic, 4*SERIAL_CLOCK_CYCLES-4)
+	assert.Equal(mem.ReadReplace(0x3C), sp.OnRead(NULL_MMU, REG_SERIAL_SB))
+	assert.Equal(INT_SERIAL, ic.ConsumeRequest())
+
+	// The transfer doesn't complete until the reply's arrived
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SB, 0x11)
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x81)
+
+	stepSerial(sp, ic, 64*SERIAL_CLOCK_CYCLES)
+	assert.Equal(mem.ReadReplace(0xFF), sp.OnRead(NULL_MMU, REG_SERIAL_SB))
+	assert.Equal(INT_NONE, ic.NextRequest())
+	assert.True(sp.ctrl.IsTransferEnabled())
+	assert.Equal(uint(SERIAL_POLL_CYCLES), sp.NextEvent())
+
+	cable.send(0x42)
+	stepSerial(sp, ic, 4)
+	assert.Equal(mem.ReadReplace(0x42), sp.OnRead(NULL_MMU, REG_SERIAL_SB))
+	assert.Equal(INT_SERIAL, ic.NextRequest())
+	assert.Equal(scheduler.NEVER, sp.NextEvent())
+}
+
+func TestSerialExternalClock(t *testing.T) {
+	assert := assert.New(t)
+
+	cable := &clockedCable{master: 0x3C}
+	sp, ic := newSerialTestPort(cable)
+
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SB, 0x42)
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x80)
+	assert.Equal(uint(SERIAL_POLL_CYCLES), sp.NextEvent())
+
+	// Nothing happens until the other end clocks a transfer
+	stepSerial(sp, ic, 64*SERIAL_CLOCK_CYCLES)
+	assert.Equal(mem.ReadReplace(0x42), sp.OnRead(NULL_MMU, REG_SERIAL_SB))
+	assert.Empty(cable.received)
+
+	cable.clocked = true
+	stepSerial(sp, ic, 4)
+	assert.Equal([]byte{0x42}, cable.received)
+	assert.Equal(uint(8*SERIAL_CLOCK_CYCLES-4), sp.NextEvent())
+
+	stepSerial(sp, ic, 8*SERIAL_CLOCK_CYCLES-8)
+	assert.Equal(INT_NONE, ic.NextRequest())
+
+	stepSerial(sp, ic, 4)
+	assert.Equal(mem.ReadReplace(0x3C), sp.OnRead(NULL_MMU, REG_SERIAL_SB))
+	assert.Equal(INT_SERIAL, ic.NextRequest())
+	assert.False(sp.ctrl.IsTransferEnabled())
+}
+
+func TestSerialExternalClockCancel(t *testing.T) {
+	assert := assert.New(t)
+
+	cable := &clockedCable{master: 0x3C}
+	sp, ic := newSerialTestPort(cable)
+
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SB, 0x42)
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x80)
+	stepSerial(sp, ic, 4*SERIAL_CLOCK_CYCLES)
+
+	// Re-writing SC keeps waiting on the other end
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x80)
+	assert.Zero(cable.stopped)
+
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x00)
+	assert.Equal(1, cable.stopped)
+	assert.Equal(scheduler.NEVER, sp.NextEvent())
+
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x00)
+	assert.Equal(1, cable.stopped)
+
+	// Switching to the internal clock stops waiting on the other end too
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x80)
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x81)
+	assert.Equal(2, cable.stopped)
+	assert.Empty(cable.received)
+}
+
+func TestSerialExternalClockNoCable(t *testing.T) {
+	assert := assert.New(t)
+
+	sp, ic := newSerialTestPort(&NullSerialCable{})
+
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SB, 0x42)
+	sp.OnWrite(NULL_MMU, REG_SERIAL_SC, 0x80)
+	assert.Equal(scheduler.NEVER, sp.NextEvent())
+
+	stepSerial(sp, ic, 64*SERIAL_CLOCK_CYCLES)
+	assert.Equal(mem.ReadReplace(0x42), sp.OnRead(NULL_MMU, REG_SERIAL_SB))
+	assert.Equal(INT_NONE, ic.NextRequest())
+	assert.True(sp.ctrl.IsTransferEnabled())
+}
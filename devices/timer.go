@@ -2,8 +2,10 @@ package devices
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
 )
 
 const (
@@ -16,6 +18,16 @@ const (
 
 	TIMER_CLK_EN_MASK  = 1 << 2
 	TIMER_CLK_SEL_MASK = 0x3
+
+	// DIV bit 4, as seen from the internal system counter
+	TIMER_DIV_APU_BIT = 1 << 12
+
+	// TIMER_RELOAD_CYCLES is how long after TIMA overflows until the reload
+	// sequence is over. TIMA reads 0x00 for the first M-cycle, then is reloaded
+	// from TMA (& the interrupt requested) at the start of the second, which
+	// lasts TIMER_RELOADING_CYCLES.
+	TIMER_RELOAD_CYCLES    = 9
+	TIMER_RELOADING_CYCLES = 4
 )
 
 type TimerClockSelector byte
@@ -34,20 +46,44 @@ const (
 	TIMER_TIMA_CLK_256  = 256
 )
 
+// DivAPUListener is notified on every falling edge of DIV bit 4, which is
+// used to clock the APU's frame sequencer
+type DivAPUListener interface {
+	ClockDivAPU()
+}
+
+// Timer is built around the 16-bit system counter, which increments every
+// cycle, & of which DIV is the upper 8 bits. TIMA is incremented on the
+// falling edge of the counter bit selected by TAC (ANDed w/ the enable bit),
+// so anything which makes that signal fall, like resetting DIV or changing
+// TAC, also increments it.
 type Timer struct {
-	divider    uint8
-	counter    uint8
-	modulo     uint8
+	sysCounter uint16
+	counter    uint8 // TIMA
+	modulo     uint8 // TMA
 	incCounter bool
 	freqSel    TimerClockSelector
 
-	counterClk uint
+	// reload counts down the cycles left in the reload sequence after TIMA
+	// overflows, or is 0 if there isn't one
+	reload uint8
+
+	divAPU DivAPUListener
 }
 
+var _ savestate.Versioned = (*Timer)(nil)
+
 func NewTimer() *Timer {
 	return &Timer{}
 }
 
+func (timer *Timer) ConnectDivAPU(listener DivAPUListener) {
+	timer.divAPU = listener
+}
+
+// FreqDivider returns how many cycles there are between each TIMA increment.
+// TIMA is incremented on the falling edge of the system counter bit for half
+// this.
 func (timer *Timer) FreqDivider() uint {
 	switch timer.freqSel {
 	case TIMER_CLK_SEL_CPU_DIV_1024:
@@ -63,42 +99,108 @@ func (timer *Timer) FreqDivider() uint {
 	}
 }
 
-func (timer *Timer) Step(cycles uint8, ic *InterruptController) {
-	timer.divider += cycles
+func (timer *Timer) LoadState(r io.Reader) error {
+	return savestate.Read(r, timer.stateFields()...)
+}
+
+// LoadStateVersion migrates state from before the timer was rebuilt on the
+// system counter (version 0), which kept DIV-APU's clock as a copy of it.
+// TIMA's progress towards its next increment was kept separately, & is now
+// implied by the system counter, so it's dropped.
+func (timer *Timer) LoadStateVersion(r io.Reader, version uint8) error {
+	var (
+		divider    uint8
+		counterClk uint
+	)
+
+	err := savestate.Read(r,
+		&divider,
+		&timer.counter,
+		&timer.modulo,
+		&timer.incCounter,
+		&timer.freqSel,
+		&counterClk,
+		&timer.sysCounter,
+	)
+	timer.reload = 0
+
+	return err
+}
+
+// NextEvent returns how many cycles until TIMA next overflows or is reloaded,
+// or DIV-APU is next clocked, whichever is sooner
+func (timer *Timer) NextEvent() uint {
+	// DIV bit 4 falls every time the system counter passes a multiple of twice it
+	next := uint(TIMER_DIV_APU_BIT*2 - uint(timer.sysCounter)%(TIMER_DIV_APU_BIT*2))
+
+	if timer.isReloadPending() {
+		next = min(next, uint(timer.reload-TIMER_RELOADING_CYCLES))
+	}
 
-	if !timer.incCounter {
-		return
+	if timer.incCounter {
+		freq := timer.FreqDivider()
+		overflow := timer.cyclesUntilTick() + uint(0xFF-timer.counter)*freq
+		next = min(next, overflow)
 	}
 
-	timer.counterClk += uint(cycles)
-	requestInt := false
+	return next
+}
 
-	for timer.counterClk >= timer.FreqDivider() {
-		timer.counterClk -= timer.FreqDivider()
+func (timer *Timer) SaveState(w io.Writer) error {
+	return savestate.Write(w, timer.stateFields()...)
+}
 
-		if timer.counter == 0xFF {
-			timer.counter = timer.modulo
-			requestInt = true
-		} else {
-			timer.counter += 1
+func (timer *Timer) StateVersion() uint8 {
+	return 1
+}
+
+func (timer *Timer) Step(cycles uint8, ic *InterruptController) {
+	remaining := uint(cycles)
+
+	for remaining > 0 {
+		// Stop at anything that changes TIMA, so there's at most one per step
+		step := remaining
+
+		if timer.isReloadPending() {
+			step = min(step, uint(timer.reload-TIMER_RELOADING_CYCLES))
 		}
-	}
 
-	if requestInt {
-		ic.RequestTimer()
+		if timer.incCounter {
+			step = min(step, timer.cyclesUntilTick())
+		}
+
+		if timer.reload > 0 {
+			timer.reload -= uint8(min(step, uint(timer.reload)))
+
+			// TIMA's reloaded at the start of the M-cycle after it overflowed
+			if timer.reload == TIMER_RELOADING_CYCLES {
+				timer.counter = timer.modulo
+				ic.RequestTimer()
+			}
+		}
+
+		timer.advance(step)
+		remaining -= step
 	}
 }
 
+// SystemCounter returns the internal 16-bit counter which drives DIV, TIMA &
+// the APU's frame sequencer
+func (timer *Timer) SystemCounter() uint16 {
+	return timer.sysCounter
+}
+
 func (timer *Timer) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 	switch addr {
 	case REG_TIMER_DIV:
-		return mem.ReadReplace(timer.divider)
+		return mem.ReadReplace(uint8(timer.sysCounter >> 8))
 	case REG_TIMER_TIMA:
 		return mem.ReadReplace(timer.counter)
 	case REG_TIMER_TMA:
 		return mem.ReadReplace(timer.modulo)
 	case REG_TIMER_TAC:
-		tac := byte(timer.freqSel)
+		// The upper 5 bits are unused, & always read as 1
+		tac := byte(timer.freqSel) | 0xF8
 
 		if timer.incCounter {
 			tac |= TIMER_CLK_EN_MASK
@@ -113,19 +215,33 @@ func (timer *Timer) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 func (timer *Timer) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 	switch addr {
 	case REG_TIMER_DIV:
-		timer.divider = 0
+		timer.ResetDiv()
 	case REG_TIMER_TIMA:
-		timer.counter = value
+		switch {
+		case timer.isReloadPending():
+			// Writing TIMA before it's reloaded cancels the reload, & the interrupt
+			timer.reload = 0
+			timer.counter = value
+		case timer.isReloading():
+			// TIMA's being loaded from TMA, which wins
+		default:
+			timer.counter = value
+		}
 	case REG_TIMER_TMA:
 		timer.modulo = value
+
+		// TMA is still being copied to TIMA while it's reloaded
+		if timer.isReloading() {
+			timer.counter = value
+		}
 	case REG_TIMER_TAC:
-		enableCounter := (value & TIMER_CLK_EN_MASK) == TIMER_CLK_EN_MASK
+		wasHigh := timer.timerSignal()
+
+		timer.incCounter = (value & TIMER_CLK_EN_MASK) == TIMER_CLK_EN_MASK
+		timer.freqSel = TimerClockSelector(value & TIMER_CLK_SEL_MASK)
 
-		if enableCounter && !timer.incCounter {
-			timer.incCounter = true
-			timer.freqSel = TimerClockSelector(value & TIMER_CLK_SEL_MASK)
-		} else {
-			timer.incCounter = enableCounter
+		if wasHigh && !timer.timerSignal() {
+			timer.incrementTIMA()
 		}
 	default:
 		panic(fmt.Sprintf("Attempting to write 0x%02X @ 0x%04X, which is out-of-bounds for timer", value, addr))
@@ -133,3 +249,88 @@ func (timer *Timer) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite
 
 	return mem.WriteBlock()
 }
+
+// ResetDiv resets the system counter, either as DIV's written, or when STOP
+// is executed. Any of its bits which were set fall, which can clock TIMA & the
+// APU.
+func (timer *Timer) ResetDiv() {
+	if timer.timerSignal() {
+		timer.incrementTIMA()
+	}
+
+	if (timer.sysCounter & TIMER_DIV_APU_BIT) != 0 {
+		timer.clockDivAPU()
+	}
+
+	timer.sysCounter = 0
+}
+
+// advance moves the system counter forward by cycles, clocking TIMA & the APU
+// on each falling edge
+func (timer *Timer) advance(cycles uint) {
+	prev := uint(timer.sysCounter)
+	next := prev + cycles
+	timer.sysCounter = uint16(next)
+
+	// A bit falls whenever the counter reaches a multiple of twice it
+	for range next/(TIMER_DIV_APU_BIT*2) - prev/(TIMER_DIV_APU_BIT*2) {
+		timer.clockDivAPU()
+	}
+
+	if timer.incCounter {
+		freq := timer.FreqDivider()
+		for range next/freq - prev/freq {
+			timer.incrementTIMA()
+		}
+	}
+}
+
+func (timer *Timer) clockDivAPU() {
+	if timer.divAPU != nil {
+		timer.divAPU.ClockDivAPU()
+	}
+}
+
+// cyclesUntilTick returns how many cycles until the selected system counter
+// bit next falls
+func (timer *Timer) cyclesUntilTick() uint {
+	freq := timer.FreqDivider()
+
+	return freq - uint(timer.sysCounter)%freq
+}
+
+func (timer *Timer) incrementTIMA() {
+	if timer.counter == 0xFF {
+		// TIMA reads 0x00 until it's reloaded
+		timer.counter = 0x00
+		timer.reload = TIMER_RELOAD_CYCLES
+	} else {
+		timer.counter += 1
+	}
+}
+
+// isReloadPending returns whether TIMA has overflowed, but not been reloaded
+func (timer *Timer) isReloadPending() bool {
+	return timer.reload > TIMER_RELOADING_CYCLES
+}
+
+// isReloading returns whether it's the M-cycle in which TIMA is reloaded
+func (timer *Timer) isReloading() bool {
+	return timer.reload > 0 && timer.reload <= TIMER_RELOADING_CYCLES
+}
+
+func (timer *Timer) stateFields() []any {
+	return []any{
+		&timer.sysCounter,
+		&timer.counter,
+		&timer.modulo,
+		&timer.incCounter,
+		&timer.freqSel,
+		&timer.reload,
+	}
+}
+
+// timerSignal returns the input to TIMA's falling edge detector
+func (timer *Timer) timerSignal() bool {
+	return timer.incCounter && uint(timer.sysCounter)&(timer.FreqDivider()/2) != 0
+}
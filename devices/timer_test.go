@@ -1,10 +1,13 @@
 package devices
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var NULL_MMU = mem.NewMMU([]byte{})
@@ -13,14 +16,20 @@ func TestTimerDivider(t *testing.T) {
 	assert := assert.New(t)
 
 	timer := NewTimer()
-	assert.Equal(uint8(0), timer.divider)
+	assert.Equal(mem.ReadReplace(0), timer.OnRead(NULL_MMU, REG_TIMER_DIV))
 
-	timer.Step(12, &InterruptController{})
-	assert.Equal(uint8(12), timer.divider)
+	// DIV is the upper byte of the system counter
+	timer.Step(255, &InterruptController{})
+	assert.Equal(mem.ReadReplace(0), timer.OnRead(NULL_MMU, REG_TIMER_DIV))
+
+	timer.Step(1, &InterruptController{})
+	assert.Equal(mem.ReadReplace(1), timer.OnRead(NULL_MMU, REG_TIMER_DIV))
+	assert.Equal(uint16(256), timer.SystemCounter())
 
 	op := timer.OnWrite(NULL_MMU, REG_TIMER_DIV, 0xFF)
 	assert.Equal(mem.WriteBlock(), op)
-	assert.Equal(uint8(0), timer.divider)
+	assert.Equal(mem.ReadReplace(0), timer.OnRead(NULL_MMU, REG_TIMER_DIV))
+	assert.Equal(uint16(0), timer.SystemCounter())
 }
 
 func TestTimerTIMA(t *testing.T) {
@@ -105,7 +114,156 @@ func TestTimerInterrupt(t *testing.T) {
 	assert.Equal(uint8(0xFF), timer.counter)
 	assert.Equal(INT_NONE, ic.NextRequest())
 
+	timer.OnWrite(NULL_MMU, REG_TIMER_TMA, 0xAB)
+
+	// TIMA reads 0x00 for an M-cycle after overflowing, before it's reloaded
 	timer.Step(16, ic)
 	assert.Equal(uint8(0x0), timer.counter)
+	assert.Equal(INT_NONE, ic.NextRequest())
+
+	timer.Step(4, ic)
+	assert.Equal(uint8(0x0), timer.counter)
+	assert.Equal(INT_NONE, ic.NextRequest())
+
+	timer.Step(1, ic)
+	assert.Equal(uint8(0xAB), timer.counter)
 	assert.Equal(INT_TIMER, ic.NextRequest())
 }
+
+func TestTimerNextEvent(t *testing.T) {
+	assert := assert.New(t)
+
+	timer := NewTimer()
+	ic := &InterruptController{}
+	ic.OnWrite(NULL_MMU, REG_IE, 0xFF)
+
+	// Only DIV-APU while TIMA is disabled
+	assert.Equal(uint(TIMER_DIV_APU_BIT*2), timer.NextEvent())
+
+	timer.OnWrite(NULL_MMU, REG_TIMER_TIMA, 0xF0)
+	timer.OnWrite(NULL_MMU, REG_TIMER_TAC, 0x05)
+	assert.Equal(uint(16*16), timer.NextEvent())
+
+	timer.Step(255, ic)
+	assert.Equal(uint(1), timer.NextEvent())
+	assert.Equal(INT_NONE, ic.NextRequest())
+
+	// The interrupt isn't requested until TIMA's reloaded
+	timer.Step(1, ic)
+	assert.Equal(uint(5), timer.NextEvent())
+	assert.Equal(INT_NONE, ic.NextRequest())
+
+	timer.Step(4, ic)
+	assert.Equal(uint(1), timer.NextEvent())
+	assert.Equal(INT_NONE, ic.NextRequest())
+
+	timer.Step(1, ic)
+	assert.Equal(INT_TIMER, ic.NextRequest())
+}
+
+func TestTimerSpuriousTicks(t *testing.T) {
+	testCases := []struct {
+		name     string
+		counter  uint16
+		tac      byte
+		write    uint16
+		value    byte
+		expected uint8
+	}{
+		{name: "DIV reset w/ bit high", counter: 0x08, tac: 0x05, write: REG_TIMER_DIV, expected: 1},
+		{name: "DIV reset w/ bit low", counter: 0x07, tac: 0x05, write: REG_TIMER_DIV, expected: 0},
+		{name: "DIV reset w/ timer disabled", counter: 0x08, tac: 0x01, write: REG_TIMER_DIV, expected: 0},
+		{name: "TAC disabled w/ bit high", counter: 0x08, tac: 0x05, write: REG_TIMER_TAC, value: 0x01, expected: 1},
+		{name: "TAC disabled w/ bit low", counter: 0x07, tac: 0x05, write: REG_TIMER_TAC, value: 0x01, expected: 0},
+		{name: "TAC to low bit from high", counter: 0x08, tac: 0x05, write: REG_TIMER_TAC, value: 0x06, expected: 1},
+		{name: "TAC to high bit from low", counter: 0x20, tac: 0x05, write: REG_TIMER_TAC, value: 0x06, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			timer := NewTimer()
+			ic := &InterruptController{}
+
+			timer.Step(uint8(tc.counter), ic)
+			timer.OnWrite(NULL_MMU, REG_TIMER_TAC, tc.tac)
+			timer.OnWrite(NULL_MMU, REG_TIMER_TIMA, 0)
+
+			timer.OnWrite(NULL_MMU, tc.write, tc.value)
+			assert.Equal(mem.ReadReplace(tc.expected), timer.OnRead(NULL_MMU, REG_TIMER_TIMA))
+		})
+	}
+}
+
+func TestTimerReloadWrites(t *testing.T) {
+	testCases := []struct {
+		name      string
+		delay     uint8
+		write     uint16
+		value     byte
+		tima      uint8
+		interrupt IRQ
+	}{
+		// Before the reload, TIMA writes cancel it
+		{name: "TIMA before reload", delay: 4, write: REG_TIMER_TIMA, value: 0x12, tima: 0x12, interrupt: INT_NONE},
+		{name: "TMA before reload", delay: 4, write: REG_TIMER_TMA, value: 0x34, tima: 0x34, interrupt: INT_TIMER},
+		// While reloading, TIMA writes are ignored, but TMA writes go through
+		{name: "TIMA while reloading", delay: 8, write: REG_TIMER_TIMA, value: 0x12, tima: 0xAB, interrupt: INT_TIMER},
+		{name: "TMA while reloading", delay: 8, write: REG_TIMER_TMA, value: 0x34, tima: 0x34, interrupt: INT_TIMER},
+		// Afterwards, it's all back to normal
+		{name: "TIMA after reload", delay: 12, write: REG_TIMER_TIMA, value: 0x12, tima: 0x12, interrupt: INT_TIMER},
+		{name: "TMA after reload", delay: 12, write: REG_TIMER_TMA, value: 0x34, tima: 0xAB, interrupt: INT_TIMER},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			timer := NewTimer()
+			ic := &InterruptController{}
+			ic.OnWrite(NULL_MMU, REG_IE, 0xFF)
+
+			timer.OnWrite(NULL_MMU, REG_TIMER_TIMA, 0xFF)
+			timer.OnWrite(NULL_MMU, REG_TIMER_TMA, 0xAB)
+			timer.OnWrite(NULL_MMU, REG_TIMER_TAC, 0x05)
+
+			// Overflow, then write at the end of an M-cycle, like the CPU does
+			timer.Step(16, ic)
+			timer.Step(tc.delay, ic)
+			timer.OnWrite(NULL_MMU, tc.write, tc.value)
+			timer.Step(3, ic)
+
+			assert.Equal(mem.ReadReplace(tc.tima), timer.OnRead(NULL_MMU, REG_TIMER_TIMA))
+			assert.Equal(tc.interrupt, ic.NextRequest())
+		})
+	}
+}
+
+func TestTimerLoadStateVersion0(t *testing.T) {
+	assert := assert.New(t)
+
+	// divider, TIMA, TMA, enabled, TAC clock select, TIMA clock & DIV-APU clock
+	var state bytes.Buffer
+	require.NoError(t, savestate.Write(&state,
+		uint8(0x34),
+		uint8(0xAB),
+		uint8(0xCD),
+		true,
+		TIMER_CLK_SEL_CPU_DIV_16,
+		uint64(5),
+		uint16(0x1234),
+	))
+
+	timer := NewTimer()
+	timer.reload = TIMER_RELOAD_CYCLES
+	require.NoError(t, timer.LoadStateVersion(&state, 0))
+	assert.Zero(state.Len())
+
+	assert.Equal(uint16(0x1234), timer.SystemCounter())
+	assert.Equal(mem.ReadReplace(0x12), timer.OnRead(NULL_MMU, REG_TIMER_DIV))
+	assert.Equal(mem.ReadReplace(0xAB), timer.OnRead(NULL_MMU, REG_TIMER_TIMA))
+	assert.Equal(mem.ReadReplace(0xCD), timer.OnRead(NULL_MMU, REG_TIMER_TMA))
+	assert.Equal(mem.ReadReplace(0xFD), timer.OnRead(NULL_MMU, REG_TIMER_TAC))
+	assert.False(timer.isReloadPending())
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"image"
 	"io"
+	"time"
 
+	"github.com/maxfierke/gogo-gb/apu"
 	"github.com/maxfierke/gogo-gb/cart"
 	"github.com/maxfierke/gogo-gb/cpu"
 	"github.com/maxfierke/gogo-gb/debug"
@@ -13,6 +15,7 @@ import (
 	"github.com/maxfierke/gogo-gb/mem"
 	"github.com/maxfierke/gogo-gb/ppu"
 	"github.com/maxfierke/gogo-gb/ppu/rendering"
+	"github.com/maxfierke/gogo-gb/scheduler"
 )
 
 const (
@@ -21,6 +24,8 @@ const (
 
 type CGB struct {
 	// Components
+	apu       *apu.APU
+	bootROM   *devices.CGBBootROM
 	cpu       *cpu.CPU
 	mmu       *mem.MMU
 	cartridge *cart.Cartridge
@@ -36,6 +41,7 @@ type CGB struct {
 	// Non-components
 	debugger        debug.Debugger
 	debuggerHandler mem.MemHandlerHandle
+	scheduler       *scheduler.Scheduler
 }
 
 var _ Console = (*CGB)(nil)
@@ -60,6 +66,8 @@ func NewCGB(opts ...ConsoleOption) (*CGB, error) {
 	ic := devices.NewInterruptController()
 
 	cgb := &CGB{
+		apu:       apu.NewAPU(),
+		bootROM:   devices.NewCGBBootROM(),
 		cpu:       cgbCpu,
 		mmu:       mmu,
 		cartridge: cart.NewCartridge(),
@@ -81,34 +89,60 @@ func NewCGB(opts ...ConsoleOption) (*CGB, error) {
 		}
 	}
 
+	cgb.apu.EnableColor()
+	cgb.dma.EnableColor()
 	cgb.ppu.EnableColor()
+	cgb.serial.EnableColor()
 	cgb.ppu.ConnectHDMA(cgb.hdma)
-
-	mmu.AddHandler(mem.MemRegion{Start: 0x0000, End: 0x7FFF}, cgb.cartridge) // MBCs ROM Banks
-	mmu.AddHandler(mem.MemRegion{Start: 0xA000, End: 0xBFFF}, cgb.cartridge) // MBCs RAM Banks
+	cgb.timer.ConnectDivAPU(cgb.apu)
+	cgb.cpu.ConnectTicker(cgb.tick)
+	cgb.cpu.ConnectStop(cgb.stop)
+
+	// Everything but the CPU & APU is only stepped when it has something to do,
+	// or its registers are accessed
+	cgb.scheduler = scheduler.NewScheduler()
+	cartridgeTask := cgb.scheduler.AddTask(cgb.cartridge.Step, cgb.cartridge.NextEvent)
+	dmaTask := cgb.scheduler.AddTask(func(cycles uint8) { cgb.dma.Step(mmu, cycles) }, cgb.dma.NextEvent)
+	ppuTask := cgb.scheduler.AddTask(func(cycles uint8) { cgb.ppu.Step(mmu, cycles) }, cgb.ppu.NextEvent)
+	serialTask := cgb.scheduler.AddTask(func(cycles uint8) { cgb.serial.Step(cycles, ic) }, cgb.serial.NextEvent)
+	timerTask := cgb.scheduler.AddTask(func(cycles uint8) { cgb.timer.Step(cycles, ic) }, cgb.timer.NextEvent)
+
+	syncedCartROM := cgb.scheduler.WriteHandler(cartridgeTask, cgb.cartridge)
+	syncedCartRAM := cgb.scheduler.Handler(cartridgeTask, cgb.cartridge)
+	syncedDMA := cgb.scheduler.Handler(dmaTask, cgb.dma)
+	syncedPPU := cgb.scheduler.Handler(ppuTask, cgb.ppu)
+	syncedSerial := cgb.scheduler.Handler(serialTask, cgb.serial)
+	syncedTimer := cgb.scheduler.Handler(timerTask, cgb.timer)
+
+	// OAM DMA sits in front of everything it can conflict with while copying
+	cgb.dma.AttachMemHandlers(mmu)
+
+	mmu.AddHandler(mem.MemRegion{Start: 0x0000, End: 0x7FFF}, syncedCartROM) // MBCs ROM Banks
+	mmu.AddHandler(mem.MemRegion{Start: 0xA000, End: 0xBFFF}, syncedCartRAM) // MBCs RAM Banks
 
 	mmu.AddHandler(mem.MemRegion{Start: 0xC000, End: 0xDFFF}, cgb.wram) // WRAM Banks
 
 	mmu.AddHandler(mem.MemRegion{Start: 0xE000, End: 0xFDFF}, echo)     // Echo RAM (mirrors WRAM)
 	mmu.AddHandler(mem.MemRegion{Start: 0xFEA0, End: 0xFEFF}, unmapped) // Nop writes, zero reads
 
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF00, End: 0xFF00}, cgb.joypad) // Joypad
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF01, End: 0xFF02}, cgb.serial) // Serial Port (Control & Data)
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF04, End: 0xFF07}, cgb.timer)  // Timer (not RTC)
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF40, End: 0xFF41}, cgb.ppu)    // LCD status, control registers
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF42, End: 0xFF45}, cgb.ppu)    // PPU registers
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF46, End: 0xFF46}, cgb.dma)    // DMA
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF47, End: 0xFF4B}, cgb.ppu)    // PPU registers
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF00, End: 0xFF00}, cgb.joypad)   // Joypad
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF01, End: 0xFF02}, syncedSerial) // Serial Port (Control & Data)
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF04, End: 0xFF07}, syncedTimer)  // Timer (not RTC)
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF10, End: 0xFF3F}, cgb.apu)      // APU registers & wave RAM
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF40, End: 0xFF41}, syncedPPU)    // LCD status, control registers
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF42, End: 0xFF45}, syncedPPU)    // PPU registers
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF46, End: 0xFF46}, syncedDMA)    // DMA
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF47, End: 0xFF4B}, syncedPPU)    // PPU registers
 
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF4C, End: 0xFF4C}, cgb.ppu)  // DMG Mode (during Boot ROM)
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF4F, End: 0xFF4F}, cgb.ppu)  // VRAM Bank Select
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF51, End: 0xFF55}, cgb.hdma) // VRAM DMA
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF4C, End: 0xFF4C}, syncedPPU) // DMG Mode (during Boot ROM)
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF4F, End: 0xFF4F}, syncedPPU) // VRAM Bank Select
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF51, End: 0xFF55}, cgb.hdma)  // VRAM DMA
 
 	mmu.AddHandler(mem.MemRegion{Start: 0xFF56, End: 0xFF56}, unmapped) // IR Port
 
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF68, End: 0xFF6B}, cgb.ppu)  // BG/OBJ Palettes
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF6C, End: 0xFF6C}, cgb.ppu)  // OBJ Priority Mode
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF70, End: 0xFF70}, cgb.wram) // WRAM Bank Select
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF68, End: 0xFF6B}, syncedPPU) // BG/OBJ Palettes
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF6C, End: 0xFF6C}, syncedPPU) // OBJ Priority Mode
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF70, End: 0xFF70}, cgb.wram)  // WRAM Bank Select
 
 	mmu.AddHandler(mem.MemRegion{Start: 0xFF72, End: 0xFF73}, unmapped) // Unknown, should be R/W on CGB
 	mmu.AddHandler(mem.MemRegion{Start: 0xFF74, End: 0xFF74}, unmapped) // Unknown, should be R/W on CGB
@@ -116,8 +150,8 @@ func NewCGB(opts ...ConsoleOption) (*CGB, error) {
 
 	mmu.AddHandler(mem.MemRegion{Start: 0xFF4D, End: 0xFF4D}, cgb.cpu) // CPU Speed Switch
 
-	mmu.AddHandler(mem.MemRegion{Start: 0x8000, End: 0x9FFF}, cgb.ppu) // VRAM tiles
-	mmu.AddHandler(mem.MemRegion{Start: 0xFE00, End: 0xFE9F}, cgb.ppu) // OAM
+	mmu.AddHandler(mem.MemRegion{Start: 0x8000, End: 0x9FFF}, syncedPPU) // VRAM tiles
+	mmu.AddHandler(mem.MemRegion{Start: 0xFE00, End: 0xFE9F}, syncedPPU) // OAM
 
 	mmu.AddHandler(mem.MemRegion{Start: 0xFF0F, End: 0xFF0F}, cgb.ic) // Interrupts Requested
 	mmu.AddHandler(mem.MemRegion{Start: 0xFFFF, End: 0xFFFF}, cgb.ic) // Interrupts Enabled
@@ -125,6 +159,10 @@ func NewCGB(opts ...ConsoleOption) (*CGB, error) {
 	return cgb, nil
 }
 
+func (cgb *CGB) AttachAudioOutput(output devices.AudioOutput) {
+	cgb.apu.AttachOutput(output)
+}
+
 func (cgb *CGB) AttachCable(cable devices.SerialCable) {
 	cgb.serial.AttachCable(cable)
 }
@@ -175,6 +213,8 @@ func (cgb *CGB) LoadCartridge(r io.Reader) error {
 }
 
 func (cgb *CGB) Draw() image.Image {
+	cgb.scheduler.SyncAll()
+
 	return cgb.ppu.Draw()
 }
 
@@ -188,6 +228,8 @@ func (cgb *CGB) LoadSave(r io.Reader) error {
 }
 
 func (cgb *CGB) Save(w io.Writer) error {
+	cgb.scheduler.SyncAll()
+
 	err := cgb.cartridge.Save(w)
 	if err != nil {
 		return fmt.Errorf("writing save: %w", err)
@@ -196,11 +238,42 @@ func (cgb *CGB) Save(w io.Writer) error {
 	return nil
 }
 
+// LoadState restores a snapshot of the entire machine, as written by SaveState.
+// The same cartridge (and boot ROM, if any) must already be loaded.
+func (cgb *CGB) LoadState(r io.Reader) error {
+	err := loadState(r, cgb.stateComponents())
+
+	// Everything's synced up before saving, so can pick up from now
+	cgb.scheduler.Reset()
+
+	return err
+}
+
+func (cgb *CGB) SaveState(w io.Writer) error {
+	cgb.scheduler.SyncAll()
+
+	return saveState(w, cgb.stateComponents())
+}
+
+func (cgb *CGB) Model() ConsoleModel {
+	return ConsoleModelCGB
+}
+
 func (cgb *CGB) ReceiveInputs(inputs devices.JoypadInputs) {
 	cgb.joypad.ReceiveInputs(inputs)
 }
 
 func (cgb *CGB) Step() (uint8, error) {
+	if cgb.cpu.IsStopped() {
+		if !cgb.joypad.IsAnyLineLow() {
+			// The system clock is stopped, so nothing advances until it's woken up
+			return 4, nil
+		}
+
+		cgb.cpu.ExitStop()
+		cgb.ppu.SetStopped(false)
+	}
+
 	cgb.debugger.OnDecode(cgb.cpu, cgb.mmu)
 
 	var cycles uint8
@@ -208,8 +281,19 @@ func (cgb *CGB) Step() (uint8, error) {
 
 	haltedPriorToExecute := cgb.cpu.IsHalted()
 
-	if cgb.hdma.IsActive(ppu.HDMA_MODE_GENERAL) {
-		cgb.hdma.Step(cgb.mmu)
+	if haltedPriorToExecute {
+		cgb.hdma.SkipHBlank()
+	}
+
+	if cgb.hdma.IsTransferring() {
+		// The CPU is stalled while each block is copied, so can't take an
+		// interrupt until the last one's done
+		cycles = cgb.hdma.Step(cgb.mmu, cgb.cpu.IsDoubleSpeed())
+		cgb.tick(cycles)
+
+		if cgb.hdma.IsTransferring() {
+			return cycles, nil
+		}
 	} else {
 		cycles, err = cgb.cpu.Step(cgb.mmu)
 		if err != nil {
@@ -231,17 +315,60 @@ func (cgb *CGB) Step() (uint8, error) {
 		cgb.debugger.OnInterrupt(cgb.cpu, cgb.mmu)
 	}
 
-	cgb.cartridge.Step(cycles)
-	cgb.dma.Step(cgb.mmu, cycles)
-	cgb.ppu.Step(cgb.mmu, cycles)
-	cgb.timer.Step(cycles, cgb.ic)
-	cgb.serial.Step(cycles, cgb.ic)
-
 	return cycles, nil
 }
 
+// UseEmulatedClock makes the cartridge's RTC, if any, follow emulated time
+// from seed, so that emulation is fully deterministic
+func (cgb *CGB) UseEmulatedClock(seed time.Time) {
+	cgb.cartridge.UseEmulatedClock(seed)
+}
+
 func (cgb *CGB) detachDebugger() {
 	// Remove any existing handlers
 	cgb.mmu.RemoveHandler(cgb.debuggerHandler)
 	cgb.debugger = debug.NewNullDebugger()
 }
+
+// stateComponents lists every component included in save states, in the order
+// they're saved & restored
+func (cgb *CGB) stateComponents() []stateComponent {
+	return []stateComponent{
+		{name: "cpu", component: cgb.cpu},
+		{name: "mmu", component: cgb.mmu},
+		{name: "boot_rom", component: cgb.bootROM},
+		{name: "cartridge", component: cgb.cartridge},
+		{name: "dma", component: cgb.dma},
+		{name: "hdma", component: cgb.hdma},
+		{name: "interrupts", component: cgb.ic},
+		{name: "joypad", component: cgb.joypad},
+		{name: "ppu", component: cgb.ppu},
+		{name: "serial", component: cgb.serial},
+		{name: "timer", component: cgb.timer},
+		{name: "apu", component: cgb.apu},
+		{name: "wram", component: cgb.wram},
+	}
+}
+
+// stop is called after the CPU executes STOP, which resets DIV, & either
+// stops the system, or switches speed
+func (cgb *CGB) stop() {
+	// Everything's caught up at the old speed, before switching to the new one
+	cgb.scheduler.SyncAll()
+	cgb.apu.SetDoubleSpeed(cgb.cpu.IsDoubleSpeed())
+	cgb.ppu.SetDoubleSpeed(cgb.cpu.IsDoubleSpeed())
+	cgb.cartridge.SetDoubleSpeed(cgb.cpu.IsDoubleSpeed())
+	cgb.timer.ResetDiv()
+	cgb.ppu.SetStopped(cgb.cpu.IsStopped())
+
+	// Everything's been changed outside of their handlers, so reschedule it all
+	cgb.scheduler.Reset()
+}
+
+// tick advances everything but the CPU by cycles. The CPU calls this as it
+// accesses memory, so that each access sees the rest of the system as it
+// would be at that point in the instruction.
+func (cgb *CGB) tick(cycles uint8) {
+	cgb.scheduler.Advance(cycles)
+	cgb.apu.Step(cycles)
+}
@@ -1,18 +1,25 @@
 package hardware
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"image"
+	"image/png"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/maxfierke/gogo-gb/cart"
 	"github.com/maxfierke/gogo-gb/debug"
 	"github.com/maxfierke/gogo-gb/devices"
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/ppu"
+	"github.com/maxfierke/gogo-gb/savestate"
 )
 
 type Console interface {
+	AttachAudioOutput(output devices.AudioOutput)
 	AttachCable(cable devices.SerialCable)
 	AttachDebugger(debugger debug.Debugger)
 	SetupDebugger()
@@ -21,10 +28,14 @@ type Console interface {
 	CartridgeHeader() cart.Header
 	CyclesPerFrame() uint
 	LoadCartridge(r io.Reader) error
+	Model() ConsoleModel
 	Save(w io.Writer) error
 	LoadSave(r io.Reader) error
+	SaveState(w io.Writer) error
+	LoadState(r io.Reader) error
 	Step() (uint8, error)
 	ReceiveInputs(inputs devices.JoypadInputs)
+	UseEmulatedClock(seed time.Time)
 }
 
 type ConsoleOption func(console Console, mmu *mem.MMU) error
@@ -37,15 +48,21 @@ const (
 
 type ConsoleModel string
 
+var (
+	ErrStateCartridgeMismatch = errors.New("save state is for a different cartridge")
+	ErrStateModelMismatch     = errors.New("save state is for a different console model")
+	ErrUnsupportedWhileLinked = errors.New("not supported while linked to another console")
+)
+
 func WithBootROM(r io.Reader) ConsoleOption {
 	return func(console Console, mmu *mem.MMU) error {
 		var bootROM devices.BootROM
 
-		switch console.(type) {
+		switch c := console.(type) {
 		case *DMG:
-			bootROM = devices.NewDMGBootROM()
+			bootROM = c.bootROM
 		case *CGB:
-			bootROM = devices.NewCGBBootROM()
+			bootROM = c.bootROM
 		default:
 			return errors.New("unrecognized console")
 		}
@@ -80,6 +97,22 @@ func WithFakeBootROM() ConsoleOption {
 	}
 }
 
+// WithRenderer replaces the console's default (scanline) renderer
+func WithRenderer(renderer ppu.RendererConstructor) ConsoleOption {
+	return func(console Console, mmu *mem.MMU) error {
+		switch c := console.(type) {
+		case *DMG:
+			c.ppu.UseRenderer(renderer)
+		case *CGB:
+			c.ppu.UseRenderer(renderer)
+		default:
+			return errors.New("unrecognized console")
+		}
+
+		return nil
+	}
+}
+
 func NewConsole(model ConsoleModel, opts ...ConsoleOption) (Console, error) {
 	switch model {
 	case ConsoleModelDMG:
@@ -101,20 +134,125 @@ func NewConsole(model ConsoleModel, opts ...ConsoleOption) (Console, error) {
 	}
 }
 
-func Run(console Console, host devices.HostInterface) error {
+// FrameHook is used by hosts that need to access the console between frames,
+// e.g. to snapshot or restore it, & is given to Run w/ WithFrameHook.
+// BeforeFrame is called from Run ahead of each frame, so it never races
+// emulation or joypad input. If it returns false, the frame is not emulated &
+// the console's current screen is drawn as-is, e.g. because the console was
+// just restored to an earlier frame.
+type FrameHook interface {
+	BeforeFrame(console Console) (bool, error)
+}
+
+// InputRecorder receives the inputs applied for each frame emulated by Run,
+// along w/ the frame drawn
+type InputRecorder interface {
+	RecordFrame(inputs devices.JoypadInputs, frame image.Image) error
+}
+
+// InputPlayer supplies the inputs for each frame emulated by Run, in place of
+// the host's. NextFrame returns io.EOF once it runs out of frames, after which
+// the host's inputs are used again. VerifyFrame returns an error if a frame
+// doesn't match what was expected.
+type InputPlayer interface {
+	NextFrame() (devices.JoypadInputs, error)
+	VerifyFrame(frame image.Image) error
+}
+
+type RunOption func(options *runOptions)
+
+type runOptions struct {
+	frameHook FrameHook
+	linked    *linkedConsole
+	player    InputPlayer
+	recorder  InputRecorder
+}
+
+func WithFrameHook(hook FrameHook) RunOption {
+	return func(options *runOptions) {
+		options.frameHook = hook
+	}
+}
+
+func WithInputPlayer(player InputPlayer) RunOption {
+	return func(options *runOptions) {
+		options.player = player
+	}
+}
+
+func WithInputRecorder(recorder InputRecorder) RunOption {
+	return func(options *runOptions) {
+		options.recorder = recorder
+	}
+}
+
+// WithLinkedConsole runs the console linked to another, as w/ RunLinked. Input
+// playback, recording & frame hooks aren't supported while linked, so Run
+// returns ErrUnsupportedWhileLinked if given any of them too.
+func WithLinkedConsole(console Console, host devices.HostInterface) RunOption {
+	return func(options *runOptions) {
+		options.linked = &linkedConsole{console: console, host: host}
+	}
+}
+
+func Run(console Console, host devices.HostInterface, opts ...RunOption) error {
+	var options runOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.linked != nil {
+		if err := options.checkLinkable(); err != nil {
+			return err
+		}
+
+		return RunLinked(console, options.linked.console, host, options.linked.host)
+	}
+
 	framebuffer := host.Framebuffer()
 	defer close(framebuffer)
 
+	console.AttachAudioOutput(host.AudioOutput())
 	console.AttachCable(host.SerialCable())
 	console.SetupDebugger()
 
-	go func() {
-		for inputs := range host.JoypadInput() {
-			console.ReceiveInputs(inputs)
-		}
-	}()
+	hostInputs := host.JoypadInput()
+	player := options.player
+
+	var inputs devices.JoypadInputs
 
 	for range host.RequestFrame() {
+		inputs = latestInputs(inputs, hostInputs)
+
+		if player != nil {
+			playerInputs, err := player.NextFrame()
+			if errors.Is(err, io.EOF) {
+				host.Log("input playback finished, resuming host inputs")
+				player = nil
+			} else if err != nil {
+				return fmt.Errorf("playing back inputs: %w", err)
+			} else {
+				inputs = playerInputs
+			}
+		}
+
+		// Inputs are applied once at the start of every frame, whether they've
+		// changed or not, so that recorded frames play back identically
+		console.ReceiveInputs(inputs)
+
+		if options.frameHook != nil {
+			runFrame, err := options.frameHook.BeforeFrame(console)
+			if err != nil {
+				return err
+			}
+
+			if !runFrame {
+				framebuffer <- console.Draw()
+
+				continue
+			}
+		}
+
 		var frameCycles uint
 		for frameCycles < console.CyclesPerFrame() {
 			cycles, err := console.Step()
@@ -124,7 +262,223 @@ func Run(console Console, host devices.HostInterface) error {
 			frameCycles += uint(cycles)
 		}
 
-		framebuffer <- console.Draw()
+		frame := console.Draw()
+
+		if options.recorder != nil {
+			if err := options.recorder.RecordFrame(inputs, frame); err != nil {
+				return fmt.Errorf("recording inputs: %w", err)
+			}
+		}
+
+		if player != nil {
+			if err := player.VerifyFrame(frame); err != nil {
+				host.LogWarn("%v", err)
+			}
+		}
+
+		framebuffer <- frame
+	}
+
+	return nil
+}
+
+// checkLinkable returns an error if any of the options can't be used while
+// linked to another console
+func (options *runOptions) checkLinkable() error {
+	switch {
+	case options.player != nil:
+		return fmt.Errorf("input playback: %w", ErrUnsupportedWhileLinked)
+	case options.recorder != nil:
+		return fmt.Errorf("input recording: %w", ErrUnsupportedWhileLinked)
+	case options.frameHook != nil:
+		return fmt.Errorf("frame hook: %w", ErrUnsupportedWhileLinked)
+	default:
+		return nil
+	}
+}
+
+// RunLinked runs two consoles connected by a link cable, at the pace of the
+// frames requested by hostA. Both are stepped in lockstep, whichever's furthest
+// behind going next, so each transfer happens at the same point for both. hostB
+// is sent each frame it's waiting on, but doesn't hold up emulation.
+func RunLinked(a, b Console, hostA, hostB devices.HostInterface) error {
+	cableA, cableB := devices.NewLocalLinkCables()
+
+	linkedA := &linkedConsole{console: a, host: hostA}
+	linkedB := &linkedConsole{console: b, host: hostB}
+
+	linkedA.attach(cableA)
+	linkedB.attach(cableB)
+
+	defer close(hostA.Framebuffer())
+	defer close(hostB.Framebuffer())
+
+	framesB := hostB.RequestFrame()
+
+	for range hostA.RequestFrame() {
+		linkedA.receiveInputs()
+		linkedB.receiveInputs()
+
+		for linkedA.elapsed < cgbCyclesPerFrame || linkedB.elapsed < cgbCyclesPerFrame {
+			next := linkedA
+			if linkedB.elapsed < linkedA.elapsed {
+				next = linkedB
+			}
+
+			if err := next.step(); err != nil {
+				return err
+			}
+		}
+
+		linkedA.elapsed -= cgbCyclesPerFrame
+		linkedB.elapsed -= cgbCyclesPerFrame
+
+		hostA.Framebuffer() <- a.Draw()
+
+		select {
+		case _, ok := <-framesB:
+			if !ok {
+				framesB = nil
+
+				continue
+			}
+
+			hostB.Framebuffer() <- b.Draw()
+		default:
+		}
+	}
+
+	return nil
+}
+
+// linkedConsole is one of the consoles run by RunLinked
+type linkedConsole struct {
+	console Console
+	host    devices.HostInterface
+	inputs  devices.JoypadInputs
+
+	// Measured in double speed cycles, so either console can be compared
+	// against the other, whatever speed they're running at
+	elapsed uint
+}
+
+func (lc *linkedConsole) attach(cable devices.SerialCable) {
+	lc.console.AttachAudioOutput(lc.host.AudioOutput())
+	lc.console.AttachCable(cable)
+	lc.console.SetupDebugger()
+}
+
+func (lc *linkedConsole) receiveInputs() {
+	lc.inputs = latestInputs(lc.inputs, lc.host.JoypadInput())
+	lc.console.ReceiveInputs(lc.inputs)
+}
+
+func (lc *linkedConsole) step() error {
+	// Each cycle takes twice as long at normal speed
+	scale := cgbCyclesPerFrame / lc.console.CyclesPerFrame()
+
+	cycles, err := lc.console.Step()
+	if err != nil {
+		return err
+	}
+
+	lc.elapsed += uint(cycles) * scale
+
+	return nil
+}
+
+// LoadStateFile restores a .state file written by SaveStateFile, after
+// checking that it was taken from the same cartridge & console model
+func LoadStateFile(r io.Reader, console Console) error {
+	meta, err := savestate.ReadMetadata(r)
+	if err != nil {
+		return fmt.Errorf("reading save state metadata: %w", err)
+	}
+
+	header := console.CartridgeHeader()
+	if meta.Title != header.Title || meta.GlobalChecksum != header.GlobalChecksum {
+		return fmt.Errorf(
+			"%w: state is for %q (0x%04X)",
+			ErrStateCartridgeMismatch,
+			strings.TrimRight(meta.Title, "\x00"),
+			meta.GlobalChecksum,
+		)
+	}
+
+	if meta.Model != string(console.Model()) {
+		return fmt.Errorf("%w: state is for %s", ErrStateModelMismatch, meta.Model)
+	}
+
+	return console.LoadState(r)
+}
+
+// SaveStateFile writes a snapshot of the console to a .state file, along w/
+// metadata about the cartridge & console and a thumbnail of the screen
+func SaveStateFile(w io.Writer, console Console) error {
+	var thumbnail bytes.Buffer
+	if err := png.Encode(&thumbnail, console.Draw()); err != nil {
+		return fmt.Errorf("encoding thumbnail: %w", err)
+	}
+
+	header := console.CartridgeHeader()
+	meta := savestate.Metadata{
+		Title:          header.Title,
+		GlobalChecksum: header.GlobalChecksum,
+		Model:          string(console.Model()),
+		Timestamp:      time.Now(),
+		Thumbnail:      thumbnail.Bytes(),
+	}
+
+	if err := savestate.WriteMetadata(w, meta); err != nil {
+		return fmt.Errorf("writing save state metadata: %w", err)
+	}
+
+	return console.SaveState(w)
+}
+
+type stateComponent struct {
+	name      string
+	component savestate.Serializer
+}
+
+// latestInputs returns the most recent inputs sent by the host, so that
+// they're only ever delivered to the console between frames
+func latestInputs(inputs devices.JoypadInputs, hostInputs <-chan devices.JoypadInputs) devices.JoypadInputs {
+	for {
+		select {
+		case input, ok := <-hostInputs:
+			if !ok {
+				return inputs
+			}
+			inputs = input
+		default:
+			return inputs
+		}
+	}
+}
+
+func loadState(r io.Reader, components []stateComponent) error {
+	chunks, err := savestate.ReadChunks(r)
+	if err != nil {
+		return fmt.Errorf("reading save state: %w", err)
+	}
+
+	for _, c := range components {
+		if err := chunks.LoadChunk(c.name, c.component); err != nil {
+			return fmt.Errorf("loading %s state: %w", c.name, err)
+		}
+	}
+
+	return nil
+}
+
+func saveState(w io.Writer, components []stateComponent) error {
+	chunks := savestate.NewChunkWriter(w)
+
+	for _, c := range components {
+		if err := chunks.WriteChunk(c.name, c.component); err != nil {
+			return fmt.Errorf("saving %s state: %w", c.name, err)
+		}
 	}
 
 	return nil
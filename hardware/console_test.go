@@ -0,0 +1,628 @@
+package hardware
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/maxfierke/gogo-gb/cpu"
+	"github.com/maxfierke/gogo-gb/devices"
+	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
+	"github.com/maxfierke/gogo-gb/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Console fields which are intentionally left out of save states
+var nonStateFields = []string{"debugger", "debuggerHandler", "scheduler"}
+
+func testROM() []byte {
+	rom := make([]byte, 0x8000)
+
+	program := []byte{
+		0x3E, 0x05, 0xE0, 0x07, // LD A, 0x05; LDH (TAC), A
+		0x3E, 0x80, 0xE0, 0x26, // LD A, 0x80; LDH (NR52), A
+		0x3E, 0xF0, 0xE0, 0x12, // LD A, 0xF0; LDH (NR12), A
+		0x3E, 0x87, 0xE0, 0x14, // LD A, 0x87; LDH (NR14), A
+		0x3E, 0x91, 0xE0, 0x40, // LD A, 0x91; LDH (LCDC), A
+		0x21, 0x00, 0xC0, // LD HL, 0xC000
+		0x34,       // INC (HL)
+		0xF0, 0x05, // LDH A, (TIMA)
+		0xEA, 0x01, 0xC0, // LD (0xC001), A
+		0xE0, 0x42, // LDH (SCY), A
+		0x18, 0xF3, // JR -13
+	}
+
+	copy(rom[0x0000:], []byte{0xC3, 0x50, 0x01}) // JP 0x0150
+	copy(rom[0x0100:], []byte{0x00, 0xC3, 0x50, 0x01})
+	copy(rom[0x0150:], program)
+
+	return rom
+}
+
+func newTestConsole(t *testing.T, model ConsoleModel) Console {
+	t.Helper()
+
+	console, err := NewConsole(model)
+	require.NoError(t, err)
+	require.NoError(t, console.LoadCartridge(bytes.NewReader(testROM())))
+
+	return console
+}
+
+func stepConsole(t *testing.T, console Console, steps int) {
+	t.Helper()
+
+	for range steps {
+		_, err := console.Step()
+		require.NoError(t, err)
+	}
+}
+
+func stateComponents(t *testing.T, console Console) []stateComponent {
+	t.Helper()
+
+	switch c := console.(type) {
+	case *DMG:
+		return c.stateComponents()
+	case *CGB:
+		return c.stateComponents()
+	default:
+		t.Fatalf("unrecognized console: %T", console)
+
+		return nil
+	}
+}
+
+func TestSaveStateCoversAllComponents(t *testing.T) {
+	for _, model := range []ConsoleModel{ConsoleModelDMG, ConsoleModelCGB} {
+		t.Run(string(model), func(t *testing.T) {
+			assert := assert.New(t)
+
+			console := newTestConsole(t, model)
+			components := stateComponents(t, console)
+
+			consoleValue := reflect.ValueOf(console).Elem()
+			for i := range consoleValue.NumField() {
+				field := consoleValue.Type().Field(i)
+				if slices.Contains(nonStateFields, field.Name) {
+					continue
+				}
+
+				fieldValue := consoleValue.Field(i)
+				included := slices.ContainsFunc(components, func(c stateComponent) bool {
+					return reflect.ValueOf(c.component).Pointer() == fieldValue.Pointer()
+				})
+				assert.Truef(included, "%s is not included in save states", field.Name)
+			}
+		})
+	}
+}
+
+func TestSaveStateRoundTrip(t *testing.T) {
+	for _, model := range []ConsoleModel{ConsoleModelDMG, ConsoleModelCGB} {
+		t.Run(string(model), func(t *testing.T) {
+			assert := assert.New(t)
+
+			original := newTestConsole(t, model)
+			stepConsole(t, original, 50000)
+
+			var snapshot bytes.Buffer
+			require.NoError(t, original.SaveState(&snapshot))
+
+			restored := newTestConsole(t, model)
+			require.NoError(t, restored.LoadState(bytes.NewReader(snapshot.Bytes())))
+
+			var restoredSnapshot bytes.Buffer
+			require.NoError(t, restored.SaveState(&restoredSnapshot))
+			assert.Equal(snapshot.Bytes(), restoredSnapshot.Bytes())
+
+			// Both should continue identically from the snapshot
+			stepConsole(t, original, 50000)
+			stepConsole(t, restored, 50000)
+
+			var expected, actual bytes.Buffer
+			require.NoError(t, original.SaveState(&expected))
+			require.NoError(t, restored.SaveState(&actual))
+			assert.Equal(expected.Bytes(), actual.Bytes())
+			assert.Equal(original.Draw(), restored.Draw())
+		})
+	}
+}
+
+func TestStateFile(t *testing.T) {
+	assert := assert.New(t)
+
+	original := newTestConsole(t, ConsoleModelDMG)
+	stepConsole(t, original, 50000)
+
+	var stateFile bytes.Buffer
+	require.NoError(t, SaveStateFile(&stateFile, original))
+
+	meta, err := savestate.ReadMetadata(bytes.NewReader(stateFile.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(savestate.FILE_VERSION, meta.Version)
+	assert.Equal(original.CartridgeHeader().Title, meta.Title)
+	assert.Equal(string(ConsoleModelDMG), meta.Model)
+	assert.NotEmpty(meta.Thumbnail)
+
+	restored := newTestConsole(t, ConsoleModelDMG)
+	require.NoError(t, LoadStateFile(bytes.NewReader(stateFile.Bytes()), restored))
+	assert.Equal(original.Draw(), restored.Draw())
+
+	otherModel := newTestConsole(t, ConsoleModelCGB)
+	err = LoadStateFile(bytes.NewReader(stateFile.Bytes()), otherModel)
+	assert.ErrorIs(err, ErrStateModelMismatch)
+
+	otherROM := testROM()
+	otherROM[0x014E] = 0xFF // Global checksum
+	otherCart, err := NewConsole(ConsoleModelDMG)
+	require.NoError(t, err)
+	require.NoError(t, otherCart.LoadCartridge(bytes.NewReader(otherROM)))
+	err = LoadStateFile(bytes.NewReader(stateFile.Bytes()), otherCart)
+	assert.ErrorIs(err, ErrStateCartridgeMismatch)
+}
+
+type testHost struct {
+	fbChan    chan image.Image
+	frameChan chan struct{}
+	inputChan chan devices.JoypadInputs
+
+	snapshot    bytes.Buffer
+	beforeFrame func(console Console) (bool, error)
+}
+
+func newTestHost() *testHost {
+	return &testHost{
+		fbChan:    make(chan image.Image),
+		frameChan: make(chan struct{}),
+		inputChan: make(chan devices.JoypadInputs, 1),
+	}
+}
+
+func (h *testHost) AudioOutput() devices.AudioOutput {
+	return devices.NewNullAudioOutput(devices.DEFAULT_AUDIO_SAMPLE_RATE)
+}
+
+func (h *testHost) BeforeFrame(console Console) (bool, error) {
+	return h.beforeFrame(console)
+}
+
+func (h *testHost) Framebuffer() chan<- image.Image          { return h.fbChan }
+func (h *testHost) JoypadInput() <-chan devices.JoypadInputs { return h.inputChan }
+func (h *testHost) RequestFrame() <-chan struct{}            { return h.frameChan }
+func (h *testHost) Log(msg string, args ...any)              {}
+func (h *testHost) LogErr(msg string, args ...any)           {}
+func (h *testHost) LogWarn(msg string, args ...any)          {}
+func (h *testHost) SerialCable() devices.SerialCable         { return &devices.NullSerialCable{} }
+
+func TestRunFrameHook(t *testing.T) {
+	assert := assert.New(t)
+
+	console := newTestConsole(t, ConsoleModelDMG)
+	host := newTestHost()
+
+	frame := 0
+	host.beforeFrame = func(console Console) (bool, error) {
+		frame++
+
+		switch frame {
+		case 10:
+			host.snapshot.Reset()
+
+			return true, console.SaveState(&host.snapshot)
+		case 20:
+			return false, console.LoadState(bytes.NewReader(host.snapshot.Bytes()))
+		default:
+			return true, nil
+		}
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Run(console, host, WithFrameHook(host))
+	}()
+
+	for range 20 {
+		host.inputChan <- devices.JoypadInputs{}
+		host.frameChan <- struct{}{}
+		<-host.fbChan
+	}
+	close(host.frameChan)
+	require.NoError(t, <-done)
+
+	// The console should be left as it was restored, w/o emulating frame 20
+	var state bytes.Buffer
+	require.NoError(t, console.SaveState(&state))
+	assert.Equal(20, frame)
+	assert.Equal(host.snapshot.Bytes(), state.Bytes())
+}
+
+type testInputLog struct {
+	inputs []devices.JoypadInputs
+	frames []image.Image
+	played int
+}
+
+func (l *testInputLog) RecordFrame(inputs devices.JoypadInputs, frame image.Image) error {
+	l.inputs = append(l.inputs, inputs)
+	l.frames = append(l.frames, frame)
+
+	return nil
+}
+
+func (l *testInputLog) NextFrame() (devices.JoypadInputs, error) {
+	if l.played == len(l.inputs) {
+		return devices.JoypadInputs{}, io.EOF
+	}
+	l.played++
+
+	return l.inputs[l.played-1], nil
+}
+
+func (l *testInputLog) VerifyFrame(frame image.Image) error {
+	if !reflect.DeepEqual(l.frames[l.played-1], frame) {
+		return fmt.Errorf("frame %d diverged", l.played)
+	}
+
+	return nil
+}
+
+func TestRunInputPlayback(t *testing.T) {
+	assert := assert.New(t)
+
+	runFrames := func(console Console, frames int, hostInputs func(frame int) devices.JoypadInputs, opts ...RunOption) {
+		host := newTestHost()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- Run(console, host, opts...)
+		}()
+
+		for frame := range frames {
+			host.inputChan <- hostInputs(frame)
+			host.frameChan <- struct{}{}
+			<-host.fbChan
+		}
+		close(host.frameChan)
+		require.NoError(t, <-done)
+	}
+
+	var log testInputLog
+
+	recorded := newTestConsole(t, ConsoleModelDMG)
+	runFrames(recorded, 30, func(frame int) devices.JoypadInputs {
+		return devices.JoypadInputs{A: frame%3 == 0, Down: frame > 10}
+	}, WithInputRecorder(&log))
+	assert.Len(log.inputs, 30)
+	assert.Equal(devices.JoypadInputs{A: true, Down: true}, log.inputs[12])
+
+	// Host inputs are ignored during playback
+	played := newTestConsole(t, ConsoleModelDMG)
+	runFrames(played, 30, func(frame int) devices.JoypadInputs {
+		return devices.JoypadInputs{Start: true}
+	}, WithInputPlayer(&log))
+	assert.Equal(30, log.played)
+
+	var expected, actual bytes.Buffer
+	require.NoError(t, recorded.SaveState(&expected))
+	require.NoError(t, played.SaveState(&actual))
+	assert.Equal(expected.Bytes(), actual.Bytes())
+}
+
+// stopROM selects the buttons, then STOPs until one's pressed
+func stopROM() []byte {
+	rom := make([]byte, 0x8000)
+
+	program := []byte{
+		0x3E, 0x91, 0xE0, 0x40, // LD A, 0x91; LDH (LCDC), A
+		0x3E, 0x10, 0xE0, 0x00, // LD A, 0x10; LDH (P1), A
+		0x10, 0x00, // STOP
+		0x04,       // INC B
+		0x18, 0xFD, // JR -3
+	}
+
+	copy(rom[0x0000:], []byte{0xC3, 0x50, 0x01}) // JP 0x0150
+	copy(rom[0x0100:], []byte{0x00, 0xC3, 0x50, 0x01})
+	copy(rom[0x0150:], program)
+
+	return rom
+}
+
+func TestStopUntilJoypad(t *testing.T) {
+	for _, model := range []ConsoleModel{ConsoleModelDMG, ConsoleModelCGB} {
+		t.Run(string(model), func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			console, err := NewConsole(model)
+			require.NoError(err)
+			require.NoError(console.LoadCartridge(bytes.NewReader(stopROM())))
+
+			var (
+				c     *cpu.CPU
+				mmu   *mem.MMU
+				sched *scheduler.Scheduler
+			)
+			switch console := console.(type) {
+			case *DMG:
+				c, mmu, sched = console.cpu, console.mmu, console.scheduler
+			case *CGB:
+				c, mmu, sched = console.cpu, console.mmu, console.scheduler
+			}
+
+			for i := 0; i < 100 && !c.IsStopped(); i++ {
+				stepConsole(t, console, 1)
+			}
+			require.True(c.IsStopped())
+			assert.Equal(byte(0), mmu.Peek8(devices.REG_TIMER_DIV))
+
+			// Nothing advances, & the LCD isn't driven, until a button's pressed
+			now := sched.Now()
+			stepConsole(t, console, 1000)
+			assert.Equal(now, sched.Now())
+			assert.True(c.IsStopped())
+
+			frame := console.Draw()
+			r, g, b, _ := frame.At(0, 0).RGBA()
+			assert.Equal([3]uint32{0xFFFF, 0xFFFF, 0xFFFF}, [3]uint32{r, g, b})
+
+			// D-pad isn't selected, so doesn't wake it
+			console.ReceiveInputs(devices.JoypadInputs{Up: true})
+			stepConsole(t, console, 10)
+			assert.True(c.IsStopped())
+
+			console.ReceiveInputs(devices.JoypadInputs{A: true})
+			stepConsole(t, console, 10)
+			assert.False(c.IsStopped())
+			assert.Greater(sched.Now(), now)
+			assert.NotZero(c.Reg.B.Read())
+		})
+	}
+}
+
+func TestCGBSpeedSwitch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rom := make([]byte, 0x8000)
+	program := []byte{
+		0x3E, 0x91, 0xE0, 0x40, // LD A, 0x91; LDH (LCDC), A
+		0x3E, 0x01, 0xE0, 0x4D, // LD A, 0x01; LDH (KEY1), A
+		0x10, 0x00, // STOP
+		0x18, 0xFE, // JR -2
+	}
+	copy(rom[0x0000:], []byte{0xC3, 0x50, 0x01}) // JP 0x0150
+	copy(rom[0x0150:], program)
+
+	cgb, err := NewCGB()
+	require.NoError(err)
+	require.NoError(cgb.LoadCartridge(bytes.NewReader(rom)))
+
+	for i := 0; i < 100 && !cgb.cpu.IsDoubleSpeed(); i++ {
+		stepConsole(t, cgb, 1)
+	}
+	require.True(cgb.cpu.IsDoubleSpeed())
+	assert.False(cgb.cpu.IsStopped())
+	assert.Equal(byte(0x80), cgb.mmu.Peek8(cpu.REG_KEY1))
+	assert.Equal(cgbCyclesPerFrame, cgb.CyclesPerFrame())
+
+	// The PPU runs at the same speed, so a frame's twice as many CPU cycles
+	ly := cgb.mmu.Peek8(0xFF44)
+	var cycles uint
+	for cycles < cgb.CyclesPerFrame()/2 {
+		c, err := cgb.Step()
+		require.NoError(err)
+		cycles += uint(c)
+	}
+	assert.NotEqual(ly, cgb.mmu.Peek8(0xFF44))
+
+	for cycles < cgb.CyclesPerFrame() {
+		c, err := cgb.Step()
+		require.NoError(err)
+		cycles += uint(c)
+	}
+	assert.Equal(ly, cgb.mmu.Peek8(0xFF44))
+}
+
+func TestCGBInterruptDuringGDMA(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	rom := make([]byte, 0x8000)
+	program := []byte{
+		0x31, 0xFE, 0xFF, // LD SP, 0xFFFE
+		0x3E, 0x04, 0xE0, 0xFF, // LD A, 0x04; LDH (IE), A
+		0xFB,                   // EI
+		0x3E, 0xC0, 0xE0, 0x51, // LD A, 0xC0; LDH (HDMA1), A
+		0x3E, 0x00, 0xE0, 0x52, // LD A, 0x00; LDH (HDMA2), A
+		0x3E, 0x80, 0xE0, 0x53, // LD A, 0x80; LDH (HDMA3), A
+		0x3E, 0x00, 0xE0, 0x54, // LD A, 0x00; LDH (HDMA4), A
+		0x3E, 0x0F, 0xE0, 0x55, // LD A, 0x0F; LDH (HDMA5), A
+		0x18, 0xFE, // JR -2
+	}
+	copy(rom[0x0000:], []byte{0xC3, 0x50, 0x01}) // JP 0x0150
+	copy(rom[0x0050:], []byte{0x18, 0xFE})       // JR -2
+	copy(rom[0x0150:], program)
+
+	cgb, err := NewCGB()
+	require.NoError(err)
+	require.NoError(cgb.LoadCartridge(bytes.NewReader(rom)))
+
+	for i := 0; i < 100 && !cgb.hdma.IsTransferring(); i++ {
+		stepConsole(t, cgb, 1)
+	}
+	require.True(cgb.hdma.IsTransferring())
+
+	cgb.ic.RequestTimer()
+
+	// The CPU's stalled for every block, & takes the interrupt after the last
+	blocks := 0
+	for cgb.hdma.IsTransferring() {
+		assert.Equal(devices.INT_TIMER, cgb.ic.NextRequest())
+		assert.NotEqual(uint16(0x0050), cgb.cpu.PC.Read())
+
+		stepConsole(t, cgb, 1)
+		blocks++
+	}
+	assert.Equal(16, blocks)
+	assert.Equal(devices.INT_NONE, cgb.ic.NextRequest())
+	assert.Equal(uint16(0x0050), cgb.cpu.PC.Read())
+}
+
+// haltROM waits for VBlank in a loop, like most games do once they're done
+// with a frame
+func haltROM() []byte {
+	rom := make([]byte, 0x8000)
+
+	program := []byte{
+		0x3E, 0x01, 0xE0, 0xFF, // LD A, 0x01; LDH (IE), A
+		0x3E, 0x91, 0xE0, 0x40, // LD A, 0x91; LDH (LCDC), A
+		0xFB,       // EI
+		0x76,       // HALT
+		0x18, 0xFD, // JR -3
+	}
+
+	copy(rom[0x0000:], []byte{0xC3, 0x50, 0x01}) // JP 0x0150
+	copy(rom[0x0040:], []byte{0xD9})             // RETI
+	copy(rom[0x0100:], []byte{0x00, 0xC3, 0x50, 0x01})
+	copy(rom[0x0150:], program)
+
+	return rom
+}
+
+func BenchmarkStepFrame(b *testing.B) {
+	roms := []struct {
+		name string
+		rom  []byte
+	}{
+		{name: "busy", rom: testROM()},
+		{name: "halt", rom: haltROM()},
+	}
+
+	for _, model := range []ConsoleModel{ConsoleModelDMG, ConsoleModelCGB} {
+		for _, rom := range roms {
+			b.Run(fmt.Sprintf("%s/%s", model, rom.name), func(b *testing.B) {
+				console, err := NewConsole(model)
+				require.NoError(b, err)
+				require.NoError(b, console.LoadCartridge(bytes.NewReader(rom.rom)))
+
+				for b.Loop() {
+					for cycles := uint(0); cycles < console.CyclesPerFrame(); {
+						stepCycles, err := console.Step()
+						if err != nil {
+							b.Fatal(err)
+						}
+
+						cycles += uint(stepCycles)
+					}
+				}
+			})
+		}
+	}
+}
+
+// linkROM waits for delay iterations, then transfers sb over the serial port
+// using sc, storing what was received at 0xC000
+func linkROM(delay, sb, sc byte) []byte {
+	rom := make([]byte, 0x8000)
+
+	program := []byte{
+		0x06, delay, // LD B, delay
+		0x05,       // DEC B
+		0x20, 0xFD, // JR NZ, -3
+		0x3E, sb, 0xE0, 0x01, // LD A, sb; LDH (SB), A
+		0x3E, sc, 0xE0, 0x02, // LD A, sc; LDH (SC), A
+		0xF0, 0x02, // LDH A, (SC)
+		0xCB, 0x7F, // BIT 7, A
+		0x20, 0xFA, // JR NZ, -6
+		0xF0, 0x01, // LDH A, (SB)
+		0xEA, 0x00, 0xC0, // LD (0xC000), A
+		0x18, 0xFE, // JR -2
+	}
+
+	copy(rom[0x0000:], []byte{0xC3, 0x50, 0x01}) // JP 0x0150
+	copy(rom[0x0100:], []byte{0x00, 0xC3, 0x50, 0x01})
+	copy(rom[0x0150:], program)
+
+	return rom
+}
+
+func TestRunLinked(t *testing.T) {
+	for _, models := range [][2]ConsoleModel{
+		{ConsoleModelDMG, ConsoleModelDMG},
+		{ConsoleModelCGB, ConsoleModelDMG},
+	} {
+		t.Run(fmt.Sprintf("%s+%s", models[0], models[1]), func(t *testing.T) {
+			assert := assert.New(t)
+
+			master, err := NewConsole(models[0])
+			require.NoError(t, err)
+			require.NoError(t, master.LoadCartridge(bytes.NewReader(linkROM(0x00, 0x11, 0x81))))
+
+			slave, err := NewConsole(models[1])
+			require.NoError(t, err)
+			require.NoError(t, slave.LoadCartridge(bytes.NewReader(linkROM(0x01, 0x42, 0x80))))
+
+			hostA := newTestHost()
+			hostB := newTestHost()
+
+			done := make(chan error, 1)
+			go func() {
+				done <- RunLinked(master, slave, hostA, hostB)
+			}()
+
+			for range 5 {
+				hostA.frameChan <- struct{}{}
+				<-hostA.fbChan
+			}
+			close(hostA.frameChan)
+			require.NoError(t, <-done)
+
+			assert.Equal(byte(0x42), consoleMMU(master).Peek8(0xC000))
+			assert.Equal(byte(0x11), consoleMMU(slave).Peek8(0xC000))
+		})
+	}
+}
+
+func TestRunLinkedUnsupportedOptions(t *testing.T) {
+	testCases := []struct {
+		name string
+		opt  func(host *testHost) RunOption
+	}{
+		{name: "input player", opt: func(host *testHost) RunOption { return WithInputPlayer(&testInputLog{}) }},
+		{name: "input recorder", opt: func(host *testHost) RunOption { return WithInputRecorder(&testInputLog{}) }},
+		{name: "frame hook", opt: func(host *testHost) RunOption { return WithFrameHook(host) }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			host := newTestHost()
+			linked := newTestConsole(t, ConsoleModelDMG)
+
+			err := Run(
+				newTestConsole(t, ConsoleModelDMG),
+				host,
+				WithLinkedConsole(linked, newTestHost()),
+				tc.opt(host),
+			)
+			assert.ErrorIs(t, err, ErrUnsupportedWhileLinked)
+		})
+	}
+}
+
+func consoleMMU(console Console) *mem.MMU {
+	switch c := console.(type) {
+	case *DMG:
+		return c.mmu
+	case *CGB:
+		return c.mmu
+	default:
+		return nil
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"image"
 	"io"
+	"time"
 
+	"github.com/maxfierke/gogo-gb/apu"
 	"github.com/maxfierke/gogo-gb/cart"
 	"github.com/maxfierke/gogo-gb/cpu"
 	"github.com/maxfierke/gogo-gb/debug"
@@ -13,6 +15,7 @@ import (
 	"github.com/maxfierke/gogo-gb/mem"
 	"github.com/maxfierke/gogo-gb/ppu"
 	"github.com/maxfierke/gogo-gb/ppu/rendering"
+	"github.com/maxfierke/gogo-gb/scheduler"
 )
 
 const (
@@ -23,6 +26,8 @@ const (
 
 type DMG struct {
 	// Components
+	apu       *apu.APU
+	bootROM   *devices.DMGBootROM
 	cpu       *cpu.CPU
 	mmu       *mem.MMU
 	cartridge *cart.Cartridge
@@ -36,6 +41,7 @@ type DMG struct {
 	// Non-components
 	debugger        debug.Debugger
 	debuggerHandler mem.MemHandlerHandle
+	scheduler       *scheduler.Scheduler
 }
 
 var _ Console = (*DMG)(nil)
@@ -54,6 +60,8 @@ func NewDMG(opts ...ConsoleOption) (*DMG, error) {
 	ic := devices.NewInterruptController()
 
 	dmg := &DMG{
+		apu:       apu.NewAPU(),
+		bootROM:   devices.NewDMGBootROM(),
 		cpu:       cpu,
 		mmu:       mmu,
 		cartridge: cart.NewCartridge(),
@@ -73,22 +81,46 @@ func NewDMG(opts ...ConsoleOption) (*DMG, error) {
 		}
 	}
 
-	mmu.AddHandler(mem.MemRegion{Start: 0x0000, End: 0x7FFF}, dmg.cartridge) // MBCs ROM Banks
-	mmu.AddHandler(mem.MemRegion{Start: 0xA000, End: 0xBFFF}, dmg.cartridge) // MBCs RAM Banks
+	dmg.timer.ConnectDivAPU(dmg.apu)
+	dmg.cpu.ConnectTicker(dmg.tick)
+	dmg.cpu.ConnectStop(dmg.stop)
+
+	// Everything but the CPU & APU is only stepped when it has something to do,
+	// or its registers are accessed
+	dmg.scheduler = scheduler.NewScheduler()
+	cartridgeTask := dmg.scheduler.AddTask(dmg.cartridge.Step, dmg.cartridge.NextEvent)
+	dmaTask := dmg.scheduler.AddTask(func(cycles uint8) { dmg.dma.Step(mmu, cycles) }, dmg.dma.NextEvent)
+	ppuTask := dmg.scheduler.AddTask(func(cycles uint8) { dmg.ppu.Step(mmu, cycles) }, dmg.ppu.NextEvent)
+	serialTask := dmg.scheduler.AddTask(func(cycles uint8) { dmg.serial.Step(cycles, ic) }, dmg.serial.NextEvent)
+	timerTask := dmg.scheduler.AddTask(func(cycles uint8) { dmg.timer.Step(cycles, ic) }, dmg.timer.NextEvent)
+
+	syncedCartROM := dmg.scheduler.WriteHandler(cartridgeTask, dmg.cartridge)
+	syncedCartRAM := dmg.scheduler.Handler(cartridgeTask, dmg.cartridge)
+	syncedDMA := dmg.scheduler.Handler(dmaTask, dmg.dma)
+	syncedPPU := dmg.scheduler.Handler(ppuTask, dmg.ppu)
+	syncedSerial := dmg.scheduler.Handler(serialTask, dmg.serial)
+	syncedTimer := dmg.scheduler.Handler(timerTask, dmg.timer)
+
+	// OAM DMA sits in front of everything it can conflict with while copying
+	dmg.dma.AttachMemHandlers(mmu)
+
+	mmu.AddHandler(mem.MemRegion{Start: 0x0000, End: 0x7FFF}, syncedCartROM) // MBCs ROM Banks
+	mmu.AddHandler(mem.MemRegion{Start: 0xA000, End: 0xBFFF}, syncedCartRAM) // MBCs RAM Banks
 
 	mmu.AddHandler(mem.MemRegion{Start: 0xE000, End: 0xFDFF}, echo)     // Echo RAM (mirrors WRAM)
 	mmu.AddHandler(mem.MemRegion{Start: 0xFEA0, End: 0xFEFF}, unmapped) // Nop writes, zero reads
 
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF00, End: 0xFF00}, dmg.joypad) // Joypad
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF01, End: 0xFF02}, dmg.serial) // Serial Port (Control & Data)
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF04, End: 0xFF07}, dmg.timer)  // Timer (not RTC)
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF40, End: 0xFF41}, dmg.ppu)    // LCD status, control registers
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF42, End: 0xFF45}, dmg.ppu)    // PPU registers
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF46, End: 0xFF46}, dmg.dma)    // DMA
-	mmu.AddHandler(mem.MemRegion{Start: 0xFF47, End: 0xFF4B}, dmg.ppu)    // PPU registers
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF00, End: 0xFF00}, dmg.joypad)   // Joypad
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF01, End: 0xFF02}, syncedSerial) // Serial Port (Control & Data)
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF04, End: 0xFF07}, syncedTimer)  // Timer (not RTC)
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF10, End: 0xFF3F}, dmg.apu)      // APU registers & wave RAM
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF40, End: 0xFF41}, syncedPPU)    // LCD status, control registers
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF42, End: 0xFF45}, syncedPPU)    // PPU registers
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF46, End: 0xFF46}, syncedDMA)    // DMA
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF47, End: 0xFF4B}, syncedPPU)    // PPU registers
 
-	mmu.AddHandler(mem.MemRegion{Start: 0x8000, End: 0x9FFF}, dmg.ppu) // VRAM tiles
-	mmu.AddHandler(mem.MemRegion{Start: 0xFE00, End: 0xFE9F}, dmg.ppu) // OAM
+	mmu.AddHandler(mem.MemRegion{Start: 0x8000, End: 0x9FFF}, syncedPPU) // VRAM tiles
+	mmu.AddHandler(mem.MemRegion{Start: 0xFE00, End: 0xFE9F}, syncedPPU) // OAM
 
 	mmu.AddHandler(mem.MemRegion{Start: 0xFF0F, End: 0xFF0F}, dmg.ic)   // Interrupts Requested
 	mmu.AddHandler(mem.MemRegion{Start: 0xFF4D, End: 0xFF77}, unmapped) // CGB regs
@@ -97,6 +129,10 @@ func NewDMG(opts ...ConsoleOption) (*DMG, error) {
 	return dmg, nil
 }
 
+func (dmg *DMG) AttachAudioOutput(output devices.AudioOutput) {
+	dmg.apu.AttachOutput(output)
+}
+
 func (dmg *DMG) AttachCable(cable devices.SerialCable) {
 	dmg.serial.AttachCable(cable)
 }
@@ -143,6 +179,8 @@ func (dmg *DMG) LoadCartridge(r io.Reader) error {
 }
 
 func (dmg *DMG) Draw() image.Image {
+	dmg.scheduler.SyncAll()
+
 	return dmg.ppu.Draw()
 }
 
@@ -156,6 +194,8 @@ func (dmg *DMG) LoadSave(r io.Reader) error {
 }
 
 func (dmg *DMG) Save(w io.Writer) error {
+	dmg.scheduler.SyncAll()
+
 	err := dmg.cartridge.Save(w)
 	if err != nil {
 		return fmt.Errorf("writing save: %w", err)
@@ -164,11 +204,42 @@ func (dmg *DMG) Save(w io.Writer) error {
 	return nil
 }
 
+// LoadState restores a snapshot of the entire machine, as written by SaveState.
+// The same cartridge (and boot ROM, if any) must already be loaded.
+func (dmg *DMG) LoadState(r io.Reader) error {
+	err := loadState(r, dmg.stateComponents())
+
+	// Everything's synced up before saving, so can pick up from now
+	dmg.scheduler.Reset()
+
+	return err
+}
+
+func (dmg *DMG) SaveState(w io.Writer) error {
+	dmg.scheduler.SyncAll()
+
+	return saveState(w, dmg.stateComponents())
+}
+
+func (dmg *DMG) Model() ConsoleModel {
+	return ConsoleModelDMG
+}
+
 func (dmg *DMG) ReceiveInputs(inputs devices.JoypadInputs) {
 	dmg.joypad.ReceiveInputs(inputs)
 }
 
 func (dmg *DMG) Step() (uint8, error) {
+	if dmg.cpu.IsStopped() {
+		if !dmg.joypad.IsAnyLineLow() {
+			// The system clock is stopped, so nothing advances until it's woken up
+			return 4, nil
+		}
+
+		dmg.cpu.ExitStop()
+		dmg.ppu.SetStopped(false)
+	}
+
 	dmg.debugger.OnDecode(dmg.cpu, dmg.mmu)
 
 	var cycles uint8
@@ -194,17 +265,54 @@ func (dmg *DMG) Step() (uint8, error) {
 		dmg.debugger.OnInterrupt(dmg.cpu, dmg.mmu)
 	}
 
-	dmg.cartridge.Step(cycles)
-	dmg.dma.Step(dmg.mmu, cycles)
-	dmg.ppu.Step(dmg.mmu, cycles)
-	dmg.timer.Step(cycles, dmg.ic)
-	dmg.serial.Step(cycles, dmg.ic)
-
 	return cycles, nil
 }
 
+// UseEmulatedClock makes the cartridge's RTC, if any, follow emulated time
+// from seed, so that emulation is fully deterministic
+func (dmg *DMG) UseEmulatedClock(seed time.Time) {
+	dmg.cartridge.UseEmulatedClock(seed)
+}
+
 func (dmg *DMG) detachDebugger() {
 	// Remove any existing handlers
 	dmg.mmu.RemoveHandler(dmg.debuggerHandler)
 	dmg.debugger = debug.NewNullDebugger()
 }
+
+// stateComponents lists every component included in save states, in the order
+// they're saved & restored
+func (dmg *DMG) stateComponents() []stateComponent {
+	return []stateComponent{
+		{name: "cpu", component: dmg.cpu},
+		{name: "mmu", component: dmg.mmu},
+		{name: "boot_rom", component: dmg.bootROM},
+		{name: "cartridge", component: dmg.cartridge},
+		{name: "dma", component: dmg.dma},
+		{name: "interrupts", component: dmg.ic},
+		{name: "joypad", component: dmg.joypad},
+		{name: "ppu", component: dmg.ppu},
+		{name: "serial", component: dmg.serial},
+		{name: "timer", component: dmg.timer},
+		{name: "apu", component: dmg.apu},
+	}
+}
+
+// stop is called after the CPU executes STOP, which resets DIV, & either
+// stops the system, or switches speed
+func (dmg *DMG) stop() {
+	dmg.scheduler.SyncAll()
+	dmg.timer.ResetDiv()
+	dmg.ppu.SetStopped(dmg.cpu.IsStopped())
+
+	// DIV's changed outside of the timer's handlers
+	dmg.scheduler.Reset()
+}
+
+// tick advances everything but the CPU by cycles. The CPU calls this as it
+// accesses memory, so that each access sees the rest of the system as it
+// would be at that point in the instruction.
+func (dmg *DMG) tick(cycles uint8) {
+	dmg.scheduler.Advance(cycles)
+	dmg.apu.Step(cycles)
+}
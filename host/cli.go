@@ -3,6 +3,8 @@ package host
 import (
 	"image"
 	"log"
+	"os"
+	"os/signal"
 	"time"
 
 	"github.com/maxfierke/gogo-gb/devices"
@@ -10,17 +12,20 @@ import (
 )
 
 type CLIHost struct {
+	audioOutput devices.AudioOutput
 	fbChan      chan image.Image
 	frameChan   chan struct{}
 	inputChan   chan devices.JoypadInputs
 	logger      *log.Logger
 	serialCable devices.SerialCable
+	stopSignals []os.Signal
 }
 
 var _ Host = (*CLIHost)(nil)
 
 func NewCLIHost() *CLIHost {
 	return &CLIHost{
+		audioOutput: devices.NewNullAudioOutput(devices.DEFAULT_AUDIO_SAMPLE_RATE),
 		fbChan:      make(chan image.Image),
 		frameChan:   make(chan struct{}),
 		inputChan:   make(chan devices.JoypadInputs),
@@ -29,6 +34,10 @@ func NewCLIHost() *CLIHost {
 	}
 }
 
+func (h *CLIHost) AudioOutput() devices.AudioOutput {
+	return h.audioOutput
+}
+
 func (h *CLIHost) Framebuffer() chan<- image.Image {
 	return h.fbChan
 }
@@ -57,6 +66,18 @@ func (h *CLIHost) SetLogger(logger *log.Logger) {
 	h.logger = logger
 }
 
+// AttachAudioOutput replaces the default of discarding audio, e.g. to
+// capture it to a file
+func (h *CLIHost) AttachAudioOutput(output devices.AudioOutput) {
+	h.audioOutput = output
+}
+
+// SetAudioSampleRate only changes the rate reported to the console, as the
+// CLI host has nowhere to play audio and discards it.
+func (h *CLIHost) SetAudioSampleRate(sampleRate int) {
+	h.audioOutput = devices.NewNullAudioOutput(sampleRate)
+}
+
 func (h *CLIHost) SerialCable() devices.SerialCable {
 	return h.serialCable
 }
@@ -65,25 +86,24 @@ func (h *CLIHost) AttachSerialCable(serialCable devices.SerialCable) {
 	h.serialCable = serialCable
 }
 
-func (h *CLIHost) Run(console hardware.Console) error {
-	done := make(chan error)
-	defer close(h.inputChan)
-	defer close(h.frameChan)
-
-	// "Renderer"
-	go func() {
-		ticker := time.NewTicker(time.Second / 60)
+// StopOnSignals gracefully stops emulation when any of the given signals are
+// received, so that saves and the like can be written out
+func (h *CLIHost) StopOnSignals(signals ...os.Signal) {
+	h.stopSignals = signals
+}
 
-		for range ticker.C {
-			h.frameChan <- struct{}{}
+func (h *CLIHost) Run(console hardware.Console, opts ...hardware.RunOption) error {
+	done := make(chan error, 1)
+	defer close(h.inputChan)
 
-			// Consume frame
-			<-h.fbChan
-		}
-	}()
+	stop := make(chan os.Signal, 1)
+	if len(h.stopSignals) > 0 {
+		signal.Notify(stop, h.stopSignals...)
+		defer signal.Stop(stop)
+	}
 
 	go func() {
-		if err := hardware.Run(console, h); err != nil {
+		if err := hardware.Run(console, h, opts...); err != nil {
 			h.LogErr("unexpected error occurred during runtime: %v", err)
 			done <- err
 
@@ -93,5 +113,33 @@ func (h *CLIHost) Run(console hardware.Console) error {
 		done <- nil
 	}()
 
-	return <-done
+	// "Renderer"
+	ticker := time.NewTicker(time.Second / 60)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-done:
+			close(h.frameChan)
+
+			return err
+		case sig := <-stop:
+			h.Log("received %v, stopping emulation", sig)
+
+			// Let the console finish its current frame & shut down cleanly
+			close(h.frameChan)
+
+			return <-done
+		case <-ticker.C:
+			select {
+			case h.frameChan <- struct{}{}:
+				// Consume frame
+				<-h.fbChan
+			case err := <-done:
+				close(h.frameChan)
+
+				return err
+			}
+		}
+	}
 }
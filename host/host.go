@@ -10,7 +10,9 @@ import (
 type Host interface {
 	devices.HostInterface
 
+	AttachAudioOutput(output devices.AudioOutput)
 	AttachSerialCable(serialCable devices.SerialCable)
+	SetAudioSampleRate(sampleRate int)
 	SetLogger(logger *log.Logger)
-	Run(console hardware.Console) error
+	Run(console hardware.Console, opts ...hardware.RunOption) error
 }
@@ -1,46 +1,80 @@
 package host
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"image"
 	"log"
 	"math"
+	"sync/atomic"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/audio"
 	"github.com/maxfierke/gogo-gb/devices"
 	"github.com/maxfierke/gogo-gb/hardware"
+	"github.com/maxfierke/gogo-gb/savestate"
 )
 
 const (
 	FB_HEIGHT = 144
 	FB_WIDTH  = 160
+
+	// How much audio can be queued up before the oldest samples are dropped
+	AUDIO_BUFFER_LEN = 200 * time.Millisecond
+	// How much audio ebiten buffers before handing it off to the audio device
+	AUDIO_PLAYER_BUFFER_LEN = 50 * time.Millisecond
+
+	// How many frames are emulated between each snapshot taken for rewinding.
+	// One snapshot is restored per frame while rewinding, so this is also how
+	// much faster than real-time rewinding goes.
+	REWIND_SNAPSHOT_INTERVAL = 2
 )
 
 type UI struct {
-	fbChan      chan image.Image
-	frameChan   chan struct{}
-	inputChan   chan devices.JoypadInputs
-	logger      *log.Logger
-	serialCable devices.SerialCable
+	audioBuffer  *devices.AudioBuffer
+	audioCapture devices.AudioOutput
+	audioPlayer  *audio.Player
+	fbChan       chan image.Image
+	frameChan    chan struct{}
+	inputChan    chan devices.JoypadInputs
+	logger       *log.Logger
+	serialCable  devices.SerialCable
 
 	framebufferImage *ebiten.Image
+
+	rewind         *savestate.RewindBuffer
+	rewindFrames   uint
+	rewindSnapshot bytes.Buffer
+	rewinding      atomic.Bool
 }
 
 var (
-	_ Host        = (*UI)(nil)
-	_ ebiten.Game = (*UI)(nil)
+	_ Host               = (*UI)(nil)
+	_ ebiten.Game        = (*UI)(nil)
+	_ hardware.FrameHook = (*UI)(nil)
 )
 
 func NewUIHost() *UI {
 	return &UI{
+		audioBuffer: newUIAudioBuffer(devices.DEFAULT_AUDIO_SAMPLE_RATE),
 		fbChan:      make(chan image.Image, 1),
 		frameChan:   make(chan struct{}),
-		inputChan:   make(chan devices.JoypadInputs),
+		inputChan:   make(chan devices.JoypadInputs, 1),
 		logger:      log.Default(),
 		serialCable: &devices.NullSerialCable{},
 	}
 }
 
+func (ui *UI) AudioOutput() devices.AudioOutput {
+	if ui.audioCapture != nil {
+		return devices.NewMultiAudioOutput(ui.audioBuffer, ui.audioCapture)
+	}
+
+	return ui.audioBuffer
+}
+
 func (ui *UI) Framebuffer() chan<- image.Image {
 	return ui.fbChan
 }
@@ -69,14 +103,72 @@ func (ui *UI) SetLogger(logger *log.Logger) {
 	ui.logger = logger
 }
 
+func (ui *UI) SetAudioSampleRate(sampleRate int) {
+	ui.audioBuffer = newUIAudioBuffer(sampleRate)
+}
+
 func (ui *UI) SerialCable() devices.SerialCable {
 	return ui.serialCable
 }
 
+// AttachAudioOutput captures audio to an additional output, alongside playing
+// it back through the speakers
+func (ui *UI) AttachAudioOutput(output devices.AudioOutput) {
+	ui.audioCapture = output
+}
+
 func (ui *UI) AttachSerialCable(serialCable devices.SerialCable) {
 	ui.serialCable = serialCable
 }
 
+// EnableRewind keeps a history of snapshots, using up to budget bytes, which
+// can be rewound through by holding Backspace
+func (ui *UI) EnableRewind(budget int) {
+	ui.rewind = savestate.NewRewindBuffer(budget)
+}
+
+// BeforeFrame takes a snapshot every REWIND_SNAPSHOT_INTERVAL frames, or
+// restores the most recent one while rewinding
+func (ui *UI) BeforeFrame(console hardware.Console) (bool, error) {
+	if ui.rewind == nil {
+		return true, nil
+	}
+
+	if ui.rewinding.Load() {
+		if ui.rewind.Len() == 0 {
+			// Nothing further back to rewind to
+			return false, nil
+		}
+
+		snapshot, err := ui.rewind.Pop()
+		if err != nil {
+			return false, fmt.Errorf("rewinding: %w", err)
+		}
+
+		if err := console.LoadState(bytes.NewReader(snapshot)); err != nil {
+			return false, fmt.Errorf("rewinding: %w", err)
+		}
+
+		ui.rewindFrames = 0
+
+		return false, nil
+	}
+
+	if ui.rewindFrames%REWIND_SNAPSHOT_INTERVAL == 0 {
+		ui.rewindSnapshot.Reset()
+		if err := console.SaveState(&ui.rewindSnapshot); err != nil {
+			return false, fmt.Errorf("taking rewind snapshot: %w", err)
+		}
+
+		if err := ui.rewind.Push(ui.rewindSnapshot.Bytes()); err != nil {
+			return false, fmt.Errorf("taking rewind snapshot: %w", err)
+		}
+	}
+	ui.rewindFrames++
+
+	return true, nil
+}
+
 func (ui *UI) Update() error {
 	var inputs devices.JoypadInputs
 
@@ -108,6 +200,14 @@ func (ui *UI) Update() error {
 		inputs.Right = true
 	}
 
+	ui.rewinding.Store(ebiten.IsKeyPressed(ebiten.KeyBackspace))
+
+	// Only the latest inputs matter, as the console picks them up between
+	// frames. Replace any it hasn't gotten to yet.
+	select {
+	case <-ui.inputChan:
+	default:
+	}
 	ui.inputChan <- inputs
 
 	requestFrame := struct{}{}
@@ -150,7 +250,7 @@ func (ui *UI) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight
 	return int(FB_WIDTH * scale), int(FB_HEIGHT * scale)
 }
 
-func (ui *UI) Run(console hardware.Console) error {
+func (ui *UI) Run(console hardware.Console, opts ...hardware.RunOption) error {
 	ebiten.SetWindowSize(480, 432)
 	ebiten.SetWindowTitle("gogo-gb, the go-getting GB emulator")
 	ebiten.SetVsyncEnabled(true)
@@ -163,9 +263,22 @@ func (ui *UI) Run(console hardware.Console) error {
 		return errors.New("console cannot be nil")
 	}
 
+	audioContext := audio.NewContext(ui.audioBuffer.SampleRate())
+	audioPlayer, err := audioContext.NewPlayer(ui.audioBuffer)
+	if err != nil {
+		return fmt.Errorf("initializing audio player: %w", err)
+	}
+	audioPlayer.SetBufferSize(AUDIO_PLAYER_BUFFER_LEN)
+	audioPlayer.Play()
+	ui.audioPlayer = audioPlayer
+
+	if ui.rewind != nil {
+		opts = append(opts, hardware.WithFrameHook(ui))
+	}
+
 	go func() {
 		ui.Log("starting console main loop")
-		if err := hardware.Run(console, ui); err != nil {
+		if err := hardware.Run(console, ui, opts...); err != nil {
 			ui.LogErr("unexpected error occurred during runtime: %v", err)
 
 			return
@@ -179,3 +292,9 @@ func (ui *UI) Run(console hardware.Console) error {
 
 	return ebiten.RunGame(ui)
 }
+
+func newUIAudioBuffer(sampleRate int) *devices.AudioBuffer {
+	capacity := int(int64(sampleRate) * int64(AUDIO_BUFFER_LEN) / int64(time.Second))
+
+	return devices.NewAudioBuffer(sampleRate, capacity)
+}
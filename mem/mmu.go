@@ -1,10 +1,35 @@
 package mem
 
+import (
+	"io"
+
+	"github.com/maxfierke/gogo-gb/savestate"
+)
+
+const (
+	MMU_PAGE_SIZE  = 0x100
+	MMU_PAGE_COUNT = 0x100
+)
+
 type MMU struct {
 	ram           []byte
 	handleCounter uint
 	handles       map[MemHandlerHandle]MemRegion
-	handlers      map[uint16][]MMUHandler
+	pages         [MMU_PAGE_COUNT]*mmuPage
+	unrestricted  bool
+
+	// Sits in front of every other handler for addresses up to priorityEnd,
+	// while set. It's checked before the page table, so pages w/o any other
+	// handlers stay on the fast path when it isn't.
+	priority    MemHandler
+	priorityEnd uint16
+}
+
+// mmuPage holds the handlers for each address in a page. Pages w/o any
+// handlers aren't allocated, so accesses to them go straight to RAM.
+type mmuPage struct {
+	handlers [MMU_PAGE_SIZE][]MMUHandler
+	count    int // Handlers registered across all addresses in the page
 }
 
 type MMUHandler struct {
@@ -112,7 +137,6 @@ func NewMMU(ram []byte) *MMU {
 		ram:           ram,
 		handleCounter: 0,
 		handles:       map[MemHandlerHandle]MemRegion{},
-		handlers:      map[uint16][]MMUHandler{},
 	}
 }
 
@@ -122,15 +146,17 @@ func (mmu *MMU) AddHandler(region MemRegion, handler MemHandler) MemHandlerHandl
 	mmu.handles[handle] = region
 
 	for addr := uint(region.Start); addr <= uint(region.End); addr++ {
-		addr16 := uint16(addr)
-		val, exist := mmu.handlers[addr16]
-
-		if !exist {
-			val = make([]MMUHandler, 0)
-			mmu.handlers[addr16] = val
+		page := mmu.pages[addr/MMU_PAGE_SIZE]
+		if page == nil {
+			page = &mmuPage{}
+			mmu.pages[addr/MMU_PAGE_SIZE] = page
 		}
 
-		mmu.handlers[addr16] = append(val, MMUHandler{handle: handle, handler: handler})
+		page.handlers[addr%MMU_PAGE_SIZE] = append(
+			page.handlers[addr%MMU_PAGE_SIZE],
+			MMUHandler{handle: handle, handler: handler},
+		)
+		page.count++
 	}
 
 	return handle
@@ -146,31 +172,102 @@ func (mmu *MMU) RemoveHandler(handle MemHandlerHandle) {
 	delete(mmu.handles, handle)
 
 	for addr := uint(region.Start); addr <= uint(region.End); addr++ {
-		addr16 := uint16(addr)
-		val, exist := mmu.handlers[addr16]
+		page := mmu.pages[addr/MMU_PAGE_SIZE]
+		if page == nil {
+			continue
+		}
 
-		if exist {
-			newVal := make([]MMUHandler, len(val)-1)
+		addrHandlers := page.handlers[addr%MMU_PAGE_SIZE]
+		newHandlers := make([]MMUHandler, 0, len(addrHandlers))
 
-			for i := range val {
-				if val[i].handle != handle {
-					newVal = append(newVal, val[i])
-				}
+		for i := range addrHandlers {
+			if addrHandlers[i].handle != handle {
+				newHandlers = append(newHandlers, addrHandlers[i])
 			}
+		}
+
+		page.count -= len(addrHandlers) - len(newHandlers)
 
-			mmu.handlers[addr16] = newVal
+		if len(newHandlers) == 0 {
+			newHandlers = nil
+		}
+		page.handlers[addr%MMU_PAGE_SIZE] = newHandlers
+
+		// Back to the fast path, once the page is plain RAM again
+		if page.count == 0 {
+			mmu.pages[addr/MMU_PAGE_SIZE] = nil
 		}
 	}
 }
 
+// SetPriorityHandler puts handler in front of every other handler for addresses
+// up to end, until ClearPriorityHandler is called. Unlike AddHandler, it's cheap
+// to set & clear, for handlers that only need to intercept accesses for a short
+// while, e.g. during OAM DMA.
+func (mmu *MMU) SetPriorityHandler(end uint16, handler MemHandler) {
+	mmu.priority = handler
+	mmu.priorityEnd = end
+}
+
+func (mmu *MMU) ClearPriorityHandler() {
+	mmu.priority = nil
+	mmu.priorityEnd = 0
+}
+
+// LoadState restores the contents of RAM. Handlers are considered part of the
+// console's configuration, and aren't included.
+func (mmu *MMU) LoadState(r io.Reader) error {
+	return savestate.Read(r, mmu.ram)
+}
+
+func (mmu *MMU) SaveState(w io.Writer) error {
+	return savestate.Write(w, mmu.ram)
+}
+
+// IsUnrestricted reports whether the access in progress is a Peek or Poke,
+// which handlers should let through regardless of any restrictions on the CPU,
+// e.g. VRAM being inaccessible during mode 3
+func (mmu *MMU) IsUnrestricted() bool {
+	return mmu != nil && mmu.unrestricted
+}
+
+// Peek8 reads addr as Read8 does, but w/o the restrictions the CPU has on
+// what it can read, so that debuggers can inspect the real contents
+func (mmu *MMU) Peek8(addr uint16) byte {
+	mmu.unrestricted = true
+	defer func() { mmu.unrestricted = false }()
+
+	return mmu.Read8(addr)
+}
+
+func (mmu *MMU) Peek16(addr uint16) uint16 {
+	low := mmu.Peek8(addr)
+	high := mmu.Peek8(addr + 1)
+
+	return uint16(high)<<8 | uint16(low)
+}
+
+// Poke8 writes addr as Write8 does, but w/o the restrictions the CPU has on
+// what it can write, e.g. for DMA
+func (mmu *MMU) Poke8(addr uint16, value byte) {
+	mmu.unrestricted = true
+	defer func() { mmu.unrestricted = false }()
+
+	mmu.Write8(addr, value)
+}
+
 func (mmu *MMU) Read8(addr uint16) byte {
-	addrHandlers, handlersExist := mmu.handlers[addr]
+	if mmu.priority != nil && addr <= mmu.priorityEnd {
+		if memread := mmu.priority.OnRead(mmu, addr); !memread.passthrough {
+			return memread.replacement
+		}
+	}
 
-	if handlersExist {
-		for i := range addrHandlers {
-			handler := addrHandlers[i]
+	if page := mmu.pages[addr/MMU_PAGE_SIZE]; page != nil {
+		addrHandlers := page.handlers[addr%MMU_PAGE_SIZE]
 
-			memread := handler.handler.OnRead(mmu, addr)
+		for i := range addrHandlers {
+			memread := addrHandlers[i].handler.OnRead(mmu, addr)
 
 			if !memread.passthrough {
 				return memread.replacement
@@ -182,13 +279,25 @@ func (mmu *MMU) Read8(addr uint16) byte {
 }
 
 func (mmu *MMU) Write8(addr uint16, value byte) {
-	addrHandlers, handlersExist := mmu.handlers[addr]
+	if mmu.priority != nil && addr <= mmu.priorityEnd {
+		memwrite := mmu.priority.OnWrite(mmu, addr, value)
 
-	if handlersExist {
-		for i := range addrHandlers {
-			handler := addrHandlers[i]
+		if memwrite.blocked {
+			return
+		}
 
-			memwrite := handler.handler.OnWrite(mmu, addr, value)
+		if !memwrite.passthrough {
+			mmu.ram[addr] = memwrite.replacement
+
+			return
+		}
+	}
+
+	if page := mmu.pages[addr/MMU_PAGE_SIZE]; page != nil {
+		addrHandlers := page.handlers[addr%MMU_PAGE_SIZE]
+
+		for i := range addrHandlers {
+			memwrite := addrHandlers[i].handler.OnWrite(mmu, addr, value)
 
 			if memwrite.blocked {
 				return
@@ -218,11 +327,11 @@ func (mmu *MMU) Write16(addr uint16, value uint16) {
 }
 
 func (mmu *MMU) nextHandle() MemHandlerHandle {
-	handle := MemHandlerHandle{
-		val: mmu.handleCounter,
-	}
-
+	// Handles start at 1, so that the zero value is never a valid handle, and
+	// can safely be removed
 	mmu.handleCounter += 1
 
-	return handle
+	return MemHandlerHandle{
+		val: mmu.handleCounter,
+	}
 }
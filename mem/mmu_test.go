@@ -41,6 +41,25 @@ func (h *testWriteBlockHandler) OnWrite(mmu *MMU, addr uint16, value byte) MemWr
 	return WriteBlock()
 }
 
+// testRestrictedHandler blocks all access, except Peeks & Pokes
+type testRestrictedHandler struct{}
+
+func (h *testRestrictedHandler) OnRead(mmu *MMU, addr uint16) MemRead {
+	if mmu.IsUnrestricted() {
+		return ReadPassthrough()
+	}
+
+	return ReadReplace(0xFF)
+}
+
+func (h *testRestrictedHandler) OnWrite(mmu *MMU, addr uint16, value byte) MemWrite {
+	if mmu.IsUnrestricted() {
+		return WritePassthrough()
+	}
+
+	return WriteBlock()
+}
+
 func TestMmuBasicReads(t *testing.T) {
 	assert := assert.New(t)
 
@@ -143,3 +162,143 @@ func TestMmuWriteHandlerBlock(t *testing.T) {
 	assert.Equal(byte(0x00), ram[0x103])
 	assert.Equal(byte(0x22), ram[0x201])
 }
+
+func TestMmuPeekPoke(t *testing.T) {
+	assert := assert.New(t)
+
+	ram := make([]byte, 0xFFFF)
+	mmu := NewMMU(ram)
+
+	mmu.AddHandler(MemRegion{Start: 0x100, End: 0x200}, &testRestrictedHandler{})
+
+	ram[0x103] = 0x11
+	ram[0x104] = 0x22
+
+	assert.Equal(byte(0xFF), mmu.Read8(0x103))
+	assert.Equal(byte(0x11), mmu.Peek8(0x103))
+	assert.Equal(uint16(0x2211), mmu.Peek16(0x103))
+
+	mmu.Write8(0x105, 0x33)
+	assert.Equal(byte(0x00), ram[0x105])
+
+	mmu.Poke8(0x105, 0x33)
+	assert.Equal(byte(0x33), ram[0x105])
+
+	// Restrictions apply again afterwards
+	assert.False(mmu.IsUnrestricted())
+	assert.Equal(byte(0xFF), mmu.Read8(0x105))
+}
+
+func TestMmuRemoveHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	ram := make([]byte, 0x10000)
+	mmu := NewMMU(ram)
+
+	ram[0x103] = 0x11
+
+	replacement := mmu.AddHandler(MemRegion{Start: 0x100, End: 0x200}, &testReplacementHandler{})
+	overlay := mmu.AddHandler(MemRegion{Start: 0x0000, End: 0xFFFF}, &testPassthroughHandler{})
+	assert.Equal(handlerReadReplaceValue, mmu.Read8(0x103))
+
+	mmu.RemoveHandler(replacement)
+	assert.Equal(byte(0x11), mmu.Read8(0x103))
+
+	mmu.RemoveHandler(overlay)
+	assert.Equal(byte(0x11), mmu.Read8(0x103))
+
+	// Removing a handle that's already gone, or was never added, is a no-op
+	mmu.RemoveHandler(replacement)
+	mmu.RemoveHandler(MemHandlerHandle{})
+
+	blocked := mmu.AddHandler(MemRegion{Start: 0x100, End: 0x100}, &testWriteBlockHandler{})
+	mmu.RemoveHandler(MemHandlerHandle{})
+	mmu.Write8(0x100, 0x22)
+	assert.Equal(byte(0x00), ram[0x100])
+
+	mmu.RemoveHandler(blocked)
+	mmu.Write8(0x100, 0x22)
+	assert.Equal(byte(0x22), ram[0x100])
+}
+
+func TestMmuPriorityHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	ram := make([]byte, 0x10000)
+	mmu := NewMMU(ram)
+	mmu.AddHandler(MemRegion{Start: 0x8000, End: 0x80FF}, &testPassthroughHandler{})
+
+	mmu.SetPriorityHandler(0x80FF, &testReplacementHandler{})
+
+	// It goes in front of the other handlers, up to where it ends
+	assert.Equal(handlerReadReplaceValue, mmu.Read8(0x0100))
+	assert.Equal(handlerReadReplaceValue, mmu.Read8(0x8000))
+	assert.Equal(byte(0x00), mmu.Read8(0x8100))
+
+	mmu.Write8(0x8000, 0x42)
+	assert.Equal(handlerWriteReplaceValue, ram[0x8000])
+
+	mmu.SetPriorityHandler(0x80FF, &testWriteBlockHandler{})
+	mmu.Write8(0x8001, 0x42)
+	assert.Equal(byte(0x00), ram[0x8001])
+
+	mmu.ClearPriorityHandler()
+	mmu.Write8(0x0100, 0x42)
+	assert.Equal(byte(0x42), mmu.Read8(0x0100))
+}
+
+// newBenchMMU has handlers laid out like a console's, w/ a debugger-style
+// handler over the whole address space if overlay is set
+func newBenchMMU(overlay bool) *MMU {
+	mmu := NewMMU(make([]byte, 0x10000))
+
+	mmu.AddHandler(MemRegion{Start: 0x0000, End: 0x7FFF}, &testReplacementHandler{})
+	mmu.AddHandler(MemRegion{Start: 0xA000, End: 0xBFFF}, &testReplacementHandler{})
+	mmu.AddHandler(MemRegion{Start: 0xFF00, End: 0xFF7F}, &testPassthroughHandler{})
+
+	if overlay {
+		mmu.AddHandler(MemRegion{Start: 0x0000, End: 0xFFFF}, &testPassthroughHandler{})
+	}
+
+	return mmu
+}
+
+func BenchmarkMmuRead8(b *testing.B) {
+	benchmarks := []struct {
+		name     string
+		addr     uint16
+		overlay  bool
+		priority bool
+	}{
+		{name: "ROM", addr: 0x0150},
+		{name: "WRAM", addr: 0xC000},
+		{name: "HRAM", addr: 0xFF80},
+		{name: "WRAM w/ overlay", addr: 0xC000, overlay: true},
+		{name: "WRAM w/ priority handler", addr: 0xC000, priority: true},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			mmu := newBenchMMU(bm.overlay)
+			if bm.priority {
+				mmu.SetPriorityHandler(0xFEFF, &testPassthroughHandler{})
+			}
+
+			for b.Loop() {
+				for i := range uint16(0x100) {
+					mmu.Read8(bm.addr + i%0x40)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMmuWrite8(b *testing.B) {
+	mmu := newBenchMMU(false)
+
+	for b.Loop() {
+		for i := range uint16(0x100) {
+			mmu.Write8(0xC000+i, byte(i))
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package mem
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+
+	"github.com/maxfierke/gogo-gb/savestate"
+)
 
 const (
 	REG_WRAM_SVBK = 0xFF70
@@ -21,7 +26,10 @@ type WRAM struct {
 	wram    []byte
 }
 
-var _ MemHandler = (*WRAM)(nil)
+var (
+	_ MemHandler           = (*WRAM)(nil)
+	_ savestate.Serializer = (*WRAM)(nil)
+)
 
 func NewWRAM() *WRAM {
 	return &WRAM{
@@ -29,6 +37,14 @@ func NewWRAM() *WRAM {
 	}
 }
 
+func (w *WRAM) LoadState(r io.Reader) error {
+	return savestate.Read(r, &w.curBank, w.wram)
+}
+
+func (w *WRAM) SaveState(wr io.Writer) error {
+	return savestate.Write(wr, &w.curBank, w.wram)
+}
+
 func (w *WRAM) OnRead(mmu *MMU, addr uint16) MemRead {
 	if addr == REG_WRAM_SVBK {
 		return ReadReplace(max(w.curBank, 1) & REG_WRAM_SVBK_SEL_MASK)
@@ -0,0 +1,238 @@
+package movie
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/maxfierke/gogo-gb/devices"
+	"github.com/maxfierke/gogo-gb/hardware"
+	"github.com/maxfierke/gogo-gb/savestate"
+)
+
+const (
+	// FILE_MAGIC identifies a .ggm movie file
+	FILE_MAGIC = "GOGOGBMV"
+
+	// FILE_VERSION is bumped for any incompatible change to the movie format
+	FILE_VERSION uint16 = 1
+)
+
+const (
+	FLAG_FRAME_HASHES uint8 = 1 << iota
+)
+
+var (
+	ErrNotMovieFile       = errors.New("movie: not a movie file")
+	ErrUnsupportedVersion = errors.New("movie: unsupported format version")
+	ErrCartridgeMismatch  = errors.New("movie: recorded with a different cartridge")
+	ErrModelMismatch      = errors.New("movie: recorded with a different console model")
+	ErrBootROMMismatch    = errors.New("movie: recorded with a different boot ROM")
+	ErrPlaybackDiverged   = errors.New("movie: playback diverged from recording")
+)
+
+// Header describes everything needed to reproduce a recording from power-on:
+// the cartridge, the console & boot ROM it ran on, the contents of SRAM, and
+// the time the cartridge's RTC (if any) started from
+type Header struct {
+	Version        uint16
+	Title          string
+	GlobalChecksum uint16
+	Model          string
+	BootROMHash    [sha256.Size]byte // All zeros if no boot ROM was used
+	RTCSeed        time.Time
+	SRAM           []byte // As written by Console.Save
+	FrameHashes    bool
+}
+
+// NewHeader describes a recording about to start on console. The console
+// should be freshly powered-on, w/ any save loaded & UseEmulatedClock called
+// w/ rtcSeed. bootROM may be nil if no boot ROM is in use.
+func NewHeader(console hardware.Console, bootROM []byte, rtcSeed time.Time) (Header, error) {
+	var sram bytes.Buffer
+	if err := console.Save(&sram); err != nil {
+		return Header{}, fmt.Errorf("saving SRAM: %w", err)
+	}
+
+	cartHeader := console.CartridgeHeader()
+	header := Header{
+		Title:          cartHeader.Title,
+		GlobalChecksum: cartHeader.GlobalChecksum,
+		Model:          string(console.Model()),
+		RTCSeed:        rtcSeed,
+		SRAM:           sram.Bytes(),
+	}
+
+	if bootROM != nil {
+		header.BootROMHash = sha256.Sum256(bootROM)
+	}
+
+	return header, nil
+}
+
+// ReadHeader reads & validates the movie header, leaving r positioned at the
+// start of the recorded frames
+func ReadHeader(r io.Reader) (Header, error) {
+	var header Header
+
+	var magic [len(FILE_MAGIC)]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return header, fmt.Errorf("%w: %w", ErrNotMovieFile, err)
+	}
+
+	if string(magic[:]) != FILE_MAGIC {
+		return header, ErrNotMovieFile
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &header.Version); err != nil {
+		return header, fmt.Errorf("reading version: %w", err)
+	}
+
+	if header.Version == 0 || header.Version > FILE_VERSION {
+		return header, fmt.Errorf("%w: %d", ErrUnsupportedVersion, header.Version)
+	}
+
+	title, err := savestate.ReadBytes(r)
+	if err != nil {
+		return header, fmt.Errorf("reading title: %w", err)
+	}
+	header.Title = string(title)
+
+	model, err := savestate.ReadBytes(r)
+	if err != nil {
+		return header, fmt.Errorf("reading model: %w", err)
+	}
+	header.Model = string(model)
+
+	var (
+		rtcSeed int64
+		flags   uint8
+	)
+
+	err = savestate.Read(r, &header.GlobalChecksum, &header.BootROMHash, &rtcSeed, &flags)
+	if err != nil {
+		return header, fmt.Errorf("reading header: %w", err)
+	}
+	header.RTCSeed = time.Unix(0, rtcSeed)
+	header.FrameHashes = flags&FLAG_FRAME_HASHES != 0
+
+	header.SRAM, err = savestate.ReadBytes(r)
+	if err != nil {
+		return header, fmt.Errorf("reading SRAM: %w", err)
+	}
+
+	return header, nil
+}
+
+// WriteHeader writes the movie header. The Version field is ignored, and
+// FILE_VERSION is always written.
+func WriteHeader(w io.Writer, header Header) error {
+	magic := [len(FILE_MAGIC)]byte([]byte(FILE_MAGIC))
+
+	var flags uint8
+	if header.FrameHashes {
+		flags |= FLAG_FRAME_HASHES
+	}
+
+	return savestate.Write(w,
+		magic,
+		FILE_VERSION,
+		[]byte(header.Title),
+		[]byte(header.Model),
+		header.GlobalChecksum,
+		header.BootROMHash,
+		header.RTCSeed.UnixNano(),
+		flags,
+		header.SRAM,
+	)
+}
+
+// Verify checks that console & bootROM match those the movie was recorded
+// with. bootROM may be nil if no boot ROM is in use.
+func (header Header) Verify(console hardware.Console, bootROM []byte) error {
+	cartHeader := console.CartridgeHeader()
+	if header.Title != cartHeader.Title || header.GlobalChecksum != cartHeader.GlobalChecksum {
+		return fmt.Errorf(
+			"%w: movie is for %q (0x%04X)",
+			ErrCartridgeMismatch,
+			strings.TrimRight(header.Title, "\x00"),
+			header.GlobalChecksum,
+		)
+	}
+
+	if header.Model != string(console.Model()) {
+		return fmt.Errorf("%w: movie is for %s", ErrModelMismatch, header.Model)
+	}
+
+	var bootROMHash [sha256.Size]byte
+	if bootROM != nil {
+		bootROMHash = sha256.Sum256(bootROM)
+	}
+
+	if bootROMHash != header.BootROMHash {
+		return ErrBootROMMismatch
+	}
+
+	return nil
+}
+
+func encodeInputs(inputs devices.JoypadInputs) uint8 {
+	var encoded uint8
+
+	for i, pressed := range []bool{
+		inputs.A,
+		inputs.B,
+		inputs.Select,
+		inputs.Start,
+		inputs.Right,
+		inputs.Left,
+		inputs.Up,
+		inputs.Down,
+	} {
+		if pressed {
+			encoded |= 1 << i
+		}
+	}
+
+	return encoded
+}
+
+func decodeInputs(encoded uint8) devices.JoypadInputs {
+	pressed := func(i int) bool {
+		return encoded&(1<<i) != 0
+	}
+
+	return devices.JoypadInputs{
+		A:      pressed(0),
+		B:      pressed(1),
+		Select: pressed(2),
+		Start:  pressed(3),
+		Right:  pressed(4),
+		Left:   pressed(5),
+		Up:     pressed(6),
+		Down:   pressed(7),
+	}
+}
+
+func hashFrame(frame image.Image) uint64 {
+	hash := fnv.New64a()
+	bounds := frame.Bounds()
+
+	pixel := make([]byte, 0, 4)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := frame.At(x, y).RGBA()
+			pixel = append(pixel[:0], byte(r>>8), byte(g>>8), byte(b>>8), byte(a>>8))
+			_, _ = hash.Write(pixel)
+		}
+	}
+
+	return hash.Sum64()
+}
@@ -0,0 +1,104 @@
+package movie
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/maxfierke/gogo-gb/devices"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFrame(shade uint8) image.Image {
+	frame := image.NewRGBA(image.Rect(0, 0, 160, 144))
+	frame.Set(80, 72, color.RGBA{R: shade, G: shade, B: shade, A: 0xFF})
+
+	return frame
+}
+
+func TestHeader(t *testing.T) {
+	assert := assert.New(t)
+
+	header := Header{
+		Title:          "TEST",
+		GlobalChecksum: 0x1234,
+		Model:          "cgb",
+		BootROMHash:    [32]byte{0xAB, 0xCD},
+		RTCSeed:        time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC),
+		SRAM:           []byte{1, 2, 3},
+		FrameHashes:    true,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteHeader(&buf, header))
+
+	readHeader, err := ReadHeader(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(FILE_VERSION, readHeader.Version)
+	assert.Equal(header.Title, readHeader.Title)
+	assert.Equal(header.GlobalChecksum, readHeader.GlobalChecksum)
+	assert.Equal(header.Model, readHeader.Model)
+	assert.Equal(header.BootROMHash, readHeader.BootROMHash)
+	assert.True(header.RTCSeed.Equal(readHeader.RTCSeed))
+	assert.Equal(header.SRAM, readHeader.SRAM)
+	assert.True(readHeader.FrameHashes)
+
+	_, err = ReadHeader(bytes.NewReader([]byte("GOGOGBSS")))
+	assert.ErrorIs(err, ErrNotMovieFile)
+}
+
+func TestRecordAndPlay(t *testing.T) {
+	assert := assert.New(t)
+
+	frames := []devices.JoypadInputs{
+		{},
+		{A: true, Right: true},
+		{Start: true},
+		{B: true, Select: true, Up: true},
+		{Left: true, Down: true},
+	}
+
+	var movie bytes.Buffer
+	rec, err := NewRecorder(&movie, Header{FrameHashes: true})
+	require.NoError(t, err)
+
+	for i, inputs := range frames {
+		require.NoError(t, rec.RecordFrame(inputs, testFrame(uint8(i))))
+	}
+	require.NoError(t, rec.Close())
+	assert.Equal(len(frames), rec.Frames())
+
+	player, err := NewPlayer(&movie)
+	require.NoError(t, err)
+
+	for i, expected := range frames {
+		inputs, err := player.NextFrame()
+		require.NoError(t, err)
+		assert.Equal(expected, inputs)
+
+		// Diverge from the 4th frame onwards
+		shade := uint8(i)
+		if i >= 3 {
+			shade = 0xFF
+		}
+
+		err = player.VerifyFrame(testFrame(shade))
+		if i == 3 {
+			assert.ErrorIs(err, ErrPlaybackDiverged)
+		} else {
+			assert.NoError(err)
+		}
+	}
+
+	_, err = player.NextFrame()
+	assert.ErrorIs(err, io.EOF)
+
+	divergedAt, diverged := player.DivergedAt()
+	assert.True(diverged)
+	assert.Equal(4, divergedAt)
+}
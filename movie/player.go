@@ -0,0 +1,95 @@
+package movie
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/maxfierke/gogo-gb/devices"
+	"github.com/maxfierke/gogo-gb/hardware"
+	"github.com/maxfierke/gogo-gb/savestate"
+)
+
+// Player reads back the inputs for each frame of a movie. If the movie was
+// recorded w/ frame hashes, the first frame that doesn't match the recording
+// is reported by VerifyFrame.
+type Player struct {
+	r      *bufio.Reader
+	header Header
+	frames int
+
+	expectedHash uint64
+	divergedAt   int
+}
+
+var _ hardware.InputPlayer = (*Player)(nil)
+
+// NewPlayer reads the movie header from r & returns a Player for the frames
+// that follow it
+func NewPlayer(r io.Reader) (*Player, error) {
+	player := &Player{r: bufio.NewReader(r)}
+
+	header, err := ReadHeader(player.r)
+	if err != nil {
+		return nil, fmt.Errorf("reading movie header: %w", err)
+	}
+	player.header = header
+
+	return player, nil
+}
+
+// DivergedAt returns the first frame which didn't match the recording, if
+// any. Frames are numbered from 1.
+func (p *Player) DivergedAt() (int, bool) {
+	return p.divergedAt, p.divergedAt != 0
+}
+
+// Frames returns the number of frames played back so far
+func (p *Player) Frames() int {
+	return p.frames
+}
+
+func (p *Player) Header() Header {
+	return p.header
+}
+
+func (p *Player) NextFrame() (devices.JoypadInputs, error) {
+	encoded, err := p.r.ReadByte()
+	if err != nil {
+		return devices.JoypadInputs{}, err
+	}
+
+	if p.header.FrameHashes {
+		err := savestate.Read(p.r, &p.expectedHash)
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		if err != nil {
+			return devices.JoypadInputs{}, fmt.Errorf("reading frame %d hash: %w", p.frames+1, err)
+		}
+	}
+
+	p.frames++
+
+	return decodeInputs(encoded), nil
+}
+
+// VerifyFrame checks the last frame played back against the recording. Only
+// the first divergence is reported, as every frame after it is likely to
+// differ too.
+func (p *Player) VerifyFrame(frame image.Image) error {
+	if !p.header.FrameHashes || p.divergedAt != 0 {
+		return nil
+	}
+
+	if hashFrame(frame) != p.expectedHash {
+		p.divergedAt = p.frames
+
+		return fmt.Errorf("%w at frame %d", ErrPlaybackDiverged, p.frames)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package movie
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/maxfierke/gogo-gb/devices"
+	"github.com/maxfierke/gogo-gb/hardware"
+	"github.com/maxfierke/gogo-gb/savestate"
+)
+
+// Recorder writes the inputs for each frame to a movie, optionally along w/ a
+// hash of each frame drawn, so that playback can detect when it diverges
+type Recorder struct {
+	w           *bufio.Writer
+	frameHashes bool
+	frames      int
+}
+
+var _ hardware.InputRecorder = (*Recorder)(nil)
+
+// NewRecorder writes header to w & returns a Recorder for the frames that
+// follow it. Close must be called to flush any buffered frames.
+func NewRecorder(w io.Writer, header Header) (*Recorder, error) {
+	rec := &Recorder{
+		w:           bufio.NewWriter(w),
+		frameHashes: header.FrameHashes,
+	}
+
+	if err := WriteHeader(rec.w, header); err != nil {
+		return nil, fmt.Errorf("writing movie header: %w", err)
+	}
+
+	return rec, nil
+}
+
+func (rec *Recorder) Close() error {
+	return rec.w.Flush()
+}
+
+// Frames returns the number of frames recorded so far
+func (rec *Recorder) Frames() int {
+	return rec.frames
+}
+
+func (rec *Recorder) RecordFrame(inputs devices.JoypadInputs, frame image.Image) error {
+	if err := rec.w.WriteByte(encodeInputs(inputs)); err != nil {
+		return fmt.Errorf("writing frame %d: %w", rec.frames+1, err)
+	}
+
+	if rec.frameHashes {
+		if err := savestate.Write(rec.w, hashFrame(frame)); err != nil {
+			return fmt.Errorf("writing frame %d hash: %w", rec.frames+1, err)
+		}
+	}
+
+	rec.frames++
+
+	return nil
+}
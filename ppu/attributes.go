@@ -1,6 +1,11 @@
 package ppu
 
-import "github.com/maxfierke/gogo-gb/bits"
+import (
+	"io"
+
+	"github.com/maxfierke/gogo-gb/bits"
+	"github.com/maxfierke/gogo-gb/savestate"
+)
 
 const (
 	BG_ATTR_BIT_VRAM_BANK   = 3
@@ -131,10 +136,27 @@ type OAM struct {
 	objectData [OAM_MAX_OBJECT_COUNT]ObjectData
 }
 
+var _ savestate.Serializer = (*OAM)(nil)
+
 func NewOAM() *OAM {
 	return &OAM{}
 }
 
+// LoadState restores OAM, and re-decodes the object data from it
+func (o *OAM) LoadState(r io.Reader) error {
+	var raw [OAM_SIZE]byte
+
+	if err := savestate.Read(r, &raw); err != nil {
+		return err
+	}
+
+	for oamAddr, value := range raw {
+		o.Write(uint8(oamAddr), value)
+	}
+
+	return nil
+}
+
 func (o *OAM) Objects() []ObjectData {
 	return o.objectData[:]
 }
@@ -143,6 +165,10 @@ func (o *OAM) Read(oamAddr uint8) byte {
 	return o.raw[oamAddr]
 }
 
+func (o *OAM) SaveState(w io.Writer) error {
+	return savestate.Write(w, &o.raw)
+}
+
 func (o *OAM) Write(oamAddr uint8, value byte) {
 	o.raw[oamAddr] = value
 	o.writeObj(oamAddr, value)
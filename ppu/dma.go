@@ -2,31 +2,136 @@ package ppu
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
+	"github.com/maxfierke/gogo-gb/scheduler"
 )
 
 const (
 	REG_DMA_OAM uint16 = 0xFF46
+
+	// DMA_START_DELAY is how many M-cycles pass between FF46 being written &
+	// the transfer starting
+	DMA_START_DELAY = 1
+
+	// DMA_CYCLES_PER_BYTE is how many cycles each byte takes to copy (1 M-cycle).
+	// All 160 bytes take 160 M-cycles.
+	DMA_CYCLES_PER_BYTE = 4
+
+	// DMA_BUS_END is the end of the region the DMA sits in front of, to emulate
+	// bus conflicts. I/O registers & HRAM are on the CPU's internal bus, and can
+	// always be accessed.
+	DMA_BUS_END = 0xFEFF
 )
 
-type dmaRequest struct {
-	addr  uint16
-	value byte
-}
+// dmaBus identifies which memory bus an address is on, to tell whether the CPU
+// & DMA are in conflict
+type dmaBus uint8
+
+const (
+	dmaBusExternal dmaBus = iota
+	dmaBusVRAM
+	dmaBusWRAM // CGB only. WRAM is on the external bus on DMG.
+	dmaBusOAM
+)
 
+// DMA copies 160 bytes to OAM, one per M-cycle. While it's copying, OAM is
+// inaccessible to the CPU, and CPU accesses to the bus being copied from
+// conflict, reading whichever byte was last copied instead. It only sits in
+// front of the rest of memory while it's copying, so it doesn't slow down
+// accesses the rest of the time.
 type DMA struct {
-	enabled    bool
-	clock      uint
-	pendingDMA []*dmaRequest
+	active bool
+	source uint16
+	index  uint16 // Bytes copied so far
+	value  byte   // Byte last copied, seen by the CPU in a bus conflict
+
+	// A write to FF46 starts a transfer after DMA_START_DELAY. A transfer
+	// already in progress continues until then.
+	startDelay    uint8
+	pendingSource uint16
+
+	clock uint
+	color bool
+	mmu   *mem.MMU // Where to emulate bus conflicts, if attached
 }
 
-var _ mem.MemHandler = (*DMA)(nil)
+var (
+	_ mem.MemHandler      = (*DMA)(nil)
+	_ savestate.Versioned = (*DMA)(nil)
+)
 
 func NewDMA() *DMA {
-	return &DMA{
-		pendingDMA: make([]*dmaRequest, 0, 160),
+	return &DMA{}
+}
+
+// AttachMemHandlers has the DMA emulate bus conflicts on mmu while copying. FF46
+// is mapped separately, so it can be synced w/ the scheduler.
+func (d *DMA) AttachMemHandlers(mmu *mem.MMU) {
+	d.mmu = mmu
+	d.syncBus()
+}
+
+// EnableColor gives WRAM its own bus, as on CGB
+func (d *DMA) EnableColor() {
+	d.color = true
+}
+
+// IsActive reports whether a transfer is in progress
+func (d *DMA) IsActive() bool {
+	return d.active
+}
+
+func (d *DMA) LoadState(r io.Reader) error {
+	err := savestate.Read(r, d.stateFields()...)
+	d.syncBus()
+
+	return err
+}
+
+// LoadStateVersion migrates state from before DMA timing was emulated (version
+// 0), which copied all 160 bytes up-front & wrote them to OAM at the end. That
+// doesn't record where they were copied from, so a transfer in progress can't
+// be resumed, & is dropped. Games run OAM DMA every frame, so the next one
+// makes up for it.
+func (d *DMA) LoadStateVersion(r io.Reader, version uint8) error {
+	var (
+		enabled    bool
+		clock      uint
+		pendingLen uint8
+	)
+
+	if err := savestate.Read(r, &enabled, &clock, &pendingLen); err != nil {
+		return err
+	}
+
+	for range pendingLen {
+		var (
+			addr  uint16
+			value byte
+		)
+
+		if err := savestate.Read(r, &addr, &value); err != nil {
+			return err
+		}
 	}
+
+	*d = DMA{color: d.color, mmu: d.mmu}
+	d.syncBus()
+
+	return nil
+}
+
+// NextEvent returns how many cycles until the next byte is copied, or the
+// pending transfer starts
+func (d *DMA) NextEvent() uint {
+	if !d.active && d.startDelay == 0 {
+		return scheduler.NEVER
+	}
+
+	return DMA_CYCLES_PER_BYTE - d.clock
 }
 
 func (d *DMA) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
@@ -34,50 +139,144 @@ func (d *DMA) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 		return mem.ReadPassthrough()
 	}
 
+	if addr <= DMA_BUS_END {
+		if d.isConflicting(mmu, addr) {
+			if d.bus(addr) == dmaBusOAM {
+				return mem.ReadReplace(0xFF)
+			}
+
+			return mem.ReadReplace(d.value)
+		}
+
+		return mem.ReadPassthrough()
+	}
+
 	panic(fmt.Sprintf("Attempting to read @ 0x%04X, which is out-of-bounds for DMA", addr))
 }
 
 func (d *DMA) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 	if addr == REG_DMA_OAM {
-		if !d.enabled {
-			srcAddrStart := uint16(value) << 8
-
-			for oamAddr := range uint8(160) {
-				srcAddr := srcAddrStart + uint16(oamAddr)
-				copiedValue := mmu.Read8(srcAddr)
-
-				d.pendingDMA = append(d.pendingDMA,
-					&dmaRequest{
-						addr:  OAM_START + uint16(oamAddr),
-						value: copiedValue,
-					},
-				)
-			}
-			d.enabled = true
-		}
+		d.pendingSource = uint16(value) << 8
+		d.startDelay = DMA_START_DELAY
 
 		// Reads should return last written value, so we'll pass it through it
 		return mem.WritePassthrough()
 	}
 
+	if addr <= DMA_BUS_END {
+		if d.isConflicting(mmu, addr) {
+			return mem.WriteBlock()
+		}
+
+		return mem.WritePassthrough()
+	}
+
 	panic(fmt.Sprintf("Attempting to write 0x%02X @ 0x%04X, which is out-of-bounds for DMA", value, addr))
 }
 
+func (d *DMA) SaveState(w io.Writer) error {
+	return savestate.Write(w, d.stateFields()...)
+}
+
+func (d *DMA) StateVersion() uint8 {
+	return 1
+}
+
 func (d *DMA) Step(mmu *mem.MMU, cycles uint8) {
-	if !d.enabled {
+	if !d.active && d.startDelay == 0 {
 		return
 	}
 
 	d.clock += uint(cycles)
 
-	if d.clock >= 640 {
+	for d.clock >= DMA_CYCLES_PER_BYTE {
+		d.clock -= DMA_CYCLES_PER_BYTE
+		d.tick(mmu)
+	}
+
+	if !d.active && d.startDelay == 0 {
 		d.clock = 0
+	}
+}
+
+func (d *DMA) bus(addr uint16) dmaBus {
+	switch {
+	case addr >= VRAM_START && addr <= VRAM_END:
+		return dmaBusVRAM
+	case addr >= OAM_START:
+		return dmaBusOAM
+	case d.color && addr >= 0xC000 && addr <= 0xFDFF:
+		return dmaBusWRAM
+	default:
+		return dmaBusExternal
+	}
+}
+
+// isConflicting reports whether a CPU access to addr conflicts with the
+// transfer in progress. Debuggers (& the DMA itself) are let through.
+func (d *DMA) isConflicting(mmu *mem.MMU, addr uint16) bool {
+	if !d.active || mmu.IsUnrestricted() {
+		return false
+	}
 
-		for _, request := range d.pendingDMA {
-			mmu.Write8(request.addr, request.value)
+	bus := d.bus(addr)
+
+	return bus == dmaBusOAM || bus == d.bus(d.source)
+}
+
+func (d *DMA) stateFields() []any {
+	return []any{
+		&d.active,
+		&d.source,
+		&d.index,
+		&d.value,
+		&d.startDelay,
+		&d.pendingSource,
+		&d.clock,
+	}
+}
+
+// syncBus puts the DMA in front of the rest of memory while it's copying, &
+// takes it back out once it's done
+func (d *DMA) syncBus() {
+	if d.mmu == nil {
+		return
+	}
+
+	if d.active {
+		d.mmu.SetPriorityHandler(DMA_BUS_END, d)
+	} else {
+		d.mmu.ClearPriorityHandler()
+	}
+}
+
+// tick runs the DMA for one M-cycle
+func (d *DMA) tick(mmu *mem.MMU) {
+	if d.active {
+		d.value = mmu.Peek8(d.source + d.index)
+		mmu.Poke8(OAM_START+d.index, d.value)
+		d.index++
+
+		if d.index == OAM_SIZE {
+			d.active = false
+			d.syncBus()
 		}
+	}
+
+	if d.startDelay > 0 {
+		d.startDelay--
 
-		d.pendingDMA = make([]*dmaRequest, 0, 160)
-		d.enabled = false
+		if d.startDelay == 0 {
+			d.active = true
+			d.source = d.pendingSource
+			d.index = 0
+
+			// Sources from 0xE000 up mirror WRAM, like echo RAM
+			if d.source >= 0xE000 {
+				d.source -= 0x2000
+			}
+
+			d.syncBus()
+		}
 	}
 }
@@ -0,0 +1,149 @@
+package ppu
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
+	"github.com/maxfierke/gogo-gb/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDMA() (*DMA, *mem.MMU) {
+	dma := NewDMA()
+	mmu := mem.NewMMU(make([]byte, 0x10000))
+	dma.AttachMemHandlers(mmu)
+	mmu.AddHandler(mem.MemRegion{Start: REG_DMA_OAM, End: REG_DMA_OAM}, dma)
+
+	for i := range uint16(OAM_SIZE) {
+		mmu.Write8(0xC000+i, byte(i))
+		mmu.Write8(0xD000+i, byte(0xFF-i))
+	}
+	mmu.Write8(0x4000, 0x42)
+
+	return dma, mmu
+}
+
+func stepDMA(dma *DMA, mmu *mem.MMU, mCycles uint16) {
+	for range mCycles {
+		dma.Step(mmu, DMA_CYCLES_PER_BYTE)
+	}
+}
+
+func TestDMATiming(t *testing.T) {
+	assert := assert.New(t)
+
+	dma, mmu := newTestDMA()
+
+	mmu.Write8(REG_DMA_OAM, 0xC0)
+	assert.False(dma.IsActive())
+
+	stepDMA(dma, mmu, DMA_START_DELAY)
+	assert.True(dma.IsActive())
+
+	// One byte per M-cycle
+	stepDMA(dma, mmu, 10)
+	assert.Equal(byte(9), mmu.Peek8(OAM_START+9))
+	assert.Equal(byte(0), mmu.Peek8(OAM_START+10))
+
+	stepDMA(dma, mmu, OAM_SIZE-10)
+	assert.False(dma.IsActive())
+	assert.Equal(byte(OAM_SIZE-1), mmu.Peek8(OAM_END))
+}
+
+func TestDMABusConflicts(t *testing.T) {
+	assert := assert.New(t)
+
+	dma, mmu := newTestDMA()
+
+	mmu.Write8(REG_DMA_OAM, 0xC0)
+	stepDMA(dma, mmu, DMA_START_DELAY+5)
+
+	// Reads on the same bus see the byte last copied
+	assert.Equal(byte(4), mmu.Read8(0x4000))
+	assert.Equal(byte(4), mmu.Read8(0xD000))
+	assert.Equal(byte(0xFF), mmu.Read8(OAM_START))
+
+	// Writes are dropped
+	mmu.Write8(0xC000, 0xAA)
+	assert.Equal(byte(0x00), mmu.Peek8(0xC000))
+
+	// VRAM is on its own bus, so is unaffected
+	mmu.Write8(VRAM_START, 0xAA)
+	assert.Equal(byte(0xAA), mmu.Read8(VRAM_START))
+
+	// Debuggers see the real contents
+	assert.Equal(byte(0x42), mmu.Peek8(0x4000))
+
+	// WRAM is on its own bus on CGB
+	dma.EnableColor()
+	assert.Equal(byte(0x42), mmu.Read8(0x4000))
+	assert.Equal(byte(4), mmu.Read8(0xD000))
+}
+
+func TestDMABusReleased(t *testing.T) {
+	assert := assert.New(t)
+
+	dma, mmu := newTestDMA()
+
+	mmu.Write8(REG_DMA_OAM, 0xC0)
+	stepDMA(dma, mmu, DMA_START_DELAY+5)
+
+	var state bytes.Buffer
+	require.NoError(t, dma.SaveState(&state))
+
+	// Nothing conflicts once the transfer's done
+	stepDMA(dma, mmu, OAM_SIZE)
+	assert.False(dma.IsActive())
+	assert.Equal(byte(0x42), mmu.Read8(0x4000))
+	assert.Equal(byte(0x00), mmu.Read8(OAM_START))
+
+	// Restoring a transfer in progress picks up conflicting again
+	require.NoError(t, dma.LoadState(&state))
+	assert.Equal(byte(4), mmu.Read8(0x4000))
+}
+
+func TestDMARestart(t *testing.T) {
+	assert := assert.New(t)
+
+	dma, mmu := newTestDMA()
+
+	mmu.Write8(REG_DMA_OAM, 0xC0)
+	stepDMA(dma, mmu, DMA_START_DELAY+5)
+
+	// The first transfer carries on until the second starts
+	mmu.Write8(REG_DMA_OAM, 0xD0)
+	stepDMA(dma, mmu, DMA_START_DELAY)
+	assert.True(dma.IsActive())
+	assert.Equal(byte(5), mmu.Peek8(OAM_START+5))
+
+	stepDMA(dma, mmu, OAM_SIZE)
+	assert.False(dma.IsActive())
+	assert.Equal(byte(0xFF), mmu.Peek8(OAM_START))
+	assert.Equal(byte(0xFF-OAM_SIZE+1), mmu.Peek8(OAM_END))
+}
+
+func TestDMALoadStateVersion0(t *testing.T) {
+	assert := assert.New(t)
+
+	// enabled, clock & the bytes left to write to OAM
+	var state bytes.Buffer
+	require.NoError(t, savestate.Write(&state, true, uint64(320), uint8(2)))
+	require.NoError(t, savestate.Write(&state, OAM_START, byte(0x12), OAM_START+1, byte(0x34)))
+
+	dma, mmu := newTestDMA()
+	mmu.Write8(REG_DMA_OAM, 0xC0)
+	stepDMA(dma, mmu, DMA_START_DELAY)
+
+	require.NoError(t, dma.LoadStateVersion(&state, 0))
+	assert.Zero(state.Len())
+	assert.False(dma.IsActive())
+	assert.Equal(scheduler.NEVER, dma.NextEvent())
+
+	// DMA can be started again as normal
+	mmu.Write8(REG_DMA_OAM, 0xD0)
+	stepDMA(dma, mmu, DMA_START_DELAY+OAM_SIZE)
+	assert.Equal(byte(0xFF), mmu.Peek8(OAM_START))
+}
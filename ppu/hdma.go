@@ -2,9 +2,11 @@ package ppu
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/maxfierke/gogo-gb/bits"
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
 )
 
 const (
@@ -17,6 +19,11 @@ const (
 	REG_HDMA_LEN_MODE_START uint16 = 0xFF55
 
 	REG_HDMA_LEN_MASK = 0x7F
+
+	// HDMA_BLOCK_CYCLES is how many cycles the CPU is stalled for while a block
+	// is copied (8 M-cycles). Copying takes the same time in double speed mode,
+	// which is twice as many cycles.
+	HDMA_BLOCK_CYCLES = 32
 )
 
 type HDMAMode uint8
@@ -27,17 +34,21 @@ const (
 )
 
 type HDMA struct {
-	active bool
+	active       bool
+	blockPending bool // HBlank mode: A block is due to be copied this HBlank
 
 	mode     HDMAMode
 	srcAddr  uint16
 	destAddr uint16
-	length   uint8
+	length   uint8 // Blocks left to copy
 }
 
 const bytesInBlock = 16
 
-var _ mem.MemHandler = (*DMA)(nil)
+var (
+	_ mem.MemHandler       = (*HDMA)(nil)
+	_ savestate.Serializer = (*HDMA)(nil)
+)
 
 func NewHDMA() *HDMA {
 	return &HDMA{}
@@ -47,38 +58,77 @@ func (d *HDMA) IsActive(dmaMode HDMAMode) bool {
 	return d.active && d.mode == dmaMode
 }
 
-func (d *HDMA) Step(mmu *mem.MMU) {
-	if !d.active {
-		return
+// IsTransferring reports whether a block is due to be copied, which the CPU
+// is stalled for. General transfers copy every block in one go, while HBlank
+// transfers copy one block at the start of each HBlank.
+func (d *HDMA) IsTransferring() bool {
+	return d.active && (d.mode == HDMA_MODE_GENERAL || d.blockPending)
+}
+
+func (d *HDMA) LoadState(r io.Reader) error {
+	return savestate.Read(r, &d.active, &d.mode, &d.srcAddr, &d.destAddr, &d.length, &d.blockPending)
+}
+
+func (d *HDMA) SaveState(w io.Writer) error {
+	return savestate.Write(w, &d.active, &d.mode, &d.srcAddr, &d.destAddr, &d.length, &d.blockPending)
+}
+
+// SkipHBlank drops the block due this HBlank, if any. Blocks aren't copied
+// during HBlanks which start while the CPU is halted.
+func (d *HDMA) SkipHBlank() {
+	d.blockPending = false
+}
+
+// StartHBlank is called by the PPU at the start of each HBlank
+func (d *HDMA) StartHBlank() {
+	if d.IsActive(HDMA_MODE_HBLANK) {
+		d.blockPending = true
+	}
+}
+
+// Step copies the next block, returning the number of cycles it took
+func (d *HDMA) Step(mmu *mem.MMU, doubleSpeed bool) uint8 {
+	if !d.IsTransferring() {
+		return 0
 	}
 
 	for i := range uint16(bytesInBlock) {
-		destAddr := d.destAddr + i
-		srcAddr := d.srcAddr + i
-		value := mmu.Read8(srcAddr)
+		// The destination wraps around within VRAM
+		destAddr := VRAM_START | ((d.destAddr + i) & (VRAM_END - VRAM_START))
+		value := mmu.Read8(d.srcAddr + i)
 
-		if destAddr < VRAM_START || destAddr > VRAM_END {
-			panic(fmt.Sprintf("illegal HDMA write to 0x%04X", destAddr))
-		}
-		mmu.Write8(destAddr, value)
+		mmu.Poke8(destAddr, value)
 	}
 	d.srcAddr += bytesInBlock
-	d.destAddr += bytesInBlock
+	d.destAddr = VRAM_START | ((d.destAddr + bytesInBlock) & (VRAM_END - VRAM_START))
 	d.length--
+	d.blockPending = false
 
 	if d.length == 0 {
 		d.active = false
 	}
+
+	if doubleSpeed {
+		return HDMA_BLOCK_CYCLES * 2
+	}
+
+	return HDMA_BLOCK_CYCLES
 }
 
 func (d *HDMA) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 	if addr == REG_HDMA_LEN_MODE_START {
-		if d.active {
-			return mem.ReadReplace(0x00)
-		} else if d.mode == HDMA_MODE_HBLANK {
-			return mem.ReadReplace((HDMA_MODE_HBLANK << 7) | d.length)
+		// Blocks left to copy, minus one, so 0xFF once a transfer has finished.
+		// Bit 7 is set once the transfer is finished or cancelled.
+		remaining := (d.length - 1) & REG_HDMA_LEN_MASK
+		if !d.active {
+			remaining |= 1 << 7
 		}
 
+		return mem.ReadReplace(remaining)
+	}
+
+	if addr >= REG_HDMA_SRC_HIGH && addr <= REG_HDMA_DST_LOW {
+		// Write-only
 		return mem.ReadReplace(0xFF)
 	}
 
@@ -87,12 +137,16 @@ func (d *HDMA) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 
 func (d *HDMA) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 	if addr == REG_HDMA_LEN_MODE_START {
-		if !d.active {
+		mode := HDMAMode(bits.Read(value, 7))
+
+		if d.active && mode == HDMA_MODE_GENERAL {
+			// Cancels the HBlank transfer in progress, w/o starting a new one
+			d.active = false
+			d.blockPending = false
+		} else {
 			d.active = true
-			d.mode = HDMAMode(bits.Read(value, 7))
+			d.mode = mode
 			d.length = (value & REG_HDMA_LEN_MASK) + 1
-		} else if d.active && d.mode != HDMAMode(bits.Read(value, 7)) {
-			d.active = false
 		}
 
 		return mem.WriteBlock()
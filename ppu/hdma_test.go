@@ -0,0 +1,98 @@
+package ppu
+
+import (
+	"testing"
+
+	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHDMA() (*HDMA, *PPU, *mem.MMU) {
+	ppu, mmu, _ := newTestPPU()
+
+	hdma := NewHDMA()
+	ppu.ConnectHDMA(hdma)
+	mmu.AddHandler(mem.MemRegion{Start: REG_HDMA_SRC_HIGH, End: REG_HDMA_LEN_MODE_START}, hdma)
+
+	for i := range uint16(0x100) {
+		mmu.Write8(0xC000+i, byte(i))
+	}
+
+	mmu.Write8(REG_HDMA_SRC_HIGH, 0xC0)
+	mmu.Write8(REG_HDMA_SRC_LOW, 0x00)
+	mmu.Write8(REG_HDMA_DST_HIGH, 0x80)
+	mmu.Write8(REG_HDMA_DST_LOW, 0x00)
+
+	return hdma, ppu, mmu
+}
+
+func TestHDMAGeneral(t *testing.T) {
+	assert := assert.New(t)
+
+	hdma, _, mmu := newTestHDMA()
+
+	mmu.Write8(REG_HDMA_LEN_MODE_START, 0x03) // 4 blocks
+	assert.True(hdma.IsTransferring())
+
+	for block := range 4 {
+		assert.Equalf(uint8(HDMA_BLOCK_CYCLES*2), hdma.Step(mmu, true), "block %d", block)
+	}
+
+	assert.False(hdma.IsTransferring())
+	assert.Equal(byte(0xFF), mmu.Read8(REG_HDMA_LEN_MODE_START))
+
+	for i := range uint16(64) {
+		assert.Equal(byte(i), mmu.Peek8(VRAM_START+i))
+	}
+	assert.Equal(byte(0x00), mmu.Peek8(VRAM_START+64))
+}
+
+func TestHDMAHBlank(t *testing.T) {
+	assert := assert.New(t)
+
+	hdma, _, mmu := newTestHDMA()
+
+	mmu.Write8(REG_HDMA_LEN_MODE_START, 0x80|0x02) // 3 blocks
+	assert.False(hdma.IsTransferring())
+	assert.Equal(byte(0x02), mmu.Read8(REG_HDMA_LEN_MODE_START))
+
+	hdma.StartHBlank()
+	assert.True(hdma.IsTransferring())
+	assert.Equal(uint8(HDMA_BLOCK_CYCLES), hdma.Step(mmu, false))
+	assert.False(hdma.IsTransferring())
+	assert.Equal(byte(0x01), mmu.Read8(REG_HDMA_LEN_MODE_START))
+
+	// Skipped, e.g. because the CPU was halted
+	hdma.StartHBlank()
+	hdma.SkipHBlank()
+	assert.False(hdma.IsTransferring())
+	assert.Equal(byte(0x01), mmu.Read8(REG_HDMA_LEN_MODE_START))
+
+	hdma.StartHBlank()
+	hdma.Step(mmu, false)
+	assert.Equal(byte(0x00), mmu.Read8(REG_HDMA_LEN_MODE_START))
+
+	// Cancelling leaves the remaining length, w/ bit 7 set
+	mmu.Write8(REG_HDMA_LEN_MODE_START, 0x00)
+	assert.Equal(byte(0x80), mmu.Read8(REG_HDMA_LEN_MODE_START))
+
+	hdma.StartHBlank()
+	assert.False(hdma.IsTransferring())
+	assert.Equal(byte(16), mmu.Peek8(VRAM_START+16))
+	assert.Equal(byte(0x00), mmu.Peek8(VRAM_START+32))
+}
+
+func TestHDMAStartsOnHBlank(t *testing.T) {
+	assert := assert.New(t)
+
+	hdma, ppu, mmu := newTestHDMA()
+
+	mmu.Write8(REG_PPU_LCDC, 0x80)
+	mmu.Write8(REG_HDMA_LEN_MODE_START, 0x80)
+
+	stepUntilLine(ppu, 0, PPU_MODE_VRAM)
+	assert.False(hdma.IsTransferring())
+
+	stepUntilLine(ppu, 0, PPU_MODE_HBLANK)
+	assert.True(hdma.IsTransferring())
+}
@@ -5,6 +5,9 @@ import "github.com/maxfierke/gogo-gb/bits"
 const (
 	REG_LCD_LCDC = 0xFF40
 	REG_LCD_STAT = 0xFF41
+
+	LCD_WIDTH  = 160
+	LCD_HEIGHT = 144
 )
 
 const (
@@ -134,7 +137,7 @@ func (stat *lcdStatus) Read(ppu *PPU) uint8 {
 		mode1IntSel |
 		mode0IntSel |
 		lycEqLy |
-		uint8(ppu.Mode))
+		uint8(ppu.statMode()))
 }
 
 func (stat *lcdStatus) InterruptEnabled(ppu *PPU) bool {
@@ -142,7 +145,7 @@ func (stat *lcdStatus) InterruptEnabled(ppu *PPU) bool {
 		return true
 	}
 
-	switch ppu.Mode {
+	switch ppu.statMode() {
 	case PPU_MODE_HBLANK:
 		return stat.mode0IntSel
 	case PPU_MODE_VBLANK:
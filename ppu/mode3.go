@@ -0,0 +1,115 @@
+package ppu
+
+import (
+	"cmp"
+	"slices"
+)
+
+const (
+	// CLK_MODE3_WINDOW_PENALTY is how many dots mode 3 is lengthened by when the
+	// window is drawn on a line, while the fetcher restarts on the window
+	CLK_MODE3_WINDOW_PENALTY = 6
+
+	// CLK_MODE3_OBJ_PENALTY is how many dots mode 3 is lengthened by for each
+	// object fetched on a line
+	CLK_MODE3_OBJ_PENALTY = 6
+
+	// CLK_MODE3_OBJ_MAX_FETCH_WAIT is the most dots an object fetch can wait for
+	// the BG/window fetch it interrupts to finish, on top of CLK_MODE3_OBJ_PENALTY.
+	// Only the first object fetched over a given tile waits.
+	CLK_MODE3_OBJ_MAX_FETCH_WAIT = 5
+
+	// OBJ_OFFSCREEN_X is the OAM X position at & beyond which objects are off the
+	// right edge of the screen, and are never fetched
+	OBJ_OFFSCREEN_X = 168
+)
+
+// calculateMode3Length returns how many dots mode 3 will take on the current
+// line, based on fine scrolling, the window, and the objects found by OAM scan
+func (ppu *PPU) calculateMode3Length() uint16 {
+	// Pixels scrolled off the left edge are still shifted out, just not drawn
+	length := uint16(CLK_MODE3_PERIOD_LEN) + uint16(ppu.scrollBackgroundX%8)
+
+	windowVisible := ppu.isWindowVisible()
+	if windowVisible {
+		length += CLK_MODE3_WINDOW_PENALTY
+	}
+
+	if !ppu.lcdCtrl.objectEnabled {
+		return length
+	}
+
+	var found [OAM_MAX_OBJECTS_PER_SCANLINE]ObjectData
+	objects := ppu.scanOAM(found[:0])
+
+	// Objects are fetched left to right, as the shifter reaches them
+	slices.SortStableFunc(objects, func(a, b ObjectData) int {
+		return cmp.Compare(a.PosX+8, b.PosX+8)
+	})
+
+	// Bitmask of BG & window tiles which have already had an object fetched over
+	// them. BG tiles are bits 0-22, and window tiles bits 32-53.
+	var tilesWaited uint64
+
+	for _, object := range objects {
+		oamX := int(object.PosX + 8)
+		if oamX >= OBJ_OFFSCREEN_X {
+			continue
+		}
+
+		length += CLK_MODE3_OBJ_PENALTY
+
+		// Objects entirely off the left edge are fetched before the first tile
+		if oamX == 0 {
+			length += CLK_MODE3_OBJ_MAX_FETCH_WAIT
+
+			continue
+		}
+
+		var tile, tilePixelX int
+		if windowX := int(ppu.windowX); windowVisible && oamX > windowX {
+			tile = 32 + (oamX-windowX-1)/8
+			tilePixelX = (oamX - windowX - 1) % 8
+		} else {
+			bgX := oamX + int(ppu.scrollBackgroundX%8)
+			tile = bgX / 8
+			tilePixelX = bgX % 8
+		}
+
+		if tilesWaited&(1<<tile) == 0 {
+			tilesWaited |= 1 << tile
+			length += uint16(max(0, CLK_MODE3_OBJ_MAX_FETCH_WAIT-tilePixelX))
+		}
+	}
+
+	return length
+}
+
+func (ppu *PPU) isWindowVisible() bool {
+	// WX of 167 or above puts the window off the right edge
+	return ppu.lcdCtrl.windowEnabled &&
+		ppu.curScanLine >= ppu.windowY &&
+		ppu.windowX <= 166
+}
+
+// scanOAM appends the first OAM_MAX_OBJECTS_PER_SCANLINE objects on the
+// current line to objects, in OAM order
+func (ppu *PPU) scanOAM(objects []ObjectData) []ObjectData {
+	objHeight := uint8(8)
+	if ppu.lcdCtrl.objectSize == OBJ_SIZE_8x16 {
+		objHeight = 16
+	}
+
+	for _, object := range ppu.oam.Objects() {
+		if len(objects) == OAM_MAX_OBJECTS_PER_SCANLINE {
+			break
+		}
+
+		// Wraps around for objects partially off the top edge
+		if ppu.curScanLine-object.PosY < objHeight {
+			objects = append(objects, object)
+		}
+	}
+
+	return objects
+}
@@ -0,0 +1,66 @@
+package ppu
+
+import (
+	"image"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// nullRenderer draws nothing, but claims to draw each line all at once
+type nullRenderer struct{}
+
+func (nullRenderer) DrawImage() image.Image      { return nil }
+func (nullRenderer) LoadState(r io.Reader) error { return nil }
+func (nullRenderer) SaveState(w io.Writer) error { return nil }
+func (nullRenderer) Step(dots uint16) uint8      { return LCD_WIDTH }
+
+func TestCalculateMode3Length(t *testing.T) {
+	testCases := []struct {
+		name      string
+		scx       uint8
+		window    bool
+		objects   bool
+		objectsXs []uint8 // OAM X positions
+		expected  uint16
+	}{
+		{name: "nothing", expected: CLK_MODE3_PERIOD_LEN},
+		{name: "fine scroll", scx: 11, expected: CLK_MODE3_PERIOD_LEN + 3},
+		{name: "window", window: true, expected: CLK_MODE3_PERIOD_LEN + 6},
+		{name: "objects disabled", objectsXs: []uint8{8, 16}, expected: CLK_MODE3_PERIOD_LEN},
+		{name: "object at X=0", objects: true, objectsXs: []uint8{0}, expected: CLK_MODE3_PERIOD_LEN + 11},
+		{name: "object aligned to tile", objects: true, objectsXs: []uint8{8}, expected: CLK_MODE3_PERIOD_LEN + 11},
+		{name: "object late in tile", objects: true, objectsXs: []uint8{13}, expected: CLK_MODE3_PERIOD_LEN + 6},
+		{name: "object aligned by scroll", scx: 5, objects: true, objectsXs: []uint8{11}, expected: CLK_MODE3_PERIOD_LEN + 5 + 11},
+		{name: "objects sharing tile", objects: true, objectsXs: []uint8{9, 8}, expected: CLK_MODE3_PERIOD_LEN + 11 + 6},
+		{name: "object off right edge", objects: true, objectsXs: []uint8{168}, expected: CLK_MODE3_PERIOD_LEN},
+		{name: "object in window", window: true, objects: true, objectsXs: []uint8{90}, expected: CLK_MODE3_PERIOD_LEN + 6 + 9},
+		{
+			name:      "too many objects",
+			objects:   true,
+			objectsXs: []uint8{13, 21, 29, 37, 45, 53, 61, 69, 77, 85, 93},
+			expected:  CLK_MODE3_PERIOD_LEN + 10*6,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ppu, _, _ := newTestPPU()
+
+			ppu.curScanLine = 50
+			ppu.scrollBackgroundX = tc.scx
+			ppu.lcdCtrl.objectEnabled = tc.objects
+			ppu.lcdCtrl.windowEnabled = tc.window
+			ppu.windowX = 87
+			ppu.windowY = 20
+
+			for i, x := range tc.objectsXs {
+				ppu.oam.Write(uint8(i*4), 16+50)
+				ppu.oam.Write(uint8(i*4+1), x)
+			}
+
+			assert.Equal(t, tc.expected, ppu.calculateMode3Length())
+		})
+	}
+}
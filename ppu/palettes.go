@@ -140,3 +140,12 @@ func (cgbp *cgbPalettes) WritePalette(value byte) {
 		cgbp.addr = (cgbp.addr + 1) % 64
 	}
 }
+
+func (cgbp *cgbPalettes) stateFields() []any {
+	return []any{
+		&cgbp.palettes,
+		&cgbp.paletteRAM,
+		&cgbp.autoIncrement,
+		&cgbp.addr,
+	}
+}
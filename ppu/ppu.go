@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"image/draw"
+	"io"
 
 	"github.com/maxfierke/gogo-gb/bits"
 	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
+	"github.com/maxfierke/gogo-gb/scheduler"
 )
 
 const (
@@ -23,13 +27,18 @@ const (
 	// It is fixed at 80 dots.
 	CLK_MODE2_PERIOD_LEN = 80
 
-	// CLK_MODE3_PERIOD_LEN is the dot length of Mode 3 (VRAM / drawing).
+	// CLK_MODE3_PERIOD_LEN is the shortest dot length of Mode 3 (VRAM / drawing),
+	// before it is lengthened by fine scrolling, the window and objects.
 	// 172 dots is the floor, but 174 dots was chosen for compatibility with
 	// orangeglo's LED Screen Timer test ROM. Mode 0 and Mode 3 just need to add
 	// up to 376.
 	// This is probably a bug of some kind, but not one I feel like fixing right now.
 	CLK_MODE3_PERIOD_LEN = 174
 
+	// CLK_LY153_PERIOD_LEN is how many dots LY reads as 153 at the start of the
+	// last line of VBlank, before wrapping around to 0 early
+	CLK_LY153_PERIOD_LEN = 4
+
 	VBLANK_PERIOD_BEGIN = 144
 	VBLANK_PERIOD_END   = 153
 
@@ -101,9 +110,16 @@ type InterruptRequester interface {
 
 type RendererConstructor func(ppu *PPU, oam *OAM, vram *VRAM) Renderer
 
+// Renderer draws the PPU's output. Since a renderer may be part-way through a
+// line (or frame), it must also be able to save & restore that progress.
 type Renderer interface {
+	savestate.Serializer
+
 	DrawImage() image.Image
-	Step(dots uint8) uint8
+
+	// Step advances the renderer to the given dot of mode 3, returning how
+	// many pixels were drawn
+	Step(dots uint16) uint8
 }
 
 type PPU struct {
@@ -134,17 +150,39 @@ type PPU struct {
 	vram *VRAM
 
 	clock          uint
-	mode3Cycles    uint8
+	mode3Cycles    uint16
+	mode3Length    uint16
 	pixelsRendered uint8
 
+	// statLine is the STAT interrupt line, the OR of every enabled STAT source.
+	// An interrupt is only requested as it rises, so one source holding it high
+	// blocks any others from requesting another ("STAT blocking").
+	statLine bool
+
+	// lycEqual is whether LY matched LYC when last compared. It isn't updated
+	// while the LCD is off.
+	lycEqual bool
+
+	// firstLineAfterEnable is set for the first line after the LCD is turned on,
+	// which skips OAM scan, reporting mode 0 instead of mode 2
+	firstLineAfterEnable bool
+
 	ic       InterruptRequester
 	renderer Renderer
 
 	color                   bool
 	dmgCompatibilityEnabled bool
 	hdma                    *HDMA
+
+	// The PPU runs at the same rate regardless of CPU speed
+	baseClock scheduler.BaseClock
+
+	// stopped is set while the CPU is in STOP mode, & the LCD isn't driven
+	stopped bool
 }
 
+var _ savestate.Versioned = (*PPU)(nil)
+
 func NewPPU(ic InterruptRequester, renderer RendererConstructor) *PPU {
 	ppu := &PPU{
 		Mode:           PPU_MODE_OAM,
@@ -166,7 +204,19 @@ var grayScales = []color.Color{
 	color.Black,
 }
 
+// blankFrame is drawn while the LCD is off, or the system is stopped
+var blankFrame = func() image.Image {
+	frame := image.NewRGBA(image.Rect(0, 0, LCD_WIDTH, LCD_HEIGHT))
+	draw.Draw(frame, frame.Bounds(), image.White, image.Point{}, draw.Src)
+
+	return frame
+}()
+
 func (ppu *PPU) Draw() image.Image {
+	if !ppu.lcdCtrl.enabled || ppu.stopped {
+		return blankFrame
+	}
+
 	return ppu.renderer.DrawImage()
 }
 
@@ -240,7 +290,7 @@ func (ppu *PPU) IsColorEnabled() bool {
 }
 
 func (ppu *PPU) IsCurrentLineEqualToCompare() bool {
-	return ppu.curScanLine == ppu.cmpScanLine
+	return ppu.lycEqual
 }
 
 func (ppu *PPU) IsLCDEnabled() bool {
@@ -275,18 +325,116 @@ func (ppu *PPU) WindowY() uint8 {
 	return ppu.windowY
 }
 
+// UseRenderer replaces the PPU's renderer, e.g. to use a more accurate one
+func (ppu *PPU) UseRenderer(renderer RendererConstructor) {
+	ppu.renderer = renderer(ppu, ppu.oam, ppu.vram)
+}
+
 func (ppu *PPU) SetDMGCompatibilityEnabled(enabled bool) {
 	ppu.dmgCompatibilityEnabled = enabled
 }
 
+func (ppu *PPU) SetDoubleSpeed(enabled bool) {
+	ppu.baseClock.DoubleSpeed = enabled
+}
+
+// SetStopped blanks the LCD while the system is in STOP mode. The PPU isn't
+// stepped at all while stopped, as the clock is stopped too.
+func (ppu *PPU) SetStopped(stopped bool) {
+	ppu.stopped = stopped
+}
+
+func (ppu *PPU) LoadState(r io.Reader) error {
+	return ppu.loadState(r, ppu.stateFields())
+}
+
+// LoadStateVersion migrates state from before the PPU modeled mode 3 length,
+// the STAT line & double-speed (version 0). The STAT line & mode 3 length are
+// recalculated from the rest of the state, as they would've been at the time.
+func (ppu *PPU) LoadStateVersion(r io.Reader, version uint8) error {
+	if err := ppu.loadState(r, ppu.stateFieldsV0()); err != nil {
+		return err
+	}
+
+	ppu.firstLineAfterEnable = false
+	ppu.baseClock = scheduler.BaseClock{}
+	ppu.stopped = false
+
+	ppu.mode3Length = 0
+	if ppu.Mode == PPU_MODE_VRAM {
+		ppu.mode3Length = ppu.calculateMode3Length()
+	}
+
+	ppu.lycEqual = false
+	ppu.statLine = false
+	if ppu.lcdCtrl.enabled {
+		ppu.lycEqual = ppu.curScanLine == ppu.cmpScanLine
+		ppu.statLine = ppu.lcdStatus.InterruptEnabled(ppu)
+	}
+
+	return nil
+}
+
+func (ppu *PPU) SaveState(w io.Writer) error {
+	if err := savestate.Write(w, ppu.stateFields()...); err != nil {
+		return err
+	}
+
+	if err := ppu.oam.SaveState(w); err != nil {
+		return fmt.Errorf("saving OAM: %w", err)
+	}
+
+	if err := ppu.vram.SaveState(w); err != nil {
+		return fmt.Errorf("saving VRAM: %w", err)
+	}
+
+	if err := ppu.renderer.SaveState(w); err != nil {
+		return fmt.Errorf("saving renderer: %w", err)
+	}
+
+	return nil
+}
+
+func (ppu *PPU) StateVersion() uint8 {
+	return 1
+}
+
+// NextEvent returns how many cycles until the PPU next changes mode or LY,
+// which is when it can request an interrupt
+func (ppu *PPU) NextEvent() uint {
+	if !ppu.lcdCtrl.enabled {
+		return scheduler.NEVER
+	}
+
+	var until int
+
+	switch ppu.Mode {
+	case PPU_MODE_HBLANK:
+		until = CLK_MODE0_MODE3_PERIODS_LEN - int(ppu.mode3Cycles) - int(ppu.clock)
+	case PPU_MODE_VBLANK:
+		if ppu.curScanLine == VBLANK_PERIOD_END && ppu.clock < CLK_LY153_PERIOD_LEN {
+			until = CLK_LY153_PERIOD_LEN - int(ppu.clock)
+		} else {
+			until = CLK_MODE1_PERIOD_LEN - int(ppu.clock)
+		}
+	case PPU_MODE_OAM:
+		until = CLK_MODE2_PERIOD_LEN - int(ppu.clock)
+	case PPU_MODE_VRAM:
+		// If the renderer's running behind, it's stepped a dot at a time
+		until = int(ppu.mode3Length) - int(ppu.mode3Cycles)
+	}
+
+	return max(ppu.baseClock.Until(uint(max(until, 0))), 1)
+}
+
 func (ppu *PPU) Step(mmu *mem.MMU, cycles uint8) {
 	if !ppu.lcdCtrl.enabled {
 		return
 	}
 
-	ppu.clock += uint(cycles)
+	dots := ppu.baseClock.Cycles(cycles)
 
-	previousStatusEnabled := ppu.lcdStatus.InterruptEnabled(ppu)
+	ppu.clock += dots
 
 	switch ppu.Mode {
 	case PPU_MODE_HBLANK:
@@ -298,43 +446,53 @@ func (ppu *PPU) Step(mmu *mem.MMU, cycles uint8) {
 				ppu.Mode = PPU_MODE_VBLANK
 				ppu.curWindowLine = 0
 				ppu.ic.RequestVBlank()
-				ppu.requestLCD(previousStatusEnabled)
 			} else {
-				if ppu.hdma != nil {
-					ppu.hdma.Step(mmu)
-				}
-
 				ppu.Mode = PPU_MODE_OAM
-				ppu.requestLCD(previousStatusEnabled)
 			}
 		}
 	case PPU_MODE_VBLANK:
 		if ppu.clock >= CLK_MODE1_PERIOD_LEN {
 			ppu.clock = ppu.clock % CLK_MODE1_PERIOD_LEN
-			ppu.curScanLine += 1
 
-			if ppu.curScanLine == VBLANK_PERIOD_END {
+			if ppu.curScanLine == 0 {
+				// End of the last line, which already wrapped LY around
 				ppu.Mode = PPU_MODE_OAM
-				ppu.curScanLine = 0
-				ppu.requestLCD(previousStatusEnabled)
+			} else {
+				ppu.curScanLine += 1
 			}
 		}
+
+		if ppu.curScanLine == VBLANK_PERIOD_END && ppu.clock >= CLK_LY153_PERIOD_LEN {
+			ppu.curScanLine = 0
+		}
 	case PPU_MODE_OAM:
 		if ppu.clock >= CLK_MODE2_PERIOD_LEN {
 			ppu.clock = ppu.clock % CLK_MODE2_PERIOD_LEN
+			ppu.firstLineAfterEnable = false
 			ppu.Mode = PPU_MODE_VRAM
 			ppu.mode3Cycles = 0
+			ppu.mode3Length = ppu.calculateMode3Length()
 		}
 	case PPU_MODE_VRAM:
-		ppu.mode3Cycles += cycles
-		ppu.pixelsRendered += ppu.renderer.Step(ppu.mode3Cycles)
-		if ppu.pixelsRendered == 160 {
+		ppu.mode3Cycles += uint16(dots)
+		if ppu.pixelsRendered < 160 {
+			ppu.pixelsRendered += ppu.renderer.Step(ppu.mode3Cycles)
+		}
+
+		// Mode 3 can't end before the renderer has drawn the whole line, even if
+		// the renderer takes longer than expected
+		if ppu.pixelsRendered == 160 && ppu.mode3Cycles >= ppu.mode3Length {
 			ppu.clock = ppu.clock % uint(ppu.mode3Cycles)
 			ppu.pixelsRendered = 0
 			ppu.Mode = PPU_MODE_HBLANK
-			ppu.requestLCD(previousStatusEnabled)
+
+			if ppu.hdma != nil {
+				ppu.hdma.StartHBlank()
+			}
 		}
 	}
+
+	ppu.updateSTATLine()
 }
 
 func (ppu *PPU) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
@@ -391,7 +549,7 @@ func (ppu *PPU) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 	}
 
 	if addr == REG_PPU_BCPD_BGPD {
-		if ppu.Mode == PPU_MODE_VRAM {
+		if !ppu.isVRAMAccessible(mmu) {
 			return mem.ReadReplace(0xFF)
 		}
 
@@ -403,7 +561,7 @@ func (ppu *PPU) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 	}
 
 	if addr == REG_PPU_OCPD_OBPD {
-		if ppu.Mode == PPU_MODE_VRAM {
+		if !ppu.isVRAMAccessible(mmu) {
 			return mem.ReadReplace(0xFF)
 		}
 
@@ -417,7 +575,7 @@ func (ppu *PPU) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 	if addr >= OAM_START && addr <= OAM_END {
 		oamAddr := uint8(addr - OAM_START)
 
-		if ppu.Mode == PPU_MODE_OAM || ppu.Mode == PPU_MODE_VRAM {
+		if !ppu.isOAMAccessible(mmu) {
 			return mem.ReadReplace(0xFF)
 		}
 
@@ -427,7 +585,7 @@ func (ppu *PPU) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 	if addr >= VRAM_START && addr <= VRAM_END {
 		vramAddr := addr - VRAM_START
 
-		if ppu.Mode == PPU_MODE_VRAM {
+		if !ppu.isVRAMAccessible(mmu) {
 			return mem.ReadReplace(0xFF)
 		}
 
@@ -439,15 +597,28 @@ func (ppu *PPU) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
 
 func (ppu *PPU) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 	if addr == REG_PPU_LCDC {
+		wasEnabled := ppu.lcdCtrl.enabled
 		ppu.lcdCtrl.Write(value)
 
+		if wasEnabled && !ppu.lcdCtrl.enabled {
+			ppu.turnOffLCD()
+		} else if !wasEnabled && ppu.lcdCtrl.enabled {
+			ppu.turnOnLCD()
+		}
+
 		return mem.WriteBlock()
 	}
 
 	if addr == REG_PPU_LCDSTAT {
-		previousStatusEnabled := ppu.lcdStatus.InterruptEnabled(ppu)
+		if !ppu.color {
+			// DMG bug: Every source is briefly enabled while STAT is written, which
+			// can trigger a spurious interrupt
+			ppu.lcdStatus.Write(ppu, 0xFF)
+			ppu.updateSTATLine()
+		}
+
 		ppu.lcdStatus.Write(ppu, value)
-		ppu.requestLCD(previousStatusEnabled)
+		ppu.updateSTATLine()
 
 		return mem.WriteBlock()
 	}
@@ -471,6 +642,7 @@ func (ppu *PPU) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 
 	if addr == REG_PPU_LYC {
 		ppu.cmpScanLine = value
+		ppu.updateSTATLine()
 
 		return mem.WriteBlock()
 	}
@@ -512,7 +684,7 @@ func (ppu *PPU) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 	}
 
 	if addr == REG_PPU_BCPD_BGPD {
-		if ppu.Mode == PPU_MODE_VRAM {
+		if !ppu.isVRAMAccessible(mmu) {
 			if ppu.cgbBGPalettes.autoIncrement {
 				ppu.cgbBGPalettes.addr = (ppu.cgbBGPalettes.addr + 1) % 64
 			}
@@ -530,7 +702,7 @@ func (ppu *PPU) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 	}
 
 	if addr == REG_PPU_OCPD_OBPD {
-		if ppu.Mode == PPU_MODE_VRAM {
+		if !ppu.isVRAMAccessible(mmu) {
 			if ppu.cgbObjPalettes.autoIncrement {
 				ppu.cgbObjPalettes.addr = (ppu.cgbObjPalettes.addr + 1) % 64
 			}
@@ -564,7 +736,7 @@ func (ppu *PPU) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 	if addr >= OAM_START && addr <= OAM_END {
 		oamAddr := uint8(addr - OAM_START)
 
-		if ppu.Mode == PPU_MODE_OAM || ppu.Mode == PPU_MODE_VRAM {
+		if !ppu.isOAMAccessible(mmu) {
 			return mem.WriteBlock()
 		}
 
@@ -576,7 +748,7 @@ func (ppu *PPU) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 	if addr >= VRAM_START && addr <= VRAM_END {
 		vramAddr := addr - VRAM_START
 
-		if ppu.Mode == PPU_MODE_VRAM {
+		if !ppu.isVRAMAccessible(mmu) {
 			return mem.WriteBlock()
 		}
 
@@ -588,8 +760,134 @@ func (ppu *PPU) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
 	panic(fmt.Sprintf("Attempting to write 0x%02X @ 0x%04X, which is out-of-bounds for PPU", value, addr))
 }
 
-func (ppu *PPU) requestLCD(previousStatusEnabled bool) {
-	if !previousStatusEnabled && ppu.lcdStatus.InterruptEnabled(ppu) {
+// isOAMAccessible reports whether the CPU can access OAM, which is in use by
+// the PPU during modes 2 & 3
+func (ppu *PPU) isOAMAccessible(mmu *mem.MMU) bool {
+	return mmu.IsUnrestricted() ||
+		!ppu.lcdCtrl.enabled ||
+		(ppu.statMode() != PPU_MODE_OAM && ppu.Mode != PPU_MODE_VRAM)
+}
+
+// isVRAMAccessible reports whether the CPU can access VRAM & the CGB palette
+// data, which are in use by the PPU during mode 3
+func (ppu *PPU) isVRAMAccessible(mmu *mem.MMU) bool {
+	return mmu.IsUnrestricted() ||
+		!ppu.lcdCtrl.enabled ||
+		ppu.Mode != PPU_MODE_VRAM
+}
+
+// loadState restores fields, followed by OAM, VRAM & the renderer
+func (ppu *PPU) loadState(r io.Reader, fields []any) error {
+	if err := savestate.Read(r, fields...); err != nil {
+		return err
+	}
+
+	if err := ppu.oam.LoadState(r); err != nil {
+		return fmt.Errorf("loading OAM: %w", err)
+	}
+
+	if err := ppu.vram.LoadState(r); err != nil {
+		return fmt.Errorf("loading VRAM: %w", err)
+	}
+
+	if err := ppu.renderer.LoadState(r); err != nil {
+		return fmt.Errorf("loading renderer: %w", err)
+	}
+
+	return nil
+}
+
+// statMode returns the mode reported in STAT, which differs from the real mode
+// on the first line after the LCD is turned on
+func (ppu *PPU) statMode() PPUMode {
+	if ppu.firstLineAfterEnable && ppu.Mode == PPU_MODE_OAM {
+		return PPU_MODE_HBLANK
+	}
+
+	return ppu.Mode
+}
+
+func (ppu *PPU) turnOffLCD() {
+	ppu.Mode = PPU_MODE_HBLANK
+	ppu.curScanLine = 0
+	ppu.curWindowLine = 0
+	ppu.clock = 0
+	ppu.mode3Cycles = 0
+	ppu.pixelsRendered = 0
+	ppu.statLine = false
+}
+
+func (ppu *PPU) turnOnLCD() {
+	ppu.Mode = PPU_MODE_OAM
+	ppu.firstLineAfterEnable = true
+	ppu.updateSTATLine()
+}
+
+// updateSTATLine compares LY to LYC & recalculates the STAT interrupt line,
+// requesting an interrupt if it rises. It should be called after anything
+// affecting any of the STAT sources changes.
+func (ppu *PPU) updateSTATLine() {
+	if !ppu.lcdCtrl.enabled {
+		return
+	}
+
+	ppu.lycEqual = ppu.curScanLine == ppu.cmpScanLine
+
+	statLine := ppu.lcdStatus.InterruptEnabled(ppu)
+	if statLine && !ppu.statLine {
 		ppu.ic.RequestLCD()
 	}
+
+	ppu.statLine = statLine
+}
+
+// stateFields are those from version 0, followed by those added since. New
+// fields should be added at the end.
+func (ppu *PPU) stateFields() []any {
+	return append(ppu.stateFieldsV0(),
+		&ppu.mode3Length,
+		&ppu.statLine,
+		&ppu.lycEqual,
+		&ppu.firstLineAfterEnable,
+		&ppu.baseClock.DoubleSpeed,
+		&ppu.baseClock.Carry,
+		&ppu.stopped,
+	)
+}
+
+func (ppu *PPU) stateFieldsV0() []any {
+	fields := []any{
+		&ppu.Mode,
+		&ppu.lcdCtrl.enabled,
+		&ppu.lcdCtrl.bgWindowEnabled,
+		&ppu.lcdCtrl.windowEnabled,
+		&ppu.lcdCtrl.objectEnabled,
+		&ppu.lcdCtrl.bgTilemap,
+		&ppu.lcdCtrl.bgWindowTileset,
+		&ppu.lcdCtrl.objectSize,
+		&ppu.lcdCtrl.windowTilemap,
+		&ppu.lcdStatus.mode0IntSel,
+		&ppu.lcdStatus.mode1IntSel,
+		&ppu.lcdStatus.mode2IntSel,
+		&ppu.lcdStatus.lycIntSel,
+		&ppu.curScanLine,
+		&ppu.cmpScanLine,
+		&ppu.scrollBackgroundX,
+		&ppu.scrollBackgroundY,
+		&ppu.windowX,
+		&ppu.windowY,
+		&ppu.curWindowLine,
+		&ppu.bgPalette,
+		&ppu.objPalettes,
+		&ppu.objectPriority,
+		&ppu.clock,
+		&ppu.mode3Cycles,
+		&ppu.pixelsRendered,
+		&ppu.dmgCompatibilityEnabled,
+	}
+
+	fields = append(fields, ppu.cgbBGPalettes.stateFields()...)
+	fields = append(fields, ppu.cgbObjPalettes.stateFields()...)
+
+	return fields
 }
@@ -0,0 +1,307 @@
+package ppu
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/maxfierke/gogo-gb/savestate"
+	"github.com/maxfierke/gogo-gb/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testInterruptRequester struct {
+	lcdRequests    int
+	vblankRequests int
+}
+
+func (ic *testInterruptRequester) RequestLCD() {
+	ic.lcdRequests++
+}
+
+func (ic *testInterruptRequester) RequestVBlank() {
+	ic.vblankRequests++
+}
+
+func newTestPPU() (*PPU, *mem.MMU, *testInterruptRequester) {
+	ic := &testInterruptRequester{}
+	ppu := NewPPU(ic, func(ppu *PPU, oam *OAM, vram *VRAM) Renderer {
+		return nullRenderer{}
+	})
+
+	mmu := mem.NewMMU(make([]byte, 0x10000))
+	mmu.AddHandler(mem.MemRegion{Start: VRAM_START, End: VRAM_END}, ppu)
+	mmu.AddHandler(mem.MemRegion{Start: OAM_START, End: OAM_END}, ppu)
+	mmu.AddHandler(mem.MemRegion{Start: REG_PPU_LCDC, End: REG_PPU_LYC}, ppu)
+	mmu.AddHandler(mem.MemRegion{Start: REG_PPU_BCPS_BGPI, End: REG_PPU_BCPD_BGPD}, ppu)
+
+	return ppu, mmu, ic
+}
+
+func stepDots(ppu *PPU, dots int) {
+	for range dots / 4 {
+		ppu.Step(nil, 4)
+	}
+}
+
+// stepUntilLine steps until the start of the given mode on line LY
+func stepUntilLine(ppu *PPU, ly uint8, mode PPUMode) {
+	for range 2 * 154 * CLK_MODE1_PERIOD_LEN / 4 {
+		if ppu.curScanLine == ly && ppu.Mode == mode {
+			return
+		}
+
+		ppu.Step(nil, 4)
+	}
+}
+
+func TestVRAMAndOAMAccess(t *testing.T) {
+	assert := assert.New(t)
+
+	ppu, mmu, _ := newTestPPU()
+
+	// Accessible while the LCD is off
+	mmu.Write8(VRAM_START, 0x12)
+	mmu.Write8(OAM_START, 0x34)
+	mmu.Write8(REG_PPU_BCPS_BGPI, 0x00)
+	mmu.Write8(REG_PPU_BCPD_BGPD, 0x56)
+
+	mmu.Write8(REG_PPU_LCDC, 0x80)
+	ppu.firstLineAfterEnable = false
+
+	testCases := []struct {
+		mode           PPUMode
+		vramAccessible bool
+		oamAccessible  bool
+	}{
+		{mode: PPU_MODE_HBLANK, vramAccessible: true, oamAccessible: true},
+		{mode: PPU_MODE_VBLANK, vramAccessible: true, oamAccessible: true},
+		{mode: PPU_MODE_OAM, vramAccessible: true, oamAccessible: false},
+		{mode: PPU_MODE_VRAM, vramAccessible: false, oamAccessible: false},
+	}
+
+	for _, tc := range testCases {
+		ppu.Mode = tc.mode
+
+		expectedVRAM, expectedOAM, expectedPalette := byte(0xFF), byte(0xFF), byte(0xFF)
+		if tc.vramAccessible {
+			expectedVRAM, expectedPalette = 0x12, 0x56
+		}
+		if tc.oamAccessible {
+			expectedOAM = 0x34
+		}
+
+		assert.Equalf(expectedVRAM, mmu.Read8(VRAM_START), "VRAM in mode %d", tc.mode)
+		assert.Equalf(expectedOAM, mmu.Read8(OAM_START), "OAM in mode %d", tc.mode)
+		assert.Equalf(expectedPalette, mmu.Read8(REG_PPU_BCPD_BGPD), "palette in mode %d", tc.mode)
+
+		// Debuggers can always see the real contents
+		assert.Equalf(byte(0x12), mmu.Peek8(VRAM_START), "peeking VRAM in mode %d", tc.mode)
+		assert.Equalf(byte(0x34), mmu.Peek8(OAM_START), "peeking OAM in mode %d", tc.mode)
+		assert.Equalf(byte(0x56), mmu.Peek8(REG_PPU_BCPD_BGPD), "peeking palette in mode %d", tc.mode)
+	}
+
+	// Writes are dropped while inaccessible
+	ppu.Mode = PPU_MODE_VRAM
+	mmu.Write8(VRAM_START, 0xAB)
+	mmu.Write8(OAM_START, 0xCD)
+	assert.Equal(byte(0x12), mmu.Peek8(VRAM_START))
+	assert.Equal(byte(0x34), mmu.Peek8(OAM_START))
+
+	// ...except for DMA
+	mmu.Poke8(OAM_START, 0xCD)
+	assert.Equal(byte(0xCD), mmu.Peek8(OAM_START))
+}
+
+func TestSTATBlocking(t *testing.T) {
+	assert := assert.New(t)
+
+	ppu, mmu, ic := newTestPPU()
+
+	mmu.Write8(REG_PPU_LYC, 1)
+	mmu.Write8(REG_PPU_LCDSTAT, 1<<LCD_STAT_BIT_MODE_0_INT_SEL|1<<LCD_STAT_BIT_LYC_INT_SEL)
+	mmu.Write8(REG_PPU_LCDC, 0x80)
+	ic.lcdRequests = 0
+
+	stepUntilLine(ppu, 0, PPU_MODE_HBLANK)
+	assert.Equal(1, ic.lcdRequests)
+
+	// LY=LYC holds the line high from the end of line 0's HBlank, through the
+	// end of line 1's HBlank, so neither request an interrupt
+	stepUntilLine(ppu, 1, PPU_MODE_HBLANK)
+	assert.Equal(1, ic.lcdRequests)
+
+	stepUntilLine(ppu, 2, PPU_MODE_HBLANK)
+	assert.Equal(2, ic.lcdRequests)
+}
+
+func TestSTATLY153(t *testing.T) {
+	assert := assert.New(t)
+
+	ppu, mmu, ic := newTestPPU()
+
+	mmu.Write8(REG_PPU_LYC, 0)
+	mmu.Write8(REG_PPU_LCDSTAT, 1<<LCD_STAT_BIT_LYC_INT_SEL)
+	mmu.Write8(REG_PPU_LCDC, 0x80)
+
+	stepUntilLine(ppu, VBLANK_PERIOD_END, PPU_MODE_VBLANK)
+	assert.Equal(byte(VBLANK_PERIOD_END), mmu.Read8(REG_PPU_LY))
+	ic.lcdRequests = 0
+
+	// LY wraps around to 0 early, still in VBlank
+	stepDots(ppu, CLK_LY153_PERIOD_LEN)
+	assert.Equal(byte(0), mmu.Read8(REG_PPU_LY))
+	assert.Equal(PPU_MODE_VBLANK, ppu.Mode)
+	assert.Equal(1, ic.lcdRequests)
+
+	// ...and stays there for the first line of the next frame
+	stepUntilLine(ppu, 0, PPU_MODE_OAM)
+	assert.Equal(byte(0), mmu.Read8(REG_PPU_LY))
+	assert.Equal(1, ic.lcdRequests)
+}
+
+func TestSTATWriteBug(t *testing.T) {
+	for _, color := range []bool{false, true} {
+		ppu, mmu, ic := newTestPPU()
+		if color {
+			ppu.EnableColor()
+		}
+
+		mmu.Write8(REG_PPU_LYC, 100)
+		mmu.Write8(REG_PPU_LCDC, 0x80)
+		stepUntilLine(ppu, 10, PPU_MODE_HBLANK)
+		ic.lcdRequests = 0
+
+		mmu.Write8(REG_PPU_LCDSTAT, 0)
+
+		// Only DMGs request a spurious interrupt
+		expected := 1
+		if color {
+			expected = 0
+		}
+		assert.Equalf(t, expected, ic.lcdRequests, "color: %v", color)
+	}
+}
+
+func TestLCDOff(t *testing.T) {
+	assert := assert.New(t)
+
+	ppu, mmu, _ := newTestPPU()
+
+	mmu.Write8(REG_PPU_LCDC, 0x80)
+	stepUntilLine(ppu, 50, PPU_MODE_VRAM)
+
+	mmu.Write8(REG_PPU_LCDC, 0x00)
+	assert.Equal(byte(0), mmu.Read8(REG_PPU_LY))
+	assert.Equal(uint8(PPU_MODE_HBLANK), mmu.Read8(REG_PPU_LCDSTAT)&0b11)
+
+	r, g, b, _ := ppu.Draw().At(80, 72).RGBA()
+	assert.Equal([3]uint32{0xFFFF, 0xFFFF, 0xFFFF}, [3]uint32{r, g, b})
+
+	// The first line after turning the LCD back on reports mode 0 instead of
+	// mode 2
+	mmu.Write8(REG_PPU_LCDC, 0x80)
+	assert.Equal(uint8(PPU_MODE_HBLANK), mmu.Read8(REG_PPU_LCDSTAT)&0b11)
+	stepUntilLine(ppu, 0, PPU_MODE_VRAM)
+	stepUntilLine(ppu, 1, PPU_MODE_OAM)
+	assert.Equal(uint8(PPU_MODE_OAM), mmu.Read8(REG_PPU_LCDSTAT)&0b11)
+}
+
+func TestNextEvent(t *testing.T) {
+	testCases := []struct {
+		name           string
+		doubleSpeed    bool
+		cyclesPerFrame uint
+	}{
+		{name: "normal speed", cyclesPerFrame: 154 * CLK_MODE1_PERIOD_LEN},
+		{name: "double speed", doubleSpeed: true, cyclesPerFrame: 2 * 154 * CLK_MODE1_PERIOD_LEN},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			ppu, mmu, ic := newTestPPU()
+			ppu.SetDoubleSpeed(tc.doubleSpeed)
+			assert.Equal(scheduler.NEVER, ppu.NextEvent())
+
+			mmu.Write8(REG_PPU_LCDC, 0x80)
+
+			// Stepping by NextEvent should land on every mode & LY change exactly
+			var frameCycles uint
+			for ic.vblankRequests < 2 {
+				mode, ly := ppu.Mode, ppu.curScanLine
+
+				next := ppu.NextEvent()
+				for remaining := next - 1; remaining > 0; {
+					cycles := min(remaining, 0xFF)
+					remaining -= cycles
+
+					ppu.Step(mmu, uint8(cycles))
+					assert.Equal(mode, ppu.Mode)
+					assert.Equal(ly, ppu.curScanLine)
+				}
+
+				ppu.Step(mmu, 1)
+				assert.True(ppu.Mode != mode || ppu.curScanLine != ly)
+
+				if ic.vblankRequests == 1 {
+					frameCycles += next
+				}
+			}
+
+			assert.Equal(tc.cyclesPerFrame, frameCycles)
+		})
+	}
+}
+
+func TestLoadStateVersion0(t *testing.T) {
+	assert := assert.New(t)
+
+	ppu, mmu, _ := newTestPPU()
+	mmu.Write8(VRAM_START+0x10, 0xAB)
+	mmu.Write8(OAM_START+0x04, 0xCD)
+	mmu.Write8(REG_PPU_LYC, 50)
+	mmu.Write8(REG_PPU_LCDSTAT, 0x40)
+	mmu.Write8(REG_PPU_LCDC, 0x80)
+	ppu.SetDMGCompatibilityEnabled(true)
+	ppu.cgbObjPalettes.addr = 0x05
+	stepUntilLine(ppu, 50, PPU_MODE_VRAM)
+
+	// Written before fields were added to the PPU, & chunks were versioned
+	var data bytes.Buffer
+	require.NoError(t, savestate.Write(&data, ppu.stateFieldsV0()...))
+	require.NoError(t, ppu.oam.SaveState(&data))
+	require.NoError(t, ppu.vram.SaveState(&data))
+	require.NoError(t, ppu.renderer.SaveState(&data))
+
+	var state bytes.Buffer
+	state.Write([]byte{3, 'p', 'p', 'u'})
+	require.NoError(t, binary.Write(&state, binary.LittleEndian, uint32(data.Len())))
+	state.Write(data.Bytes())
+
+	chunks, err := savestate.ReadChunks(&state)
+	require.NoError(t, err)
+
+	loaded, _, _ := newTestPPU()
+	require.NoError(t, chunks.LoadChunk("ppu", loaded))
+
+	assert.Equal(PPU_MODE_VRAM, loaded.Mode)
+	assert.Equal(uint8(50), loaded.curScanLine)
+	assert.True(loaded.dmgCompatibilityEnabled)
+	assert.Equal(uint8(0x05), loaded.cgbObjPalettes.addr)
+	assert.Equal(ppu.oam.raw, loaded.oam.raw)
+	assert.Equal(ppu.vram, loaded.vram)
+
+	// Fields added since are recalculated to match
+	assert.Equal(ppu.mode3Length, loaded.mode3Length)
+	assert.True(loaded.lycEqual)
+	assert.True(loaded.statLine)
+
+	var expected, actual bytes.Buffer
+	require.NoError(t, savestate.Write(&expected, ppu.stateFields()...))
+	require.NoError(t, savestate.Write(&actual, loaded.stateFields()...))
+	assert.Equal(expected.Bytes(), actual.Bytes())
+}
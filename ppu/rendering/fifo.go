@@ -0,0 +1,541 @@
+package rendering
+
+import (
+	"image"
+	"image/color"
+	"io"
+
+	"github.com/maxfierke/gogo-gb/ppu"
+	"github.com/maxfierke/gogo-gb/savestate"
+)
+
+const (
+	// FIFO_STARTUP_DOTS is the length of the fetcher's first fetch of each line,
+	// which is thrown away
+	FIFO_STARTUP_DOTS = 6
+
+	// FIFO_FETCH_DOTS is the length of a tile fetch: 2 dots each for the tile
+	// index, and the low & high bytes of the tile row. Pushing to the FIFO takes
+	// at least one more dot.
+	FIFO_FETCH_DOTS = 6
+
+	// FIFO_OBJ_FETCH_DOTS is the length of an object fetch, once the BG fetch
+	// it interrupts has finished
+	FIFO_OBJ_FETCH_DOTS = 6
+
+	FIFO_SIZE = 8
+)
+
+// FIFORenderer models the pixel pipeline dot-by-dot: a fetcher that reads BG
+// & window tiles into a pixel FIFO, object fetches which stall it, and a
+// shifter which mixes the BG & object FIFOs out to the LCD one pixel per dot.
+// Registers & palettes are read as each tile is fetched or pixel is drawn, so
+// writes made mid-scanline take effect from the next affected pixel.
+type FIFORenderer struct {
+	ppu  *ppu.PPU
+	oam  *ppu.OAM
+	vram *ppu.VRAM
+
+	framebuf framebuffer
+
+	line            fifoLine
+	windowTriggered bool // Whether LY has matched WY yet this frame
+}
+
+// fifoLine is the progress of the pixel pipeline through the current line.
+// Fields are exported so that it can be written as-is to save states.
+type fifoLine struct {
+	Started     bool
+	LY          uint8
+	Dots        uint16
+	LineX       uint8 // Pixels drawn so far
+	Discard     uint8 // Pixels left to discard, for fine scrolling
+	WindowDrawn bool
+
+	Fetcher fifoFetcher
+	BG      pixelFIFO[bgPixel]
+	Obj     pixelFIFO[objPixel]
+
+	// Objects found on this line during OAM scan, by OAM index
+	Objects        [ppu.OAM_MAX_OBJECTS_PER_SCANLINE]uint8
+	ObjectCount    uint8
+	ObjectsFetched [ppu.OAM_MAX_OBJECTS_PER_SCANLINE]bool
+	ObjFetching    bool
+	ObjFetchDots   uint8
+	ObjFetchIndex  uint8
+}
+
+type fifoFetcher struct {
+	Dots       uint8 // Progress through the current fetch, up to FIFO_FETCH_DOTS
+	TileX      uint8 // Tiles fetched so far on this line, in the BG or window
+	Window     bool  // Whether fetching window tiles, rather than BG tiles
+	TileIndex  uint8
+	TileRow    uint8
+	Attributes ppu.BGAttributes
+	Row        [FIFO_SIZE]bgPixel
+}
+
+type bgPixel struct {
+	ColorID    ppu.ColorID
+	Attributes ppu.BGAttributes
+}
+
+type objPixel struct {
+	ColorID    ppu.ColorID
+	OAMIndex   uint8
+	Attributes ppu.ObjectAttributes
+}
+
+type pixelFIFO[T any] struct {
+	Pixels [FIFO_SIZE]T
+	Head   uint8
+	Len    uint8
+}
+
+var _ ppu.Renderer = (*FIFORenderer)(nil)
+
+func FIFO(ppu *ppu.PPU, oam *ppu.OAM, vram *ppu.VRAM) ppu.Renderer {
+	return &FIFORenderer{
+		ppu:  ppu,
+		oam:  oam,
+		vram: vram,
+	}
+}
+
+func (r *FIFORenderer) DrawImage() image.Image {
+	return r.framebuf.image()
+}
+
+func (r *FIFORenderer) LoadState(rd io.Reader) error {
+	if err := r.framebuf.loadState(rd); err != nil {
+		return err
+	}
+
+	return savestate.Read(rd, &r.line, &r.windowTriggered)
+}
+
+func (r *FIFORenderer) SaveState(w io.Writer) error {
+	if err := r.framebuf.saveState(w); err != nil {
+		return err
+	}
+
+	return savestate.Write(w, &r.line, &r.windowTriggered)
+}
+
+func (r *FIFORenderer) Step(dots uint16) uint8 {
+	if !r.ppu.IsLCDEnabled() || r.ppu.CurrentScanline() >= FB_HEIGHT {
+		return 0
+	}
+
+	// A line may have been abandoned part-way through by the LCD being turned off
+	if !r.line.Started || r.line.LY != r.ppu.CurrentScanline() {
+		r.startLine()
+	}
+
+	var pixels uint8
+	for r.line.Dots < dots && r.line.LineX < FB_WIDTH {
+		if r.tick() {
+			pixels++
+		}
+	}
+
+	if r.line.LineX == FB_WIDTH {
+		r.endLine()
+	}
+
+	return pixels
+}
+
+func (r *FIFORenderer) startLine() {
+	currentScanLine := r.ppu.CurrentScanline()
+
+	if currentScanLine == 0 {
+		r.windowTriggered = false
+	}
+
+	if currentScanLine == r.ppu.WindowY() {
+		r.windowTriggered = true
+	}
+
+	r.line = fifoLine{
+		Started: true,
+		LY:      currentScanLine,
+		Discard: r.ppu.ScrollBackgroundX() % 8,
+	}
+
+	r.scanOAM()
+}
+
+func (r *FIFORenderer) endLine() {
+	if r.line.WindowDrawn {
+		r.ppu.IncrementWindowLine()
+	}
+
+	r.line = fifoLine{}
+}
+
+// scanOAM selects the first 10 objects (in OAM order) on the current line
+func (r *FIFORenderer) scanOAM() {
+	currentScanLine := int(r.ppu.CurrentScanline())
+	objHeight := r.objectHeight()
+
+	for oamIndex, object := range r.oam.Objects() {
+		if r.line.ObjectCount == ppu.OAM_MAX_OBJECTS_PER_SCANLINE {
+			break
+		}
+
+		objY := objectY(object)
+		if objY <= currentScanLine && currentScanLine < objY+objHeight {
+			r.line.Objects[r.line.ObjectCount] = uint8(oamIndex)
+			r.line.ObjectCount++
+		}
+	}
+}
+
+// tick advances the pipeline by a single dot, returning whether a pixel was
+// drawn
+func (r *FIFORenderer) tick() bool {
+	line := &r.line
+	line.Dots++
+
+	if line.Dots <= FIFO_STARTUP_DOTS {
+		return false
+	}
+
+	if line.ObjFetching {
+		r.tickObjFetch()
+
+		return false
+	}
+
+	if line.Discard == 0 && r.ppu.IsObjectEnabled() {
+		if index, found := r.pendingObject(); found {
+			line.ObjFetching = true
+			line.ObjFetchDots = 0
+			line.ObjFetchIndex = index
+			r.tickObjFetch()
+
+			return false
+		}
+	}
+
+	r.checkWindow()
+	r.tickFetcher()
+
+	return r.shiftPixel()
+}
+
+// pendingObject finds an object starting at the current pixel which hasn't
+// been fetched yet
+func (r *FIFORenderer) pendingObject() (uint8, bool) {
+	objects := r.oam.Objects()
+
+	for i := range r.line.ObjectCount {
+		if r.line.ObjectsFetched[i] {
+			continue
+		}
+
+		objX := objectX(objects[r.line.Objects[i]])
+		if objX == int(r.line.LineX) || (objX < 0 && objX > -8 && r.line.LineX == 0) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func (r *FIFORenderer) tickObjFetch() {
+	line := &r.line
+
+	// The BG fetch in progress has to finish first
+	if line.Fetcher.Dots < FIFO_FETCH_DOTS {
+		r.tickFetcher()
+
+		return
+	}
+
+	line.ObjFetchDots++
+	if line.ObjFetchDots < FIFO_OBJ_FETCH_DOTS {
+		return
+	}
+
+	r.fetchObject(line.ObjFetchIndex)
+	line.ObjectsFetched[line.ObjFetchIndex] = true
+	line.ObjFetching = false
+}
+
+// fetchObject merges an object's row of pixels into the object FIFO. Pixels
+// already in the FIFO take priority, as objects are fetched in order of x
+// position, except in CGB mode, where objects earlier in OAM take priority.
+func (r *FIFORenderer) fetchObject(index uint8) {
+	oamIndex := r.line.Objects[index]
+	object := r.oam.Objects()[oamIndex]
+
+	objPixelY := uint8(int(r.ppu.CurrentScanline()) - objectY(object))
+	tile := r.vram.GetObjTile(
+		object,
+		r.ppu.ObjectSize(),
+		objPixelY,
+		r.ppu.IsColorEnabled(),
+	)
+
+	tilePixelY := objPixelY % 8
+	tileRow := tile[tilePixelY]
+	if object.Attributes.FlipY {
+		tileRow = tile[7-tilePixelY]
+	}
+
+	// Objects partially off the left edge have their first pixels cut off
+	skip := 0
+	if objX := objectX(object); objX < 0 {
+		skip = -objX
+	}
+
+	cgbPriority := r.ppu.ObjectPriority() == ppu.ObjectPriorityModeCGB && r.ppu.IsColorEnabled()
+
+	r.line.Obj.fill()
+
+	for x := skip; x < FIFO_SIZE; x++ {
+		tilePixelX := x
+		if object.Attributes.FlipX {
+			tilePixelX = 7 - x
+		}
+
+		pixel := objPixel{
+			ColorID:    ppu.ColorID(tileRow[tilePixelX]),
+			OAMIndex:   oamIndex,
+			Attributes: object.Attributes,
+		}
+
+		if pixel.ColorID == ppu.COLOR_ID_TRANSPARENT {
+			continue
+		}
+
+		existing := r.line.Obj.at(uint8(x - skip))
+		if existing.ColorID == ppu.COLOR_ID_TRANSPARENT || (cgbPriority && pixel.OAMIndex < existing.OAMIndex) {
+			*existing = pixel
+		}
+	}
+}
+
+// checkWindow switches the fetcher over to the window once the window's
+// left edge is reached, throwing away any BG pixels already fetched
+func (r *FIFORenderer) checkWindow() {
+	line := &r.line
+
+	if line.Fetcher.Window || !r.windowTriggered || !r.ppu.IsWindowEnabled() {
+		return
+	}
+
+	windowX := r.ppu.WindowX()
+	if int(line.LineX)+7 < int(windowX) {
+		return
+	}
+
+	line.BG.clear()
+	line.Fetcher = fifoFetcher{Window: true}
+	line.WindowDrawn = true
+
+	line.Discard = 0
+	if windowX < 7 {
+		line.Discard = 7 - windowX
+	}
+}
+
+func (r *FIFORenderer) tickFetcher() {
+	fetcher := &r.line.Fetcher
+
+	if fetcher.Dots < FIFO_FETCH_DOTS {
+		fetcher.Dots++
+
+		switch fetcher.Dots {
+		case 2:
+			r.fetchTileIndex()
+		case FIFO_FETCH_DOTS:
+			r.fetchTileData()
+		}
+
+		return
+	}
+
+	// The BG FIFO only accepts a new row of pixels once it's empty
+	if r.line.BG.Len == 0 {
+		r.line.BG.push(fetcher.Row)
+		fetcher.TileX++
+		fetcher.Dots = 0
+	}
+}
+
+func (r *FIFORenderer) fetchTileIndex() {
+	fetcher := &r.line.Fetcher
+
+	tileMap := r.ppu.GetBGTilemap()
+	tileX := (r.ppu.ScrollBackgroundX()/8 + fetcher.TileX) % 32
+	pixelY := r.ppu.CurrentScanline() + r.ppu.ScrollBackgroundY()
+
+	if fetcher.Window {
+		tileMap = r.ppu.GetWindowTilemap()
+		tileX = fetcher.TileX % 32
+		pixelY = r.ppu.CurrentWindowLine()
+	}
+
+	tileY := pixelY / 8
+	fetcher.TileRow = pixelY % 8
+	fetcher.TileIndex = r.vram.GetBGTileIndex(tileMap, tileX, tileY)
+
+	fetcher.Attributes = ppu.BGAttributes{}
+	if r.ppu.IsColorEnabled() {
+		fetcher.Attributes = r.vram.GetBGTileAttributes(tileMap, tileX, tileY)
+	}
+}
+
+func (r *FIFORenderer) fetchTileData() {
+	fetcher := &r.line.Fetcher
+
+	tile := r.vram.GetBGTile(
+		fetcher.Attributes.VRAMBank,
+		r.ppu.GetBGWindowTileset(),
+		fetcher.TileIndex,
+	)
+
+	tileRow := tile[fetcher.TileRow]
+	if fetcher.Attributes.FlipY {
+		tileRow = tile[7-fetcher.TileRow]
+	}
+
+	for x := range fetcher.Row {
+		tilePixelX := x
+		if fetcher.Attributes.FlipX {
+			tilePixelX = 7 - x
+		}
+
+		fetcher.Row[x] = bgPixel{
+			ColorID:    ppu.ColorID(tileRow[tilePixelX]),
+			Attributes: fetcher.Attributes,
+		}
+	}
+}
+
+// shiftPixel shifts a pixel out of the FIFOs & onto the LCD, if there's one
+// ready
+func (r *FIFORenderer) shiftPixel() bool {
+	line := &r.line
+
+	if line.BG.Len == 0 {
+		return false
+	}
+
+	bg := line.BG.pop()
+
+	var obj objPixel
+	if line.Obj.Len > 0 {
+		obj = line.Obj.pop()
+	}
+
+	if line.Discard > 0 {
+		line.Discard--
+
+		return false
+	}
+
+	r.framebuf[r.ppu.CurrentScanline()][line.LineX] = r.mixPixel(bg, obj)
+	line.LineX++
+
+	return true
+}
+
+func (r *FIFORenderer) mixPixel(bg bgPixel, obj objPixel) RenderedPixel {
+	pixel := RenderedPixel{
+		Layer:   PIXEL_LAYER_BG,
+		ColorID: ppu.COLOR_ID_WHITE,
+		Color:   color.White,
+	}
+
+	if r.ppu.IsBackgroundEnabled() {
+		pixel.ColorID = bg.ColorID
+		pixel.Color = r.ppu.GetBGPaletteColor(bg.ColorID, bg.Attributes.PaletteID)
+
+		if bg.Attributes.Priority && r.ppu.IsColorEnabled() {
+			pixel.Layer = PIXEL_LAYER_BGP
+		}
+	}
+
+	if obj.ColorID == ppu.COLOR_ID_TRANSPARENT || !r.ppu.IsObjectEnabled() {
+		return pixel
+	}
+
+	if pixel.ColorID == ppu.COLOR_ID_WHITE || // BG is color 0
+		// CGB: BG master priority isn't set
+		(r.ppu.IsColorEnabled() && !r.ppu.IsMasterBGPriorityEnabled()) ||
+		// BG doesn't have priority (CGB) AND OBJ has priority over BG
+		(pixel.Layer != PIXEL_LAYER_BGP && !obj.Attributes.BGPriority) {
+		return RenderedPixel{
+			Layer:   PIXEL_LAYER_OBJ,
+			ColorID: obj.ColorID,
+			Color:   r.ppu.GetObjPaletteColor(obj.ColorID, obj.Attributes),
+		}
+	}
+
+	return pixel
+}
+
+func (r *FIFORenderer) objectHeight() int {
+	if r.ppu.ObjectSize() == ppu.OBJ_SIZE_8x16 {
+		return 16
+	}
+
+	return 8
+}
+
+// objectX returns an object's x position on screen, which is negative for
+// objects partially off the left edge
+func objectX(object ppu.ObjectData) int {
+	if object.PosX >= 256-8 {
+		return int(object.PosX) - 256
+	}
+
+	return int(object.PosX)
+}
+
+// objectY returns an object's y position on screen, which is negative for
+// objects partially off the top edge
+func objectY(object ppu.ObjectData) int {
+	if object.PosY >= 256-16 {
+		return int(object.PosY) - 256
+	}
+
+	return int(object.PosY)
+}
+
+func (fifo *pixelFIFO[T]) at(i uint8) *T {
+	return &fifo.Pixels[(fifo.Head+i)%FIFO_SIZE]
+}
+
+func (fifo *pixelFIFO[T]) clear() {
+	fifo.Head = 0
+	fifo.Len = 0
+}
+
+// fill pads the FIFO out to its full size w/ zero-value (transparent) pixels
+func (fifo *pixelFIFO[T]) fill() {
+	var zero T
+
+	for fifo.Len < FIFO_SIZE {
+		*fifo.at(fifo.Len) = zero
+		fifo.Len++
+	}
+}
+
+func (fifo *pixelFIFO[T]) pop() T {
+	pixel := fifo.Pixels[fifo.Head]
+	fifo.Head = (fifo.Head + 1) % FIFO_SIZE
+	fifo.Len--
+
+	return pixel
+}
+
+func (fifo *pixelFIFO[T]) push(pixels [FIFO_SIZE]T) {
+	fifo.Pixels = pixels
+	fifo.Head = 0
+	fifo.Len = FIFO_SIZE
+}
@@ -0,0 +1,150 @@
+package rendering
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/maxfierke/gogo-gb/ppu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInterruptRequester struct{}
+
+func (fakeInterruptRequester) RequestLCD()    {}
+func (fakeInterruptRequester) RequestVBlank() {}
+
+func newTestPPU(renderer ppu.RendererConstructor) (*ppu.PPU, ppu.Renderer) {
+	var r ppu.Renderer
+	p := ppu.NewPPU(fakeInterruptRequester{}, func(p *ppu.PPU, oam *ppu.OAM, vram *ppu.VRAM) ppu.Renderer {
+		r = renderer(p, oam, vram)
+
+		return r
+	})
+
+	return p, r
+}
+
+// stepUntil steps the PPU until cond is met, failing if it takes longer than
+// a couple of frames
+func stepUntil(t *testing.T, p *ppu.PPU, cond func() bool) {
+	t.Helper()
+
+	for range 2 * 70224 / 4 {
+		if cond() {
+			return
+		}
+
+		p.Step(nil, 4)
+	}
+
+	require.FailNow(t, "PPU never reached expected state")
+}
+
+func stepFrame(t *testing.T, p *ppu.PPU) image.Image {
+	t.Helper()
+
+	stepUntil(t, p, func() bool { return p.Mode != ppu.PPU_MODE_VBLANK })
+	stepUntil(t, p, func() bool { return p.Mode == ppu.PPU_MODE_VBLANK })
+
+	return p.Draw()
+}
+
+func write(p *ppu.PPU, addr uint16, values ...byte) {
+	for i, value := range values {
+		p.OnWrite(nil, addr+uint16(i), value)
+	}
+}
+
+// setupScene draws a BG of tiles w/ each color, a window over the bottom
+// right, and a few objects, including one partially off-screen
+func setupScene(t *testing.T, p *ppu.PPU) {
+	t.Helper()
+
+	write(p, ppu.REG_PPU_LCDC, 0xB3) // LCD, window, 8000 tileset, objects, BG
+	stepUntil(t, p, func() bool { return p.Mode == ppu.PPU_MODE_VBLANK })
+
+	for tile := range uint16(4) {
+		for row := range uint16(8) {
+			// Every color, w/ a diagonal to catch flips & fine scrolling
+			write(p, 0x8000+tile*16+row*2, 0x55^byte(1<<row), 0x33+byte(tile))
+		}
+	}
+
+	for i := range uint16(32 * 32) {
+		write(p, 0x9800+i, byte(i%3)+byte(i/32)%2)
+	}
+
+	write(p, ppu.REG_PPU_SCX, 3)
+	write(p, ppu.REG_PPU_SCY, 5)
+	write(p, ppu.REG_PPU_WX, 87)
+	write(p, ppu.REG_PPU_WY, 100)
+	write(p, ppu.REG_PPU_BGP, 0xE4)
+	write(p, ppu.REG_PPU_OBP0, 0xD2)
+	write(p, ppu.REG_PPU_OBP1, 0x1B)
+
+	objects := [][4]byte{
+		{16 + 30, 8 + 20, 3, 0x00},
+		{16 + 34, 8 + 24, 2, 0x30},   // Overlapping, flipped in X
+		{16 + 60, 4, 1, 0x10},        // Partially off the left edge
+		{16 + 80, 8 + 100, 3, 0x40},  // Flipped in Y
+		{16 + 110, 8 + 150, 2, 0x80}, // Behind the BG & window
+	}
+	for i, object := range objects {
+		write(p, ppu.OAM_START+uint16(i*4), object[:]...)
+	}
+}
+
+func TestFIFORendererMatchesScanline(t *testing.T) {
+	assert := assert.New(t)
+
+	scanlinePPU, _ := newTestPPU(Scanline)
+	fifoPPU, _ := newTestPPU(FIFO)
+
+	setupScene(t, scanlinePPU)
+	setupScene(t, fifoPPU)
+
+	expected := stepFrame(t, scanlinePPU)
+	actual := stepFrame(t, fifoPPU)
+
+	for y := range FB_HEIGHT {
+		for x := range FB_WIDTH {
+			if !assert.Equalf(expected.At(x, y), actual.At(x, y), "pixel (%d, %d) differs", x, y) {
+				return
+			}
+		}
+	}
+}
+
+func TestFIFORendererMidScanlineWrite(t *testing.T) {
+	assert := assert.New(t)
+
+	p, r := newTestPPU(FIFO)
+	fifo, ok := r.(*FIFORenderer)
+	require.True(t, ok)
+
+	write(p, ppu.REG_PPU_LCDC, 0x91)
+	write(p, ppu.REG_PPU_BGP, 0xE4)
+	stepUntil(t, p, func() bool { return p.Mode == ppu.PPU_MODE_VBLANK })
+
+	// Change the palette half-way through drawing line 10
+	stepUntil(t, p, func() bool {
+		return p.CurrentScanline() == 10 && p.Mode == ppu.PPU_MODE_VRAM && fifo.line.LineX >= 80
+	})
+	changedAt := int(fifo.line.LineX)
+	write(p, ppu.REG_PPU_BGP, 0xE7)
+
+	stepUntil(t, p, func() bool { return p.Mode == ppu.PPU_MODE_VBLANK })
+	frame := p.Draw()
+
+	for x := range FB_WIDTH {
+		expected := color.Color(color.White)
+		if x >= changedAt {
+			expected = color.Black
+		}
+
+		assert.Equalf(color.GrayModel.Convert(expected), color.GrayModel.Convert(frame.At(x, 10)), "pixel %d differs", x)
+		assert.Equalf(color.GrayModel.Convert(color.White), color.GrayModel.Convert(frame.At(x, 9)), "pixel %d differs", x)
+	}
+}
@@ -0,0 +1,110 @@
+package rendering
+
+import (
+	"image"
+	"image/color"
+	"io"
+
+	"github.com/maxfierke/gogo-gb/ppu"
+	"github.com/maxfierke/gogo-gb/savestate"
+)
+
+type RenderedPixel struct {
+	Layer   PixelLayer
+	ColorID ppu.ColorID
+	Color   color.Color
+}
+
+type PixelLayer uint8
+
+const (
+	PIXEL_LAYER_BG  PixelLayer = iota // Background/window layer
+	PIXEL_LAYER_BGP                   // Background/window layer w/ priority over objects
+	PIXEL_LAYER_OBJ                   // Object layer
+)
+
+type framebuffer [FB_HEIGHT][FB_WIDTH]RenderedPixel
+
+func (fb *framebuffer) image() image.Image {
+	fbImage := image.NewRGBA(
+		image.Rect(0, 0, FB_WIDTH, FB_HEIGHT),
+	)
+
+	for y := range FB_HEIGHT {
+		for x, pixel := range fb[y] {
+			if pixel.Color != nil {
+				fbImage.Set(x, y, pixel.Color)
+			}
+		}
+	}
+
+	return fbImage
+}
+
+// loadState restores the framebuffer. Colors are restored as plain RGBA values,
+// w/ fully-transparent pixels being treated as not yet drawn.
+func (fb *framebuffer) loadState(r io.Reader) error {
+	var saved [FB_HEIGHT][FB_WIDTH]savedPixel
+
+	if err := savestate.Read(r, &saved); err != nil {
+		return err
+	}
+
+	for y := range saved {
+		for x, pixel := range saved[y] {
+			fb[y][x] = pixel.restore()
+		}
+	}
+
+	return nil
+}
+
+func (fb *framebuffer) saveState(w io.Writer) error {
+	var saved [FB_HEIGHT][FB_WIDTH]savedPixel
+
+	for y := range fb {
+		for x, pixel := range fb[y] {
+			saved[y][x] = newSavedPixel(pixel)
+		}
+	}
+
+	return savestate.Write(w, &saved)
+}
+
+type savedPixel struct {
+	Layer   PixelLayer
+	ColorID ppu.ColorID
+	Color   color.RGBA
+}
+
+func newSavedPixel(pixel RenderedPixel) savedPixel {
+	saved := savedPixel{
+		Layer:   pixel.Layer,
+		ColorID: pixel.ColorID,
+	}
+
+	if pixel.Color != nil {
+		red, green, blue, alpha := pixel.Color.RGBA()
+		saved.Color = color.RGBA{
+			R: uint8(red >> 8),
+			G: uint8(green >> 8),
+			B: uint8(blue >> 8),
+			A: uint8(alpha >> 8),
+		}
+	}
+
+	return saved
+}
+
+func (saved savedPixel) restore() RenderedPixel {
+	pixel := RenderedPixel{
+		Layer:   saved.Layer,
+		ColorID: saved.ColorID,
+	}
+
+	if saved.Color.A != 0 {
+		pixel.Color = saved.Color
+	}
+
+	return pixel
+}
@@ -3,6 +3,7 @@ package rendering
 import (
 	"image"
 	"image/color"
+	"io"
 
 	"github.com/maxfierke/gogo-gb/ppu"
 )
@@ -19,26 +20,12 @@ const (
 	SCANLINE_CLK_MODE3_PERIOD_LEN = 174
 )
 
-type RenderedPixel struct {
-	Layer   PixelLayer
-	ColorID ppu.ColorID
-	Color   color.Color
-}
-
-type PixelLayer uint8
-
-const (
-	PIXEL_LAYER_BG  PixelLayer = iota // Background/window layer
-	PIXEL_LAYER_BGP                   // Background/window layer w/ priority over objects
-	PIXEL_LAYER_OBJ                   // Object layer
-)
-
 type ScanlineRenderer struct {
 	ppu  *ppu.PPU
 	oam  *ppu.OAM
 	vram *ppu.VRAM
 
-	framebuf [FB_HEIGHT][FB_WIDTH]RenderedPixel
+	framebuf framebuffer
 }
 
 var _ ppu.Renderer = (*ScanlineRenderer)(nil)
@@ -52,22 +39,18 @@ func Scanline(ppu *ppu.PPU, oam *ppu.OAM, vram *ppu.VRAM) ppu.Renderer {
 }
 
 func (r *ScanlineRenderer) DrawImage() image.Image {
-	fbImage := image.NewRGBA(
-		image.Rect(0, 0, FB_WIDTH, FB_HEIGHT),
-	)
-
-	for y := range FB_HEIGHT {
-		for x, pixel := range r.framebuf[y] {
-			if pixel.Color != nil {
-				fbImage.Set(x, y, pixel.Color)
-			}
-		}
-	}
+	return r.framebuf.image()
+}
 
-	return fbImage
+func (r *ScanlineRenderer) LoadState(rd io.Reader) error {
+	return r.framebuf.loadState(rd)
 }
 
-func (r *ScanlineRenderer) Step(cycles uint8) uint8 {
+func (r *ScanlineRenderer) SaveState(w io.Writer) error {
+	return r.framebuf.saveState(w)
+}
+
+func (r *ScanlineRenderer) Step(cycles uint16) uint8 {
 	if !r.ppu.IsLCDEnabled() || r.ppu.CurrentScanline() >= FB_HEIGHT {
 		return 0
 	}
@@ -313,7 +296,9 @@ func (r *ScanlineRenderer) readPixel(x, y uint8) RenderedPixel {
 }
 
 func (r *ScanlineRenderer) writePixel(x, y uint8, colorID ppu.ColorID, color color.Color, layer PixelLayer) {
-	r.framebuf[y][x].Color = color
-	r.framebuf[y][x].ColorID = colorID
-	r.framebuf[y][x].Layer = layer
+	r.framebuf[y][x] = RenderedPixel{
+		Layer:   layer,
+		ColorID: colorID,
+		Color:   color,
+	}
 }
@@ -1,5 +1,11 @@
 package ppu
 
+import (
+	"io"
+
+	"github.com/maxfierke/gogo-gb/savestate"
+)
+
 const (
 	VRAM_BANKS                  = 2 // Bank 0/1. Bank 1 is CGB only
 	VRAM_START           uint16 = 0x8000
@@ -39,10 +45,34 @@ type VRAM struct {
 	tileset         [VRAM_BANKS][VRAM_TILESET_SIZE]Tile
 }
 
+var _ savestate.Serializer = (*VRAM)(nil)
+
 func NewVRAM() *VRAM {
 	return &VRAM{}
 }
 
+// LoadState restores both banks, and re-decodes the tileset and BG attributes
+// from them
+func (v *VRAM) LoadState(r io.Reader) error {
+	var banks [VRAM_BANKS][VRAM_SIZE]byte
+
+	if err := savestate.Read(r, &v.CurrentBank, &banks); err != nil {
+		return err
+	}
+
+	currentBank := v.CurrentBank
+	for bank := range banks {
+		v.CurrentBank = uint8(bank)
+
+		for vramAddr, value := range banks[bank] {
+			v.Write(uint16(vramAddr), value)
+		}
+	}
+	v.CurrentBank = currentBank
+
+	return nil
+}
+
 func (v *VRAM) Read(vramAddr uint16) byte {
 	return v.vram[v.CurrentBank][vramAddr]
 }
@@ -57,6 +87,10 @@ func (v *VRAM) Write(vramAddr uint16, value byte) {
 	}
 }
 
+func (v *VRAM) SaveState(w io.Writer) error {
+	return savestate.Write(w, &v.CurrentBank, &v.vram)
+}
+
 func (v *VRAM) SetCurrentBank(value uint8) {
 	if value > 1 {
 		panic("illegal vram bank")
@@ -0,0 +1,170 @@
+package savestate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// chunkVersioned is set in the length of a chunk's name when the name's
+// followed by the chunk's version. Chunks from before they were versioned
+// don't have it set, & are version 0.
+const chunkVersioned = 0x80
+
+var (
+	ErrDuplicateChunk          = errors.New("savestate: duplicate chunk")
+	ErrMissingChunk            = errors.New("savestate: missing chunk")
+	ErrUnsupportedChunkVersion = errors.New("savestate: unsupported chunk version")
+)
+
+// ChunkWriter writes the state of each component as a named, versioned &
+// length-prefixed chunk, so that a reader can skip over chunks it doesn't know
+// about, & migrate chunks from older versions of a component
+type ChunkWriter struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+func NewChunkWriter(w io.Writer) *ChunkWriter {
+	return &ChunkWriter{w: w}
+}
+
+func (cw *ChunkWriter) WriteChunk(name string, component Serializer) error {
+	if len(name) >= chunkVersioned {
+		return fmt.Errorf("chunk name %q is too long", name)
+	}
+
+	var version uint8
+	if versioned, ok := component.(Versioned); ok {
+		version = versioned.StateVersion()
+	}
+
+	cw.buf.Reset()
+	if err := component.SaveState(&cw.buf); err != nil {
+		return err
+	}
+
+	header := make([]byte, 0, 1+len(name)+1+4)
+	header = append(header, uint8(len(name))|chunkVersioned)
+	header = append(header, name...)
+	header = append(header, version)
+	header = binary.LittleEndian.AppendUint32(header, uint32(cw.buf.Len()))
+
+	if _, err := cw.w.Write(header); err != nil {
+		return fmt.Errorf("writing chunk %q header: %w", name, err)
+	}
+
+	if _, err := cw.buf.WriteTo(cw.w); err != nil {
+		return fmt.Errorf("writing chunk %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// ChunkReader holds the chunks read from a save state, by name
+type ChunkReader struct {
+	chunks map[string]chunk
+}
+
+type chunk struct {
+	version uint8
+	data    []byte
+}
+
+// ReadChunks reads chunks until EOF
+func ReadChunks(r io.Reader) (*ChunkReader, error) {
+	cr := &ChunkReader{chunks: map[string]chunk{}}
+
+	for {
+		var nameLen uint8
+		if err := binary.Read(r, binary.LittleEndian, &nameLen); errors.Is(err, io.EOF) {
+			return cr, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("reading chunk header: %w", err)
+		}
+
+		name := make([]byte, nameLen&^chunkVersioned)
+		if _, err := io.ReadFull(r, name); err != nil {
+			return nil, fmt.Errorf("reading chunk name: %w", err)
+		}
+
+		var version uint8
+		if nameLen&chunkVersioned != 0 {
+			if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+				return nil, fmt.Errorf("reading chunk %q version: %w", name, err)
+			}
+		}
+
+		var dataLen uint32
+		if err := binary.Read(r, binary.LittleEndian, &dataLen); err != nil {
+			return nil, fmt.Errorf("reading chunk %q length: %w", name, err)
+		}
+
+		var data bytes.Buffer
+		if _, err := io.CopyN(&data, r, int64(dataLen)); err != nil {
+			return nil, fmt.Errorf("reading chunk %q: %w", name, err)
+		}
+
+		if _, exists := cr.chunks[string(name)]; exists {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateChunk, name)
+		}
+
+		cr.chunks[string(name)] = chunk{version: version, data: data.Bytes()}
+	}
+}
+
+// LoadChunk restores a component from the named chunk. Chunks written before
+// a component gained new (trailing) fields are padded w/ zeros, so those
+// fields are restored as their zero values. Chunks from an older version of a
+// Versioned component are migrated by it.
+func (cr *ChunkReader) LoadChunk(name string, component Serializer) error {
+	c, exists := cr.chunks[name]
+	if !exists {
+		return fmt.Errorf("%w: %q", ErrMissingChunk, name)
+	}
+
+	r := io.MultiReader(bytes.NewReader(c.data), zeroReader{})
+
+	versioned, isVersioned := component.(Versioned)
+
+	var version uint8
+	if isVersioned {
+		version = versioned.StateVersion()
+	}
+
+	switch {
+	case c.version == version:
+		return component.LoadState(r)
+	case c.version > version:
+		return fmt.Errorf("%w: %q is version %d, expected at most %d", ErrUnsupportedChunkVersion, name, c.version, version)
+	default:
+		return versioned.LoadStateVersion(r, c.version)
+	}
+}
+
+// Names returns the names of all chunks read, in sorted order
+func (cr *ChunkReader) Names() []string {
+	return slices.Sorted(maps.Keys(cr.chunks))
+}
+
+// Size returns the length of the named chunk in bytes
+func (cr *ChunkReader) Size(name string) int {
+	return len(cr.chunks[name].data)
+}
+
+// Version returns the version of the component the named chunk was written by
+func (cr *ChunkReader) Version(name string) uint8 {
+	return cr.chunks[name].version
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+
+	return len(p), nil
+}
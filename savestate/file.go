@@ -0,0 +1,125 @@
+package savestate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image/png"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// FILE_MAGIC identifies a .state file
+	FILE_MAGIC = "GOGOGBSS"
+
+	// FILE_VERSION is bumped for incompatible changes to the container format.
+	// Fields added to a component are handled by the chunks themselves. Version
+	// 2 added chunk versions, & version 1 files are read w/ all chunks as
+	// version 0.
+	FILE_VERSION uint16 = 2
+)
+
+var (
+	ErrNotStateFile       = errors.New("savestate: not a save state file")
+	ErrUnsupportedVersion = errors.New("savestate: unsupported format version")
+)
+
+// Metadata describes the console & cartridge a .state file was taken from. It
+// is stored at the start of the file, ahead of the state's chunks.
+type Metadata struct {
+	Version        uint16
+	Title          string
+	GlobalChecksum uint16
+	Model          string
+	Timestamp      time.Time
+	Thumbnail      []byte // PNG-encoded
+}
+
+// ReadMetadata reads & validates the file header, leaving r positioned at the
+// start of the state's chunks
+func ReadMetadata(r io.Reader) (Metadata, error) {
+	var meta Metadata
+
+	var magic [len(FILE_MAGIC)]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return meta, fmt.Errorf("%w: %w", ErrNotStateFile, err)
+	}
+
+	if string(magic[:]) != FILE_MAGIC {
+		return meta, ErrNotStateFile
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &meta.Version); err != nil {
+		return meta, fmt.Errorf("reading version: %w", err)
+	}
+
+	if meta.Version == 0 || meta.Version > FILE_VERSION {
+		return meta, fmt.Errorf("%w: %d", ErrUnsupportedVersion, meta.Version)
+	}
+
+	title, err := ReadBytes(r)
+	if err != nil {
+		return meta, fmt.Errorf("reading title: %w", err)
+	}
+	meta.Title = string(title)
+
+	if err := binary.Read(r, binary.LittleEndian, &meta.GlobalChecksum); err != nil {
+		return meta, fmt.Errorf("reading global checksum: %w", err)
+	}
+
+	model, err := ReadBytes(r)
+	if err != nil {
+		return meta, fmt.Errorf("reading model: %w", err)
+	}
+	meta.Model = string(model)
+
+	var timestamp int64
+	if err := binary.Read(r, binary.LittleEndian, &timestamp); err != nil {
+		return meta, fmt.Errorf("reading timestamp: %w", err)
+	}
+	meta.Timestamp = time.Unix(0, timestamp)
+
+	meta.Thumbnail, err = ReadBytes(r)
+	if err != nil {
+		return meta, fmt.Errorf("reading thumbnail: %w", err)
+	}
+
+	return meta, nil
+}
+
+// WriteMetadata writes the file header. The Version field is ignored, and
+// FILE_VERSION is always written.
+func WriteMetadata(w io.Writer, meta Metadata) error {
+	magic := [len(FILE_MAGIC)]byte([]byte(FILE_MAGIC))
+
+	if err := Write(w, magic, FILE_VERSION); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+
+	return Write(w,
+		[]byte(meta.Title),
+		meta.GlobalChecksum,
+		[]byte(meta.Model),
+		meta.Timestamp.UnixNano(),
+		meta.Thumbnail,
+	)
+}
+
+func (meta Metadata) DebugPrint(w io.Writer) {
+	fmt.Fprintf(w, "Format Version:	%d\n", meta.Version)
+	fmt.Fprintf(w, "Title:	%s\n", strings.TrimRight(meta.Title, "\x00"))
+	fmt.Fprintf(w, "Global Checksum:	0x%04X\n", meta.GlobalChecksum)
+	fmt.Fprintf(w, "Model:	%s\n", meta.Model)
+	fmt.Fprintf(w, "Timestamp:	%s\n", meta.Timestamp.Format(time.RFC3339))
+
+	if len(meta.Thumbnail) == 0 {
+		fmt.Fprintf(w, "Thumbnail:	None\n")
+	} else if config, err := png.DecodeConfig(bytes.NewReader(meta.Thumbnail)); err != nil {
+		fmt.Fprintf(w, "Thumbnail:	Invalid (%s)\n", err)
+	} else {
+		fmt.Fprintf(w, "Thumbnail:	%dx%d PNG (%d bytes)\n", config.Width, config.Height, len(meta.Thumbnail))
+	}
+}
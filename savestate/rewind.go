@@ -0,0 +1,131 @@
+package savestate
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrRewindBufferEmpty = errors.New("savestate: rewind buffer is empty")
+
+// RewindBuffer holds a history of snapshots within a memory budget. Only the
+// newest snapshot is kept as-is. Every older snapshot is stored as a
+// compressed XOR delta against the one after it. Consecutive snapshots differ
+// very little, so the deltas are mostly zeros and compress well.
+//
+// Once the budget is exceeded, the oldest snapshots are dropped to make room.
+type RewindBuffer struct {
+	budget int
+	size   int
+	latest []byte
+	deltas []rewindDelta // oldest first
+
+	compressed bytes.Buffer
+	compressor *flate.Writer
+}
+
+type rewindDelta struct {
+	size int // uncompressed size of the older snapshot
+	data []byte
+}
+
+func NewRewindBuffer(budget int) *RewindBuffer {
+	// Only fails for an invalid compression level
+	compressor, _ := flate.NewWriter(io.Discard, flate.BestSpeed)
+
+	return &RewindBuffer{
+		budget:     budget,
+		compressor: compressor,
+	}
+}
+
+// Len returns the number of snapshots held
+func (rb *RewindBuffer) Len() int {
+	if rb.latest == nil {
+		return 0
+	}
+
+	return len(rb.deltas) + 1
+}
+
+// Size returns the number of bytes held by snapshots
+func (rb *RewindBuffer) Size() int {
+	return rb.size
+}
+
+// Push adds a copy of state as the newest snapshot
+func (rb *RewindBuffer) Push(state []byte) error {
+	if rb.latest != nil {
+		// Turn the previous snapshot into its delta against this one
+		for i := range min(len(rb.latest), len(state)) {
+			rb.latest[i] ^= state[i]
+		}
+
+		rb.compressed.Reset()
+		rb.compressor.Reset(&rb.compressed)
+
+		if _, err := rb.compressor.Write(rb.latest); err != nil {
+			return fmt.Errorf("compressing snapshot: %w", err)
+		}
+
+		if err := rb.compressor.Close(); err != nil {
+			return fmt.Errorf("compressing snapshot: %w", err)
+		}
+
+		delta := rewindDelta{
+			size: len(rb.latest),
+			data: bytes.Clone(rb.compressed.Bytes()),
+		}
+		rb.deltas = append(rb.deltas, delta)
+		rb.size += len(delta.data) - len(rb.latest)
+	}
+
+	rb.latest = bytes.Clone(state)
+	rb.size += len(rb.latest)
+
+	for rb.size > rb.budget && len(rb.deltas) > 0 {
+		rb.size -= len(rb.deltas[0].data)
+		rb.deltas[0] = rewindDelta{}
+		rb.deltas = rb.deltas[1:]
+	}
+
+	return nil
+}
+
+// Pop removes & returns the newest snapshot
+func (rb *RewindBuffer) Pop() ([]byte, error) {
+	if rb.latest == nil {
+		return nil, ErrRewindBufferEmpty
+	}
+
+	state := rb.latest
+	rb.latest = nil
+	rb.size -= len(state)
+
+	if len(rb.deltas) == 0 {
+		return state, nil
+	}
+
+	delta := rb.deltas[len(rb.deltas)-1]
+	rb.deltas = rb.deltas[:len(rb.deltas)-1]
+	rb.size -= len(delta.data)
+
+	previous := make([]byte, delta.size)
+	decompressor := flate.NewReader(bytes.NewReader(delta.data))
+	defer decompressor.Close()
+
+	if _, err := io.ReadFull(decompressor, previous); err != nil {
+		return nil, fmt.Errorf("decompressing snapshot: %w", err)
+	}
+
+	for i := range min(len(previous), len(state)) {
+		previous[i] ^= state[i]
+	}
+
+	rb.latest = previous
+	rb.size += len(previous)
+
+	return state, nil
+}
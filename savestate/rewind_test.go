@@ -0,0 +1,70 @@
+package savestate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSnapshot(size int, value byte) []byte {
+	snapshot := make([]byte, size)
+	for i := range snapshot {
+		snapshot[i] = byte(i)
+	}
+	snapshot[size/2] = value
+
+	return snapshot
+}
+
+func TestRewindBuffer(t *testing.T) {
+	assert := assert.New(t)
+
+	snapshots := [][]byte{
+		testSnapshot(1024, 0xAA),
+		testSnapshot(1024, 0xBB),
+		testSnapshot(1030, 0xCC), // Snapshots may grow...
+		testSnapshot(1020, 0xDD), // ...or shrink
+	}
+
+	rewind := NewRewindBuffer(1 << 20)
+	for _, snapshot := range snapshots {
+		require.NoError(t, rewind.Push(snapshot))
+	}
+	assert.Equal(len(snapshots), rewind.Len())
+
+	// Deltas should be much smaller than the snapshots themselves
+	assert.Less(rewind.Size(), 2*1024)
+
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		snapshot, err := rewind.Pop()
+		require.NoError(t, err)
+		assert.Equalf(snapshots[i], snapshot, "snapshot %d does not match", i)
+	}
+
+	assert.Equal(0, rewind.Len())
+	assert.Equal(0, rewind.Size())
+
+	_, err := rewind.Pop()
+	assert.ErrorIs(err, ErrRewindBufferEmpty)
+}
+
+func TestRewindBufferBudget(t *testing.T) {
+	assert := assert.New(t)
+
+	rewind := NewRewindBuffer(1500)
+	for i := range 100 {
+		require.NoError(t, rewind.Push(testSnapshot(1024, byte(i))))
+		assert.LessOrEqual(rewind.Size(), 1500)
+	}
+	assert.Less(rewind.Len(), 100)
+
+	// The newest snapshots are kept
+	snapshot, err := rewind.Pop()
+	require.NoError(t, err)
+	assert.Equal(testSnapshot(1024, 99), snapshot)
+
+	snapshot, err = rewind.Pop()
+	require.NoError(t, err)
+	assert.Equal(testSnapshot(1024, 98), snapshot)
+}
@@ -0,0 +1,112 @@
+package savestate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrSizeMismatch = errors.New("savestate: size mismatch")
+
+// Serializer is implemented by every stateful component of the console, so
+// that the machine can be snapshotted & restored as a whole. Components are
+// expected to write & read their state in the same order, and to leave out
+// anything that's fixed by configuration (e.g. ROM contents)
+type Serializer interface {
+	SaveState(w io.Writer) error
+	LoadState(r io.Reader) error
+}
+
+// Versioned is implemented by components whose state has changed in a way that
+// can't be handled by adding trailing fields. Their chunks are written w/
+// StateVersion, & chunks from an older version are passed to LoadStateVersion
+// to migrate. Components that aren't Versioned are at version 0.
+type Versioned interface {
+	Serializer
+	StateVersion() uint8
+	LoadStateVersion(r io.Reader, version uint8) error
+}
+
+// Write encodes each field in order as little-endian binary. Fields may be
+// anything understood by encoding/binary, *uint & *int (widened to 64-bits),
+// or a []byte, which is written w/ its length.
+func Write(w io.Writer, fields ...any) error {
+	for i, field := range fields {
+		var err error
+
+		switch v := field.(type) {
+		case *uint:
+			err = binary.Write(w, binary.LittleEndian, uint64(*v))
+		case *int:
+			err = binary.Write(w, binary.LittleEndian, int64(*v))
+		case []byte:
+			err = binary.Write(w, binary.LittleEndian, uint32(len(v)))
+			if err == nil {
+				_, err = w.Write(v)
+			}
+		default:
+			err = binary.Write(w, binary.LittleEndian, v)
+		}
+
+		if err != nil {
+			return fmt.Errorf("writing field %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Read decodes each field in order, as written by Write. Fields must be
+// pointers, except for []byte, which is read in-place and must be the same
+// length as when it was written.
+func Read(r io.Reader, fields ...any) error {
+	for i, field := range fields {
+		var err error
+
+		switch v := field.(type) {
+		case *uint:
+			var value uint64
+			err = binary.Read(r, binary.LittleEndian, &value)
+			*v = uint(value)
+		case *int:
+			var value int64
+			err = binary.Read(r, binary.LittleEndian, &value)
+			*v = int(value)
+		case []byte:
+			var size uint32
+			err = binary.Read(r, binary.LittleEndian, &size)
+			if err == nil && int(size) != len(v) {
+				err = fmt.Errorf("%w: expected %d bytes, got %d", ErrSizeMismatch, len(v), size)
+			}
+			if err == nil {
+				_, err = io.ReadFull(r, v)
+			}
+		default:
+			err = binary.Read(r, binary.LittleEndian, v)
+		}
+
+		if err != nil {
+			return fmt.Errorf("reading field %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// ReadBytes reads a []byte of any length, as written by Write. Unlike Read,
+// the length prefix isn't trusted for allocating the slice up-front.
+func ReadBytes(r io.Reader) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, int64(size)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
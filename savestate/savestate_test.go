@@ -0,0 +1,152 @@
+package savestate
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadWrite(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		flag    = true
+		counter = uint(0x12345)
+		regs    = [2]uint8{0xAB, 0xCD}
+		ram     = []byte{1, 2, 3}
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, &flag, &counter, &regs, ram))
+
+	var (
+		loadedFlag    bool
+		loadedCounter uint
+		loadedRegs    [2]uint8
+		loadedRAM     = make([]byte, 3)
+	)
+
+	require.NoError(t, Read(&buf, &loadedFlag, &loadedCounter, &loadedRegs, loadedRAM))
+	assert.True(loadedFlag)
+	assert.Equal(counter, loadedCounter)
+	assert.Equal(regs, loadedRegs)
+	assert.Equal(ram, loadedRAM)
+}
+
+func TestReadSizeMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, []byte{1, 2, 3}))
+
+	err := Read(&buf, make([]byte, 4))
+	assert.ErrorIs(t, err, ErrSizeMismatch)
+}
+
+type testComponent struct {
+	a uint8
+	b uint16
+}
+
+func (c *testComponent) LoadState(r io.Reader) error {
+	return Read(r, &c.a, &c.b)
+}
+
+func (c *testComponent) SaveState(w io.Writer) error {
+	return Write(w, &c.a)
+}
+
+func TestChunks(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	chunks := NewChunkWriter(&buf)
+	require.NoError(t, chunks.WriteChunk("unknown", &testComponent{a: 0xFF}))
+	require.NoError(t, chunks.WriteChunk("component", &testComponent{a: 0x12, b: 0x3456}))
+
+	reader, err := ReadChunks(&buf)
+	require.NoError(t, err)
+	assert.Equal([]string{"component", "unknown"}, reader.Names())
+
+	// Fields missing from older chunks are restored as zero values
+	component := &testComponent{b: 0xFFFF}
+	require.NoError(t, reader.LoadChunk("component", component))
+	assert.Equal(uint8(0x12), component.a)
+	assert.Equal(uint16(0), component.b)
+
+	err = reader.LoadChunk("missing", component)
+	assert.ErrorIs(err, ErrMissingChunk)
+}
+
+// versionedComponent was at version 0 a single uint16, which became a uint8 at
+// version 1
+type versionedComponent struct {
+	testComponent
+	version uint8
+}
+
+func (c *versionedComponent) StateVersion() uint8 {
+	return c.version
+}
+
+func (c *versionedComponent) LoadStateVersion(r io.Reader, version uint8) error {
+	var old uint16
+	if err := Read(r, &old); err != nil {
+		return err
+	}
+	c.a = uint8(old)
+
+	return nil
+}
+
+func TestChunksVersioned(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	chunks := NewChunkWriter(&buf)
+	require.NoError(t, chunks.WriteChunk("old", &testComponent{a: 0x34}))
+	require.NoError(t, chunks.WriteChunk("current", &versionedComponent{testComponent: testComponent{a: 0x56}, version: 1}))
+	require.NoError(t, chunks.WriteChunk("newer", &versionedComponent{version: 2}))
+
+	reader, err := ReadChunks(&buf)
+	require.NoError(t, err)
+	assert.Equal(uint8(0), reader.Version("old"))
+	assert.Equal(uint8(1), reader.Version("current"))
+
+	component := &versionedComponent{version: 1}
+	require.NoError(t, reader.LoadChunk("current", component))
+	assert.Equal(uint8(0x56), component.a)
+
+	// Older chunks are migrated
+	component = &versionedComponent{version: 1}
+	require.NoError(t, reader.LoadChunk("old", component))
+	assert.Equal(uint8(0x34), component.a)
+
+	err = reader.LoadChunk("newer", component)
+	assert.ErrorIs(err, ErrUnsupportedChunkVersion)
+
+	err = reader.LoadChunk("current", &testComponent{})
+	assert.ErrorIs(err, ErrUnsupportedChunkVersion)
+}
+
+func TestChunksUnversioned(t *testing.T) {
+	assert := assert.New(t)
+
+	// As written before chunks were versioned
+	data := []byte{
+		0x04, 'c', 'h', 'n', 'k',
+		0x03, 0x00, 0x00, 0x00,
+		0x12, 0x56, 0x34,
+	}
+
+	reader, err := ReadChunks(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal([]string{"chnk"}, reader.Names())
+	assert.Equal(uint8(0), reader.Version("chnk"))
+
+	component := &testComponent{}
+	require.NoError(t, reader.LoadChunk("chnk", component))
+	assert.Equal(uint8(0x12), component.a)
+	assert.Equal(uint16(0x3456), component.b)
+}
@@ -0,0 +1,34 @@
+package scheduler
+
+// BaseClock counts cycles for components that run at the same rate regardless
+// of CPU speed, e.g. the PPU, APU & the cartridge's RTC. In double speed mode,
+// they only get one cycle for every two CPU cycles, w/ any odd cycle carried
+// over to the next step. Its fields are exported so that components can
+// include them in their save states.
+type BaseClock struct {
+	DoubleSpeed bool
+	Carry       uint
+}
+
+// Cycles returns how many base-speed cycles pass in cycles CPU cycles
+func (c *BaseClock) Cycles(cycles uint8) uint {
+	base := c.Carry + uint(cycles)
+	c.Carry = 0
+
+	if c.DoubleSpeed {
+		c.Carry = base % 2
+		base /= 2
+	}
+
+	return base
+}
+
+// Until returns how many CPU cycles it takes for base base-speed cycles to
+// pass, e.g. for a next event func
+func (c *BaseClock) Until(base uint) uint {
+	if !c.DoubleSpeed || base == 0 {
+		return base
+	}
+
+	return base*2 - c.Carry
+}
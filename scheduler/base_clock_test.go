@@ -0,0 +1,32 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseClock(t *testing.T) {
+	assert := assert.New(t)
+
+	var clock BaseClock
+	assert.Equal(uint(5), clock.Cycles(5))
+	assert.Equal(uint(10), clock.Until(10))
+
+	// Odd cycles are carried over to the next step
+	clock.DoubleSpeed = true
+	assert.Equal(uint(2), clock.Cycles(5))
+	assert.Equal(uint(1), clock.Carry)
+	assert.Equal(uint(19), clock.Until(10))
+	assert.Equal(uint(0), clock.Until(0))
+
+	assert.Equal(uint(3), clock.Cycles(5))
+	assert.Zero(clock.Carry)
+	assert.Equal(uint(20), clock.Until(10))
+
+	// Back at normal speed, a carried cycle's counted in full
+	clock.Cycles(1)
+	clock.DoubleSpeed = false
+	assert.Equal(uint(5), clock.Cycles(4))
+	assert.Zero(clock.Carry)
+}
@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"math"
+
+	"github.com/maxfierke/gogo-gb/mem"
+)
+
+// NEVER is returned by a task's next event func when nothing the rest of the
+// system can observe will happen until its registers are next accessed
+const NEVER uint = math.MaxUint
+
+// maxStepCycles is the most cycles a task is stepped by in one go
+const maxStepCycles = math.MaxUint8
+
+// Task is a component stepped by the scheduler. It's only stepped when its
+// next event is due, or when something's about to access its registers, at
+// which point it's caught up on all the cycles it missed.
+type Task struct {
+	step      func(cycles uint8)
+	nextEvent func() uint
+
+	syncedAt uint64 // Timestamp the task was last stepped up to
+	dueAt    uint64 // Timestamp of the task's next event
+}
+
+// Scheduler keeps track of the time in cycles, & steps each task when its next
+// event is due, rather than after every instruction
+type Scheduler struct {
+	now   uint64
+	due   uint64 // Timestamp of the earliest event of any task
+	tasks []*Task
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// AddTask adds a component to be stepped by step. nextEvent returns how many
+// cycles from now the component next does something observable, like
+// requesting an interrupt, or NEVER. It's fine for it to be early.
+func (s *Scheduler) AddTask(step func(cycles uint8), nextEvent func() uint) *Task {
+	task := &Task{
+		step:      step,
+		nextEvent: nextEvent,
+		syncedAt:  s.now,
+	}
+	s.tasks = append(s.tasks, task)
+	s.schedule(task)
+
+	return task
+}
+
+// Advance moves time forward by cycles, stepping any tasks which become due
+func (s *Scheduler) Advance(cycles uint8) {
+	s.now += uint64(cycles)
+
+	for s.due <= s.now {
+		for _, task := range s.tasks {
+			if task.dueAt <= s.now {
+				s.Sync(task)
+			}
+		}
+	}
+}
+
+// Now returns the number of cycles since the scheduler was created
+func (s *Scheduler) Now() uint64 {
+	return s.now
+}
+
+// Reset reschedules every task from now, w/o stepping them. Used after tasks'
+// state has been replaced wholesale, like when loading a save state.
+func (s *Scheduler) Reset() {
+	for _, task := range s.tasks {
+		task.syncedAt = s.now
+		s.schedule(task)
+	}
+}
+
+// Sync steps task up to now, then reschedules its next event
+func (s *Scheduler) Sync(task *Task) {
+	if task.syncedAt == s.now && task.dueAt > s.now {
+		return
+	}
+
+	for task.syncedAt < s.now {
+		// Step no further than the next event, so that each step sees at most one
+		cycles := min(s.now-task.syncedAt, uint64(task.nextEvent()), maxStepCycles)
+		cycles = max(cycles, 1)
+
+		task.syncedAt += cycles
+		task.step(uint8(cycles))
+	}
+
+	s.schedule(task)
+}
+
+// SyncAll steps every task up to now
+func (s *Scheduler) SyncAll() {
+	for _, task := range s.tasks {
+		s.Sync(task)
+	}
+}
+
+// Handler wraps a task's memory handler, so that the task is synced before
+// its registers are accessed, & rescheduled after they're written
+func (s *Scheduler) Handler(task *Task, handler mem.MemHandler) mem.MemHandler {
+	return &syncHandler{scheduler: s, task: task, handler: handler, syncReads: true}
+}
+
+// WriteHandler is like Handler, but only syncs on writes. Used for regions
+// which are read constantly, but where reads don't depend on the task's
+// timing, like cartridge ROM.
+func (s *Scheduler) WriteHandler(task *Task, handler mem.MemHandler) mem.MemHandler {
+	return &syncHandler{scheduler: s, task: task, handler: handler}
+}
+
+// invalidate reschedules task at the next opportunity, once whatever changed
+// its state has taken effect
+func (s *Scheduler) invalidate(task *Task) {
+	task.dueAt = s.now
+	s.due = s.now
+}
+
+func (s *Scheduler) schedule(task *Task) {
+	if next := task.nextEvent(); next == NEVER || uint64(next) > math.MaxUint64-s.now {
+		task.dueAt = math.MaxUint64
+	} else {
+		task.dueAt = s.now + uint64(max(next, 1))
+	}
+
+	s.due = math.MaxUint64
+	for _, t := range s.tasks {
+		s.due = min(s.due, t.dueAt)
+	}
+}
+
+type syncHandler struct {
+	scheduler *Scheduler
+	task      *Task
+	handler   mem.MemHandler
+	syncReads bool
+}
+
+var _ mem.MemHandler = (*syncHandler)(nil)
+
+func (h *syncHandler) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
+	if h.syncReads {
+		h.scheduler.Sync(h.task)
+	}
+
+	return h.handler.OnRead(mmu, addr)
+}
+
+func (h *syncHandler) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
+	h.scheduler.Sync(h.task)
+	result := h.handler.OnWrite(mmu, addr, value)
+	h.scheduler.invalidate(h.task)
+
+	return result
+}
@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/maxfierke/gogo-gb/mem"
+	"github.com/stretchr/testify/assert"
+)
+
+// countdown fires an event every period cycles, recording each step
+type countdown struct {
+	period  uint
+	elapsed uint
+	fired   int
+	steps   []uint8
+}
+
+func (c *countdown) NextEvent() uint {
+	if c.period == 0 {
+		return NEVER
+	}
+
+	return c.period - c.elapsed%c.period
+}
+
+func (c *countdown) OnRead(mmu *mem.MMU, addr uint16) mem.MemRead {
+	return mem.ReadReplace(byte(c.elapsed))
+}
+
+func (c *countdown) OnWrite(mmu *mem.MMU, addr uint16, value byte) mem.MemWrite {
+	c.period = uint(value)
+	c.elapsed = 0
+
+	return mem.WriteBlock()
+}
+
+func (c *countdown) Step(cycles uint8) {
+	c.steps = append(c.steps, cycles)
+
+	for range cycles {
+		c.elapsed++
+		if c.period != 0 && c.elapsed%c.period == 0 {
+			c.fired++
+		}
+	}
+}
+
+func TestSchedulerStepsWhenDue(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewScheduler()
+	c := &countdown{period: 10}
+	s.AddTask(c.Step, c.NextEvent)
+
+	s.Advance(4)
+	s.Advance(4)
+	assert.Empty(c.steps)
+
+	// Stepped up to the event, then on to now
+	s.Advance(4)
+	assert.Equal([]uint8{10, 2}, c.steps)
+	assert.Equal(1, c.fired)
+
+	for range 5 {
+		s.Advance(4)
+	}
+	assert.Equal(3, c.fired)
+	assert.Equal(uint(32), c.elapsed)
+	assert.Equal(uint64(32), s.Now())
+}
+
+func TestSchedulerNeverDue(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewScheduler()
+	c := &countdown{}
+	task := s.AddTask(c.Step, c.NextEvent)
+
+	for range 100 {
+		s.Advance(4)
+	}
+	assert.Empty(c.steps)
+
+	// Caught up in as few steps as possible
+	s.Sync(task)
+	assert.Equal([]uint8{255, 145}, c.steps)
+	assert.Equal(uint(400), c.elapsed)
+}
+
+func TestSchedulerHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewScheduler()
+	c := &countdown{}
+	task := s.AddTask(c.Step, c.NextEvent)
+
+	mmu := mem.NewMMU(make([]byte, 0x10000))
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF00, End: 0xFF00}, s.Handler(task, c))
+
+	s.Advance(8)
+	assert.Equal(byte(8), mmu.Read8(0xFF00))
+
+	// Writes change when the next event is, so it's rescheduled
+	mmu.Write8(0xFF00, 6)
+	s.Advance(4)
+	assert.Equal(0, c.fired)
+	s.Advance(4)
+	assert.Equal(1, c.fired)
+	assert.Equal(uint(8), c.elapsed)
+}
+
+func TestSchedulerWriteHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewScheduler()
+	c := &countdown{}
+	task := s.AddTask(c.Step, c.NextEvent)
+
+	mmu := mem.NewMMU(make([]byte, 0x10000))
+	mmu.AddHandler(mem.MemRegion{Start: 0xFF00, End: 0xFF00}, s.WriteHandler(task, c))
+
+	s.Advance(8)
+	assert.Equal(byte(0), mmu.Read8(0xFF00))
+
+	mmu.Write8(0xFF00, 0)
+	assert.Equal([]uint8{8}, c.steps)
+}